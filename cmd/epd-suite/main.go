@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dyxj/chess/pkg/search"
+)
+
+func main() {
+	file := flag.String("file", "", "path to EPD suite file")
+	moveTime := flag.Duration("movetime", time.Second, "search time limit per position")
+	depth := flag.Int("depth", 0, "search depth limit per position, 0 for no limit")
+	out := flag.String("out", "", "path to write report, defaults to stdout")
+
+	flag.Parse()
+
+	if *file == "" {
+		fmt.Println("file flag is required")
+		os.Exit(2)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		fmt.Printf("failed to open suite: %v\n", err)
+		os.Exit(1)
+	}
+	defer func() { _ = f.Close() }()
+
+	name := strings.TrimSuffix(filepath.Base(*file), filepath.Ext(*file))
+	report, err := search.RunSuite(name, f, search.Limits{Depth: *depth, MoveTime: *moveTime})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	w := os.Stdout
+	if *out != "" {
+		w, err = os.Create(*out)
+		if err != nil {
+			fmt.Printf("failed to create report: %v\n", err)
+			os.Exit(1)
+		}
+		defer func() { _ = w.Close() }()
+	}
+
+	if err := report.WriteText(w); err != nil {
+		fmt.Printf("failed to write report: %v\n", err)
+		os.Exit(1)
+	}
+}
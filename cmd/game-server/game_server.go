@@ -35,11 +35,26 @@ func main() {
 
 	memCache := store.NewMemCache()
 
+	adjournStore, err := room.NewAdjournFileStore(cfg.RoomConfig.AdjournDir())
+	if err != nil {
+		log.Panicf("failed to initialize adjourn store: %v", err)
+	}
+
+	correspondenceStore, err := room.NewCorrespondenceFileStore(cfg.RoomConfig.CorrespondenceDir())
+	if err != nil {
+		log.Panicf("failed to initialize correspondence store: %v", err)
+	}
+
 	coordinator := room.NewCoordinator(
 		logger, 30*time.Second,
 		room.NewMemCache(memCache),
+		adjournStore,
+	)
+	correspondence := room.NewCorrespondence(
+		logger,
+		correspondenceStore,
 	)
-	router := server.BuildRouter(logger, coordinator, cfg.HTTPServerConfig.CORSEnabled())
+	router := server.BuildRouter(logger, coordinator, correspondence, cfg.HTTPServerConfig.CORSEnabled())
 	errSig := httpServer.Run(router)
 
 	select {
@@ -100,12 +100,11 @@ func (a *Adapter) processInput(input string) error {
 	}
 
 	if input == inputUndo {
-		hasUndo := a.g.UndoLastMove()
-		if hasUndo {
-			a.write("undo successful")
-		} else {
-			a.write("no moves to undo")
+		err := a.g.UndoLastMove()
+		if err != nil {
+			return err
 		}
+		a.write("undo successful")
 		return nil
 	}
 
@@ -122,7 +121,7 @@ func (a *Adapter) writeIntro() {
 }
 
 func (a *Adapter) gameOver() {
-	if a.g.State() == game.StateDraw || a.g.State() == game.StateStalemate {
+	if a.g.IsDraw() {
 		a.write(fmt.Sprintf("Game ended in a %v", a.g.State().String()))
 		return
 	}
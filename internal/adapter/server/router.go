@@ -25,17 +25,26 @@ func corsMiddleware(next http.Handler) http.Handler {
 func BuildRouter(
 	logger *zap.Logger,
 	coordinator *room.Coordinator,
+	correspondence *room.Correspondence,
 	corsEnabled bool,
 ) http.Handler {
 	mux := http.NewServeMux()
 
 	roomCreateHandler,
 		roomJoinHandler,
+		roomResumeHandler,
 		roomConnectHandler := setupRoomRoutes(logger, coordinator)
 
 	mux.Handle("POST /room", roomCreateHandler)
 	mux.Handle("POST /room/{code}/join", roomJoinHandler)
+	mux.Handle("POST /room/{code}/resume", roomResumeHandler)
 	mux.Handle("GET /room/connect", roomConnectHandler)
+	mux.Handle("GET /room/{code}/analysis", room.NewAnalysisHandler(logger, coordinator))
+
+	mux.Handle("POST /correspondence", room.NewCorrespondenceCreateHandler(logger, correspondence))
+	mux.Handle("POST /correspondence/{code}/join", room.NewCorrespondenceJoinHandler(logger, correspondence))
+	mux.Handle("POST /correspondence/{code}/move", room.NewCorrespondenceMoveHandler(logger, correspondence))
+	mux.Handle("GET /correspondence/{code}", room.NewCorrespondenceViewHandler(logger, correspondence))
 
 	if corsEnabled {
 		return corsMiddleware(mux)
@@ -46,13 +55,14 @@ func BuildRouter(
 func setupRoomRoutes(
 	logger *zap.Logger,
 	coordinator *room.Coordinator,
-) (*room.CreateHandler, *room.JoinHandler, *room.ConnectHandler) {
+) (*room.CreateHandler, *room.JoinHandler, *room.ResumeHandler, *room.ConnectHandler) {
 
 	creatorHandler := room.NewCreateHandler(logger, coordinator)
 	joinHandler := room.NewJoinHandler(logger, coordinator)
+	resumeHandler := room.NewResumeHandler(logger, coordinator)
 	connectHandler := room.NewConnectHandler(logger, coordinator)
 
-	return creatorHandler, joinHandler, connectHandler
+	return creatorHandler, joinHandler, resumeHandler, connectHandler
 }
 
 /*
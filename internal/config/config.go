@@ -6,6 +6,7 @@ import (
 
 type Config struct {
 	HTTPServerConfig *HTTPServerConfig `env:",init"`
+	RoomConfig       *RoomConfig       `env:",init"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -0,0 +1,16 @@
+package config
+
+type RoomConfig struct {
+	AdjournDirEV        string `env:"ADJOURN_DIR" envDefault:"data/adjourned"`
+	CorrespondenceDirEV string `env:"CORRESPONDENCE_DIR" envDefault:"data/correspondence"`
+}
+
+// AdjournDir directory adjourned games are saved to
+func (c *RoomConfig) AdjournDir() string {
+	return c.AdjournDirEV
+}
+
+// CorrespondenceDir directory correspondence games are saved to
+func (c *RoomConfig) CorrespondenceDir() string {
+	return c.CorrespondenceDirEV
+}
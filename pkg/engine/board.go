@@ -2,6 +2,7 @@ package engine
 
 import (
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
 )
@@ -74,6 +75,20 @@ type Board struct {
 	graveyard              []Piece
 	drawCounter            int
 	boardStateHashMapCount map[uint64]int
+	// startLastMove move implied by the en passant square of a loaded position,
+	// only consulted while roundHistory is empty
+	startLastMove Move
+	// startCastling castling field of a loaded position, only consulted while roundHistory is empty.
+	// Rights without a king and rook on their start squares are dropped when loading.
+	startCastling string
+	// startPly number of half moves played before a loaded position
+	startPly int
+	// invariantChecks check invariants after every ApplyMove and UndoLastMove
+	invariantChecks bool
+	variant         Variant
+	// whitePocket, blackPocket captured pieces available to drop, VariantCrazyhouse and VariantBughouse
+	whitePocket pocket
+	blackPocket pocket
 }
 
 // NewBoard creates a new chess board with the initial pieces.
@@ -113,6 +128,7 @@ func NewEmptyBoard(activeColor ...Color) *Board {
 		activeColor:            ac,
 		drawCounter:            0,
 		boardStateHashMapCount: make(map[uint64]int, 256),
+		invariantChecks:        debugInvariants,
 	}
 	return b
 }
@@ -230,6 +246,12 @@ func (b *Board) Symbol(pos int) Symbol {
 }
 
 func (b *Board) IsCheck(color Color) bool {
+	if !b.variant.hasCheck() {
+		return false
+	}
+	if b.variant == VariantAtomic && b.kingsAdjacent() {
+		return false
+	}
 	kPos := b.kingPosition(color)
 	// no king on board, for playground boards
 	if kPos == 0 {
@@ -238,6 +260,14 @@ func (b *Board) IsCheck(color Color) bool {
 	return b.isUnderAttack(b.kingPosition(color), color)
 }
 
+// Checkers positions of the opponent pieces giving check to color, nil if not in check.
+func (b *Board) Checkers(color Color) []int {
+	if !b.IsCheck(color) {
+		return nil
+	}
+	return b.attackers(b.kingPosition(color), color)
+}
+
 // isUnderAttack check if position is under attack by opponent pieces
 // using a backward approach.
 func (b *Board) isUnderAttack(pos int, defender Color) bool {
@@ -277,6 +307,51 @@ func (b *Board) isUnderAttack(pos int, defender Color) bool {
 	return false
 }
 
+// attackers returns positions of all opponent pieces attacking pos,
+// same backward approach as isUnderAttack without the early exit.
+func (b *Board) attackers(pos int, defender Color) []int {
+	return b.Attackers(pos, defender.Opposite())
+}
+
+// Attackers positions of the pieces of attacker attacking pos, whichever piece is on pos.
+// Pieces of attacker on pos defend it, pins and en passant are not considered.
+func (b *Board) Attackers(pos int, attacker Color) []int {
+	defender := attacker.Opposite()
+	positions := make([]int, 0, 2)
+
+	for i, direction := range directionCircle {
+		attackerPos := pos + int(direction)
+		for ; !b.IsSentinel(attackerPos) && b.IsEmpty(attackerPos); attackerPos += int(direction) {
+		}
+		if b.IsSentinel(attackerPos) || b.Color(attackerPos) != attacker {
+			continue
+		}
+		if slices.Contains(slidingMoversByDirectionCircleIndex[i], b.Symbol(attackerPos)) {
+			positions = append(positions, attackerPos)
+		}
+	}
+
+	for _, direction := range pieceDirections[Knight] {
+		if b.isUnderAttackByFixDirection(pos, direction, attacker, Knight) {
+			positions = append(positions, pos+int(direction))
+		}
+	}
+
+	for _, direction := range pieceDirections[King] {
+		if b.isUnderAttackByFixDirection(pos, direction, attacker, King) {
+			positions = append(positions, pos+int(direction))
+		}
+	}
+
+	for _, direction := range pawnCaptureDirections(defender) {
+		if b.isUnderAttackByFixDirection(pos, direction, attacker, Pawn) {
+			positions = append(positions, pos+int(direction))
+		}
+	}
+
+	return positions
+}
+
 func (b *Board) isUnderAttackBySlider(pos int, direction Direction, attacker Color, symbols []Symbol) bool {
 	dInt := int(direction)
 	attackerPos := pos + dInt
@@ -367,14 +442,24 @@ func (b *Board) ApplyMove(m Move) error {
 
 	b.applyMoveToPieceList(m)
 
+	if b.variant == VariantAtomic && m.hasCaptured() {
+		r.Exploded = b.explode(m.To)
+	}
+
 	b.setDrawCounter(m)
 
 	b.activeColor = b.activeColor.Opposite()
 
+	if b.variant == VariantThreeCheck {
+		r.Check = b.IsCheck(b.activeColor)
+	}
+
 	r.BoardStateHash = b.calculateBoardStateHash(m, b.activeColor)
 	b.boardStateHashMapCount[r.BoardStateHash]++
 
 	b.addRoundToHistory(r)
+
+	b.mustCheckInvariants("ApplyMove")
 	return nil
 }
 
@@ -382,6 +467,9 @@ func (b *Board) validateMoveToApply(m Move) error {
 	if m.Color != b.activeColor {
 		return ErrNotActiveColor
 	}
+	if m.IsDrop {
+		return b.validateDropToApply(m)
+	}
 	if m.From > len(b.cells)-1 || m.To > len(b.cells)-1 {
 		return ErrOutOfBoard
 	}
@@ -406,6 +494,8 @@ func (b *Board) UndoLastMove() (ok bool) {
 		return false
 	}
 
+	b.undoExplode(r.Exploded)
+
 	b.undoMovePos(r.Move)
 
 	b.undoMovePieceList(r.Move)
@@ -418,10 +508,15 @@ func (b *Board) UndoLastMove() (ok bool) {
 
 	b.removeLastRoundFromHistory()
 
+	b.mustCheckInvariants("UndoLastMove")
 	return true
 }
 
 func (b *Board) applyMovePos(m Move) {
+	if m.IsDrop {
+		b.cells[m.To] = boardSymbolMove(m)
+		return
+	}
 	if m.IsEnPassant {
 		b.applyEnPassantMovePos(m)
 		return
@@ -445,6 +540,10 @@ func (b *Board) applyMovePos(m Move) {
 }
 
 func (b *Board) undoMovePos(m Move) {
+	if m.IsDrop {
+		b.cells[m.To] = EmptyCell
+		return
+	}
 	if m.IsEnPassant {
 		b.undoEnPassantMovePos(m)
 		return
@@ -484,6 +583,12 @@ func (b *Board) applyMoveToPieceList(m Move) {
 
 	pp := b.Pieces(m.Color)
 
+	if m.IsDrop {
+		b.pocket(m.Color)[m.Symbol]--
+		b.setPieces(m.Color, append(pp, newDroppedPiece(m)))
+		return
+	}
+
 	if m.hasCaptured() {
 		xColor := m.Color.Opposite()
 		xpp := b.Pieces(xColor)
@@ -496,6 +601,13 @@ func (b *Board) applyMoveToPieceList(m Move) {
 		// Remove captured piece
 		for i := 0; i < len(xpp); i++ {
 			if xpp[i].symbol == m.Captured && xpp[i].position == capturedPos {
+				if b.variant == VariantCrazyhouse {
+					b.pocket(m.Color)[xpp[i].PocketSymbol()]++
+				}
+				// only reachable in variants where the king is not protected from check
+				if xpp[i].symbol == King && b.kingPosition(xColor) == capturedPos {
+					b.setKingPosition(xColor, 0)
+				}
 				b.graveyard = append(b.graveyard, xpp[i])
 				xpp = slices.Delete(xpp, i, i+1)
 				break
@@ -510,6 +622,7 @@ func (b *Board) applyMoveToPieceList(m Move) {
 		for i := 0; i < len(pp); i++ {
 			if pp[i].symbol == m.Symbol && pp[i].position == m.From {
 				pp[i] = NewPiece(m.Promotion, m.Color, m.To, true)
+				pp[i].promoted = true
 				break
 			}
 		}
@@ -545,6 +658,18 @@ func (b *Board) applyMoveToPieceList(m Move) {
 func (b *Board) undoMovePieceList(m Move) {
 	pp := b.Pieces(m.Color)
 
+	if m.IsDrop {
+		for i := 0; i < len(pp); i++ {
+			if pp[i].symbol == m.Symbol && pp[i].position == m.To {
+				pp = slices.Delete(pp, i, i+1)
+				break
+			}
+		}
+		b.setPieces(m.Color, pp)
+		b.pocket(m.Color)[m.Symbol]++
+		return
+	}
+
 	if m.hasCaptured() {
 		capturedPiece, hasCaptured := b.popGraveyard()
 		if hasCaptured {
@@ -552,6 +677,12 @@ func (b *Board) undoMovePieceList(m Move) {
 				// board out of sync, programmer error
 				panic("last graveyard symbol does not match move symbol")
 			}
+			if b.variant == VariantCrazyhouse {
+				b.pocket(m.Color)[capturedPiece.PocketSymbol()]--
+			}
+			if capturedPiece.symbol == King && b.kingPosition(capturedPiece.color) == 0 {
+				b.setKingPosition(capturedPiece.color, capturedPiece.position)
+			}
 			xpp := b.Pieces(capturedPiece.Color())
 			xpp = append(xpp, capturedPiece)
 
@@ -619,6 +750,47 @@ func (b *Board) LastMove() (move Move, found bool) {
 	return round.Move, true
 }
 
+// History moves played from the starting position in order.
+func (b *Board) History() []Move {
+	moves := make([]Move, len(b.roundHistory))
+	for i, r := range b.roundHistory {
+		moves[i] = r.Move
+	}
+	return moves
+}
+
+// clone deep copy of the board, exploded pieces of the history are shared as they are never modified.
+func (b *Board) clone() *Board {
+	c := *b
+	c.whitePieces = slices.Clone(b.whitePieces)
+	c.blackPieces = slices.Clone(b.blackPieces)
+	c.roundHistory = slices.Clone(b.roundHistory)
+	c.graveyard = slices.Clone(b.graveyard)
+	c.boardStateHashMapCount = maps.Clone(b.boardStateHashMapCount)
+	return &c
+}
+
+// LastCaptured piece captured by the last move.
+func (b *Board) LastCaptured() (Piece, bool) {
+	move, found := b.LastMove()
+	if !found || !move.hasCaptured() || len(b.graveyard) == 0 {
+		return Piece{}, false
+	}
+	return b.graveyard[len(b.graveyard)-1], true
+}
+
+// Captured pieces of color c captured so far in capture order,
+// pieces removed by atomic explosions are not captured.
+func (b *Board) Captured(c Color) []Piece {
+	captured := make([]Piece, 0, len(b.graveyard))
+	for _, p := range b.graveyard {
+		if p.color == c {
+			captured = append(captured, p)
+		}
+	}
+	return captured
+}
+
 func (b *Board) Is3FoldDraw() bool {
 	move, found := b.LastMove()
 	if !found {
@@ -646,6 +818,27 @@ func (b *Board) Is100MoveDraw() bool {
 	return b.drawCounter >= 100
 }
 
+// IsInsufficientMaterial color cannot checkmate by any sequence of moves.
+// True for a lone king, or a king and a single knight or bishop against a lone king.
+// Positions that need the opponent's pieces to block their own king,
+// such as king and bishop against king and same colored bishop, are not considered.
+func (b *Board) IsInsufficientMaterial(c Color) bool {
+	minors := 0
+	for _, p := range b.Pieces(c) {
+		switch p.symbol {
+		case King:
+		case Knight, Bishop:
+			minors++
+		default:
+			return false
+		}
+	}
+	if minors == 0 {
+		return true
+	}
+	return minors == 1 && len(b.Pieces(c.Opposite())) == 1
+}
+
 func (b *Board) popGraveyard() (Piece, bool) {
 	if len(b.graveyard) == 0 {
 		return Piece{}, false
@@ -116,6 +116,87 @@ func TestLoadPiecesError(t *testing.T) {
 	}
 }
 
+func TestBoard_IsInsufficientMaterial(t *testing.T) {
+	tt := []struct {
+		name     string
+		fen      string
+		color    Color
+		expected bool
+	}{
+		{name: "lone king", fen: "4k3/8/8/8/8/8/8/Q3K3 w - - 0 1", color: Black, expected: true},
+		{name: "knight against lone king", fen: "4k3/8/8/8/8/8/8/N3K3 b - - 0 1", color: White, expected: true},
+		{name: "bishop against lone king", fen: "4k3/8/8/8/8/8/8/B3K3 b - - 0 1", color: White, expected: true},
+		{name: "knight against pawn", fen: "4k3/7p/8/8/8/8/8/N3K3 b - - 0 1", color: White, expected: false},
+		{name: "two knights", fen: "4k3/8/8/8/8/8/8/NN2K3 b - - 0 1", color: White, expected: false},
+		{name: "pawn", fen: "4k3/8/8/8/8/8/P7/4K3 b - - 0 1", color: White, expected: false},
+		{name: "queen", fen: "4k3/8/8/8/8/8/8/Q3K3 w - - 0 1", color: White, expected: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := NewBoardFromFEN(tc.fen)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, b.IsInsufficientMaterial(tc.color))
+		})
+	}
+}
+
+func TestBoard_Captured(t *testing.T) {
+	b, err := NewBoardFromFEN("r1k5/1P6/8/8/8/8/8/4K3 w - - 0 1")
+	assert.NoError(t, err)
+
+	for _, san := range []string{"bxa8=Q+", "Kc7", "Qb7+", "Kxb7"} {
+		m, err := b.ParseSAN(san)
+		assert.NoError(t, err)
+		assert.NoError(t, b.ApplyMove(m))
+	}
+
+	white := b.Captured(White)
+	assert.Len(t, white, 1)
+	assert.Equal(t, Queen, white[0].Symbol())
+	assert.True(t, white[0].Promoted())
+	black := b.Captured(Black)
+	assert.Len(t, black, 1)
+	assert.Equal(t, Rook, black[0].Symbol())
+
+	assert.True(t, b.UndoLastMove())
+	assert.Empty(t, b.Captured(White))
+	assert.Len(t, b.Captured(Black), 1)
+}
+
+func TestBoard_Checkers(t *testing.T) {
+	tt := []struct {
+		name     string
+		fen      string
+		variant  Variant
+		expected []int
+	}{
+		{name: "not in check", fen: StartFEN},
+		{name: "rook", fen: "4k3/8/8/8/8/8/8/4R1K1 b - - 0 1", expected: []int{25}},
+		{name: "double check", fen: "4k3/8/3N4/8/8/8/8/4R1K1 b - - 0 1", expected: []int{25, 74}},
+		{name: "pawn", fen: "4k3/3P4/8/8/8/8/8/6K1 b - - 0 1", expected: []int{84}},
+		{name: "antichess has no check", fen: "4k3/8/8/8/8/8/8/4R1K1 b - - 0 1", variant: VariantAntichess},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := NewBoardFromFEN(tc.fen)
+			assert.NoError(t, err)
+			if tc.variant != 0 {
+				b.SetVariant(tc.variant)
+			}
+			assert.Equal(t, tc.expected, b.Checkers(b.ActiveColor()))
+		})
+	}
+}
+
+func TestSymbol_Value(t *testing.T) {
+	values := map[Symbol]int{Pawn: 1, Knight: 3, Bishop: 3, Rook: 5, Queen: 9, King: 0}
+	for s, v := range values {
+		assert.Equal(t, v, s.Value(), s.String())
+	}
+}
+
 func genTestBoard() *Board {
 	return &Board{
 		cells: [120]int{
@@ -0,0 +1,7 @@
+//go:build !chessdebug
+
+package engine
+
+// debugInvariants build with -tags chessdebug to check board invariants
+// after every ApplyMove, UndoLastMove and GobDecode.
+const debugInvariants = false
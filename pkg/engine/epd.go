@@ -0,0 +1,155 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EPD Extended Position Description, a FEN without move counters followed by
+// operations, e.g.
+//
+//	r1bqkb1r/pppp1ppp/2n2n2/4p2Q/2B1P3/8/PPPP1PPP/RNB1K1NR w KQkq - bm Qxf7#; id "mate.001";
+type EPD struct {
+	// Position the first four FEN fields
+	Position   string
+	Operations []EPDOperation
+}
+
+type EPDOperation struct {
+	Opcode   string
+	Operands []string
+}
+
+// ParseEPD parses a single EPD record.
+func ParseEPD(line string) (EPD, error) {
+	rest := strings.TrimSpace(line)
+	fields := make([]string, 0, 4)
+	for len(fields) < 4 {
+		rest = strings.TrimLeft(rest, " \t")
+		if rest == "" {
+			return EPD{}, fmt.Errorf("%w: expected 4 position fields", ErrInvalidEPD)
+		}
+		end := strings.IndexAny(rest, " \t")
+		if end < 0 {
+			end = len(rest)
+		}
+		fields = append(fields, rest[:end])
+		rest = rest[end:]
+	}
+
+	ops, err := parseEPDOperations(rest)
+	if err != nil {
+		return EPD{}, err
+	}
+
+	e := EPD{
+		Position:   strings.Join(fields, " "),
+		Operations: ops,
+	}
+
+	// validate position early so callers get errors at parse time
+	if _, err := NewBoardFromFEN(e.Position); err != nil {
+		return EPD{}, fmt.Errorf("%w: %w", ErrInvalidEPD, err)
+	}
+
+	return e, nil
+}
+
+func parseEPDOperations(s string) ([]EPDOperation, error) {
+	ops := make([]EPDOperation, 0, 4)
+	var current *EPDOperation
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == ';':
+			if current == nil {
+				return nil, fmt.Errorf("%w: empty operation", ErrInvalidEPD)
+			}
+			ops = append(ops, *current)
+			current = nil
+			i++
+		case c == '"':
+			if current == nil {
+				return nil, fmt.Errorf("%w: operand without opcode", ErrInvalidEPD)
+			}
+			end := strings.IndexByte(s[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated string operand", ErrInvalidEPD)
+			}
+			current.Operands = append(current.Operands, s[i+1:i+1+end])
+			i += end + 2
+		default:
+			end := strings.IndexAny(s[i:], " \t;")
+			if end < 0 {
+				end = len(s) - i
+			}
+			token := s[i : i+end]
+			if current == nil {
+				current = &EPDOperation{Opcode: token}
+			} else {
+				current.Operands = append(current.Operands, token)
+			}
+			i += end
+		}
+	}
+
+	if current != nil {
+		return nil, fmt.Errorf("%w: operation %q is not terminated by ';'", ErrInvalidEPD, current.Opcode)
+	}
+
+	return ops, nil
+}
+
+// Operation returns operands of the first operation matching opcode.
+func (e EPD) Operation(opcode string) ([]string, bool) {
+	for _, op := range e.Operations {
+		if op.Opcode == opcode {
+			return op.Operands, true
+		}
+	}
+	return nil, false
+}
+
+// ID returns the "id" operand or an empty string.
+func (e EPD) ID() string {
+	operands, ok := e.Operation("id")
+	if !ok || len(operands) == 0 {
+		return ""
+	}
+	return operands[0]
+}
+
+// Board creates a board from the position, applying "hmvc" and "fmvn" operations if present.
+func (e EPD) Board() (*Board, error) {
+	halfmove, fullmove := "0", "1"
+	if operands, ok := e.Operation("hmvc"); ok && len(operands) > 0 {
+		halfmove = operands[0]
+	}
+	if operands, ok := e.Operation("fmvn"); ok && len(operands) > 0 {
+		fullmove = operands[0]
+	}
+	return NewBoardFromFEN(e.Position + " " + halfmove + " " + fullmove)
+}
+
+// String formats the record back into a single EPD line.
+func (e EPD) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(e.Position)
+	for _, op := range e.Operations {
+		sb.WriteByte(' ')
+		sb.WriteString(op.Opcode)
+		for _, operand := range op.Operands {
+			sb.WriteByte(' ')
+			if strings.ContainsAny(operand, " \t;") || operand == "" {
+				sb.WriteString(`"` + operand + `"`)
+			} else {
+				sb.WriteString(operand)
+			}
+		}
+		sb.WriteByte(';')
+	}
+	return sb.String()
+}
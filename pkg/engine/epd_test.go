@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEPD(t *testing.T) {
+	line := `2rr3k/pp3pp1/1nnqbN1p/3pN3/2pP4/2P3Q1/PPB4P/R4RK1 w - - bm Qg6; id "WAC.001"; c0 "two words";`
+
+	e, err := ParseEPD(line)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2rr3k/pp3pp1/1nnqbN1p/3pN3/2pP4/2P3Q1/PPB4P/R4RK1 w - -", e.Position)
+	assert.Equal(t, "WAC.001", e.ID())
+
+	bm, ok := e.Operation("bm")
+	require.True(t, ok)
+	assert.Equal(t, []string{"Qg6"}, bm)
+
+	c0, ok := e.Operation("c0")
+	require.True(t, ok)
+	assert.Equal(t, []string{"two words"}, c0)
+
+	_, ok = e.Operation("am")
+	assert.False(t, ok)
+
+	reparsed, err := ParseEPD(e.String())
+	require.NoError(t, err)
+	assert.Equal(t, e, reparsed)
+}
+
+func TestParseEPD_MultipleOperands(t *testing.T) {
+	e, err := ParseEPD("4k3/8/8/8/8/8/8/R3K2R w KQ - bm Rd1 O-O-O; am Ke2; hmvc 12; fmvn 40;")
+	require.NoError(t, err)
+
+	bm, _ := e.Operation("bm")
+	assert.Equal(t, []string{"Rd1", "O-O-O"}, bm)
+
+	b, err := e.Board()
+	require.NoError(t, err)
+	assert.Equal(t, "4k3/8/8/8/8/8/8/R3K2R w KQ - 12 40", b.FEN())
+}
+
+func TestParseEPD_Errors(t *testing.T) {
+	tt := []struct {
+		name string
+		line string
+	}{
+		{name: "missing fields", line: "8/8/8/8/8/8/8/8 w"},
+		{name: "invalid position", line: "8/8/8 w - - bm e4;"},
+		{name: "unterminated operation", line: "4k3/8/8/8/8/8/8/4K3 w - - bm Kd2"},
+		{name: "unterminated string", line: `4k3/8/8/8/8/8/8/4K3 w - - id "abc;`},
+		{name: "empty operation", line: "4k3/8/8/8/8/8/8/4K3 w - - ;"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseEPD(tc.line)
+			assert.ErrorIs(t, err, ErrInvalidEPD)
+		})
+	}
+}
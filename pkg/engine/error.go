@@ -6,3 +6,7 @@ var ErrOutOfBoard = errors.New("position out of board")
 var ErrOccupied = errors.New("position is occupied")
 var ErrPieceNotFound = errors.New("piece not found on the board")
 var ErrNotActiveColor = errors.New("not active color")
+var ErrInvalidFEN = errors.New("invalid fen")
+var ErrInvalidSAN = errors.New("invalid san")
+var ErrInvalidEPD = errors.New("invalid epd")
+var ErrInvalidDrop = errors.New("invalid drop")
@@ -0,0 +1,79 @@
+package engine
+
+// exchangeKingValue value of the king in an exchange, high enough that
+// capturing with the king into a recapture is never worth it.
+const exchangeKingValue = 100
+
+func exchangeValue(s Symbol) int {
+	if s == King {
+		return exchangeKingValue
+	}
+	return s.Value()
+}
+
+// Defended piece on pos is defended by another piece of its color.
+func (b *Board) Defended(pos int) bool {
+	if b.IsEmpty(pos) || b.IsSentinel(pos) {
+		return false
+	}
+	return len(b.Attackers(pos, b.Color(pos))) > 0
+}
+
+// Hanging pieces of color c attacked by the opponent and not defended, kings excluded.
+func (b *Board) Hanging(c Color) []Piece {
+	var hanging []Piece
+	for _, p := range b.Pieces(c) {
+		if p.symbol == King {
+			continue
+		}
+		if len(b.Attackers(p.position, c.Opposite())) > 0 && !b.Defended(p.position) {
+			hanging = append(hanging, p)
+		}
+	}
+	return hanging
+}
+
+// ExchangeGain material attacker wins by a simple exchange on pos, capturing with the
+// least valuable piece first and recapturing in turn. Either side stops once continuing
+// loses material, attacker does not start the exchange if it loses material.
+// Returns 0 if pos is empty or not attacked. Pins are not considered.
+func (b *Board) ExchangeGain(pos int, attacker Color) int {
+	if b.IsEmpty(pos) || b.IsSentinel(pos) || b.Color(pos) == attacker {
+		return 0
+	}
+
+	// only the cells are needed to find attackers
+	sim := &Board{cells: b.cells}
+
+	// captured value of each capture in turn
+	captured := make([]int, 0, 8)
+	side := attacker
+	for {
+		from, ok := sim.leastValuableAttacker(pos, side)
+		if !ok {
+			break
+		}
+		captured = append(captured, exchangeValue(sim.Symbol(pos)))
+		sim.cells[pos] = sim.cells[from]
+		sim.cells[from] = EmptyCell
+		side = side.Opposite()
+	}
+
+	// resolve backwards, each side only captures if it gains
+	gain := 0
+	for i := len(captured) - 1; i >= 0; i-- {
+		gain = max(0, captured[i]-gain)
+	}
+	return gain
+}
+
+func (b *Board) leastValuableAttacker(pos int, attacker Color) (int, bool) {
+	best, bestValue := 0, 0
+	for _, from := range b.Attackers(pos, attacker) {
+		v := exchangeValue(b.Symbol(from))
+		if best == 0 || v < bestValue {
+			best, bestValue = from, v
+		}
+	}
+	return best, best != 0
+}
@@ -0,0 +1,64 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoard_Attackers(t *testing.T) {
+	b, err := NewBoardFromFEN("4k3/8/2p5/3p4/8/4N3/8/3R2K1 w - - 0 1")
+	require.NoError(t, err)
+
+	d5, err := ParseSquare("d5")
+	require.NoError(t, err)
+	c6, err := ParseSquare("c6")
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []int{24, 45}, b.Attackers(d5, White), "rook d1 and knight e3")
+	assert.Equal(t, []int{c6}, b.Attackers(d5, Black), "defended by pawn c6")
+	assert.True(t, b.Defended(d5))
+	assert.False(t, b.Defended(c6))
+}
+
+func TestBoard_Hanging(t *testing.T) {
+	b, err := NewBoardFromFEN("4k3/8/8/3p4/8/8/8/3RK3 w - - 0 1")
+	require.NoError(t, err)
+
+	hanging := b.Hanging(Black)
+	require.Len(t, hanging, 1)
+	assert.Equal(t, Pawn, hanging[0].Symbol())
+	assert.Equal(t, "d5", SquareName(hanging[0].Position()))
+	assert.Empty(t, b.Hanging(White), "rook is defended by the king")
+}
+
+func TestBoard_ExchangeGain(t *testing.T) {
+	tt := []struct {
+		name     string
+		fen      string
+		square   string
+		expected int
+	}{
+		{name: "undefended pawn", fen: "4k3/8/8/3p4/8/8/8/3RK3 w - - 0 1", square: "d5", expected: 1},
+		{name: "queen takes defended pawn", fen: "4k3/2p5/3p4/8/8/8/8/3QK3 w - - 0 1", square: "d6", expected: 0},
+		{name: "exchange loses a knight", fen: "4k3/8/2p5/3p4/8/4N3/8/3R2K1 w - - 0 1", square: "d5", expected: 0},
+		{name: "pawn takes defended bishop", fen: "4k3/8/2p5/3b4/4P3/8/8/4K3 w - - 0 1", square: "d5", expected: 2},
+		{name: "x-ray rook", fen: "4k3/3r4/8/3p4/8/8/3R4/3RK3 w - - 0 1", square: "d5", expected: 1},
+		{name: "empty square", fen: StartFEN, square: "e4", expected: 0},
+		{name: "own piece", fen: StartFEN, square: "e2", expected: 0},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := NewBoardFromFEN(tc.fen)
+			require.NoError(t, err)
+			pos, err := ParseSquare(tc.square)
+			require.NoError(t, err)
+
+			before := b.GridRaw()
+			assert.Equal(t, tc.expected, b.ExchangeGain(pos, White))
+			assert.Equal(t, before, b.GridRaw(), "board is not modified")
+		})
+	}
+}
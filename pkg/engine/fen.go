@@ -0,0 +1,322 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const StartFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+var fenSymbols = map[byte]Symbol{
+	'p': Pawn,
+	'n': Knight,
+	'b': Bishop,
+	'r': Rook,
+	'q': Queen,
+	'k': King,
+}
+
+var symbolLetters = map[Symbol]byte{
+	Pawn:   'p',
+	Knight: 'n',
+	Bishop: 'b',
+	Rook:   'r',
+	Queen:  'q',
+	King:   'k',
+}
+
+// NewBoardFromFEN creates a board from Forsyth-Edwards Notation.
+// Halfmove clock and fullmove number are optional and default to 0 and 1.
+//
+// The board tracks castling rights through piece move counts, so kings and rooks
+// are loaded as moved unless the castling field grants them rights. Pawns are
+// loaded as unmoved only on their starting rank, other pieces as unmoved.
+func NewBoardFromFEN(fen string) (*Board, error) {
+	fields := strings.Fields(fen)
+	if len(fields) < 4 || len(fields) > 6 {
+		return nil, fmt.Errorf("%w: expected 4 to 6 fields, got %d", ErrInvalidFEN, len(fields))
+	}
+
+	var active Color
+	switch fields[1] {
+	case "w":
+		active = White
+	case "b":
+		active = Black
+	default:
+		return nil, fmt.Errorf("%w: invalid active color %q", ErrInvalidFEN, fields[1])
+	}
+
+	b := NewEmptyBoard(active)
+
+	pieces, err := parseFENPlacement(fields[0], fields[2])
+	if err != nil {
+		return nil, err
+	}
+	if err := b.LoadPieces(pieces); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFEN, err)
+	}
+	if fields[2] != "-" {
+		b.startCastling = fields[2]
+	}
+
+	if fields[3] != "-" {
+		ep, err := ParseSquare(fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid en passant square %q", ErrInvalidFEN, fields[3])
+		}
+		// the side that just moved is the opposite of the active color
+		mover := active.Opposite()
+		forward := int(pawnMoveDirections(mover, true)[0])
+		b.startLastMove = Move{
+			Color:  mover,
+			Symbol: Pawn,
+			From:   ep - forward,
+			To:     ep + forward,
+		}
+	}
+
+	if len(fields) > 4 {
+		halfmove, err := strconv.Atoi(fields[4])
+		if err != nil || halfmove < 0 {
+			return nil, fmt.Errorf("%w: invalid halfmove clock %q", ErrInvalidFEN, fields[4])
+		}
+		b.drawCounter = halfmove
+	}
+
+	if len(fields) > 5 {
+		fullmove, err := strconv.Atoi(fields[5])
+		if err != nil || fullmove < 1 {
+			return nil, fmt.Errorf("%w: invalid fullmove number %q", ErrInvalidFEN, fields[5])
+		}
+		b.startPly = (fullmove - 1) * 2
+		if active == Black {
+			b.startPly++
+		}
+	}
+
+	return b, nil
+}
+
+func parseFENPlacement(placement string, castling string) ([]Piece, error) {
+	ranks := strings.Split(placement, "/")
+	if len(ranks) != 8 {
+		return nil, fmt.Errorf("%w: expected 8 ranks, got %d", ErrInvalidFEN, len(ranks))
+	}
+
+	if castling != "-" && strings.Trim(castling, "KQkq") != "" {
+		return nil, fmt.Errorf("%w: invalid castling field %q", ErrInvalidFEN, castling)
+	}
+	unmoved := map[int]bool{}
+	for _, c := range castling {
+		switch c {
+		case 'K':
+			unmoved[25], unmoved[28] = true, true
+		case 'Q':
+			unmoved[25], unmoved[21] = true, true
+		case 'k':
+			unmoved[95], unmoved[98] = true, true
+		case 'q':
+			unmoved[95], unmoved[91] = true, true
+		}
+	}
+
+	// kings first, following GenerateStartPieces ordering
+	kings := make([]Piece, 0, 2)
+	others := make([]Piece, 0, 30)
+	for i, rank := range ranks {
+		rankIndex := 7 - i
+		file := 0
+		for j := 0; j < len(rank); j++ {
+			c := rank[j]
+			if c >= '1' && c <= '8' {
+				file += int(c - '0')
+				continue
+			}
+
+			color := White
+			lower := c
+			if c >= 'a' && c <= 'z' {
+				color = Black
+			} else {
+				lower = c + ('a' - 'A')
+			}
+			symbol, ok := fenSymbols[lower]
+			if !ok {
+				return nil, fmt.Errorf("%w: invalid piece %q", ErrInvalidFEN, c)
+			}
+			if file > 7 {
+				return nil, fmt.Errorf("%w: rank %d overflows", ErrInvalidFEN, rankIndex+1)
+			}
+
+			pos := indexToMailbox[rankIndex*8+file]
+			// move count only affects pawn double moves and castling
+			hasMoved := false
+			switch symbol {
+			case Pawn:
+				hasMoved = !isPawnStartRank(color, rankIndex)
+			case King, Rook:
+				hasMoved = !unmoved[pos]
+			}
+			p := NewPiece(symbol, color, pos, hasMoved)
+			if symbol == King {
+				kings = append(kings, p)
+			} else {
+				others = append(others, p)
+			}
+			file++
+		}
+		if file != 8 {
+			return nil, fmt.Errorf("%w: rank %d does not have 8 files", ErrInvalidFEN, rankIndex+1)
+		}
+	}
+
+	return append(kings, others...), nil
+}
+
+func isPawnStartRank(color Color, rank int) bool {
+	if color == White {
+		return rank == 1
+	}
+	return rank == 6
+}
+
+// StartFEN Forsyth-Edwards Notation of the position before the first move in History.
+// Pockets are not part of FEN.
+func (b *Board) StartFEN() string {
+	c := b.clone()
+	for c.UndoLastMove() {
+	}
+	return c.FEN()
+}
+
+// FEN returns Forsyth-Edwards Notation of the current position.
+func (b *Board) FEN() string {
+	sb := strings.Builder{}
+
+	for rank := 7; rank >= 0; rank-- {
+		empty := 0
+		for file := 0; file < 8; file++ {
+			pos := indexToMailbox[rank*8+file]
+			if b.IsEmpty(pos) {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				sb.WriteByte(byte('0' + empty))
+				empty = 0
+			}
+			sb.WriteByte(pieceLetter(b.Symbol(pos), b.Color(pos)))
+		}
+		if empty > 0 {
+			sb.WriteByte(byte('0' + empty))
+		}
+		if rank > 0 {
+			sb.WriteByte('/')
+		}
+	}
+
+	sb.WriteByte(' ')
+	if b.activeColor == White {
+		sb.WriteByte('w')
+	} else {
+		sb.WriteByte('b')
+	}
+
+	sb.WriteByte(' ')
+	sb.WriteString(b.castlingRightsString())
+
+	sb.WriteByte(' ')
+	sb.WriteString(b.enPassantTargetString())
+
+	sb.WriteString(fmt.Sprintf(" %d %d", b.drawCounter, b.Ply()/2+1))
+
+	return sb.String()
+}
+
+// Ply returns the number of half moves played including those before a loaded position.
+func (b *Board) Ply() int {
+	return b.startPly + len(b.roundHistory)
+}
+
+func (b *Board) castlingRightsString() string {
+	sb := strings.Builder{}
+	if b.hasCastlingRight(White, 28) {
+		sb.WriteByte('K')
+	}
+	if b.hasCastlingRight(White, 21) {
+		sb.WriteByte('Q')
+	}
+	if b.hasCastlingRight(Black, 98) {
+		sb.WriteByte('k')
+	}
+	if b.hasCastlingRight(Black, 91) {
+		sb.WriteByte('q')
+	}
+	if sb.Len() == 0 {
+		return "-"
+	}
+	return sb.String()
+}
+
+// hasCastlingRight reports whether king and rook of color have not moved from their start squares.
+func (b *Board) hasCastlingRight(color Color, rookPos int) bool {
+	kingPos := 25
+	if color == Black {
+		kingPos = 95
+	}
+	king, ok := b.Piece(color, King, kingPos)
+	if !ok || king.HasMoved() {
+		return false
+	}
+	rook, ok := b.Piece(color, Rook, rookPos)
+	if !ok || rook.HasMoved() {
+		return false
+	}
+	return true
+}
+
+func (b *Board) enPassantTargetString() string {
+	target, ok := b.enPassantTarget()
+	if !ok {
+		return "-"
+	}
+	return SquareName(target)
+}
+
+// enPassantTarget returns the square skipped by the last move if it was a pawn double move.
+func (b *Board) enPassantTarget() (int, bool) {
+	lastMove, found := b.lastMoveOrStart()
+	if !found {
+		return 0, false
+	}
+	if lastMove.Symbol != Pawn || lastMove.Color == b.activeColor {
+		return 0, false
+	}
+	if lastMove.To-lastMove.From != 20 && lastMove.From-lastMove.To != 20 {
+		return 0, false
+	}
+	return (lastMove.From + lastMove.To) / 2, true
+}
+
+// lastMoveOrStart returns the last applied move, falling back to the move implied by
+// the en passant square of a position loaded from FEN.
+func (b *Board) lastMoveOrStart() (Move, bool) {
+	move, found := b.LastMove()
+	if found {
+		return move, true
+	}
+	if b.startLastMove.Symbol != 0 {
+		return b.startLastMove, true
+	}
+	return Move{}, false
+}
+
+func pieceLetter(s Symbol, c Color) byte {
+	l := symbolLetters[s]
+	if c == White {
+		return l - ('a' - 'A')
+	}
+	return l
+}
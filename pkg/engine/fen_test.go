@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBoardFromFEN_StartPosition(t *testing.T) {
+	b, err := NewBoardFromFEN(StartFEN)
+	require.NoError(t, err)
+
+	expected := NewBoard()
+	assert.Equal(t, expected.cells, b.cells)
+	assert.Equal(t, expected.activeColor, b.activeColor)
+	assert.Equal(t, expected.whiteKingPos, b.whiteKingPos)
+	assert.Equal(t, expected.blackKingPos, b.blackKingPos)
+	assert.ElementsMatch(t, expected.whitePieces, b.whitePieces)
+	assert.ElementsMatch(t, expected.blackPieces, b.blackPieces)
+	assert.Len(t, b.GenerateLegalMoves(White), 20)
+	assert.Equal(t, StartFEN, b.FEN())
+}
+
+func TestBoard_FEN_RoundTrip(t *testing.T) {
+	tt := []string{
+		StartFEN,
+		"rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2",
+		"r3k2r/8/8/8/8/8/8/R3K2R b Kq - 5 30",
+		"8/8/8/4k3/8/8/8/4K3 w - - 99 120",
+		"4k3/8/8/8/8/8/8/4K2R w K - 0 1",
+	}
+
+	for _, fen := range tt {
+		t.Run(fen, func(t *testing.T) {
+			b, err := NewBoardFromFEN(fen)
+			require.NoError(t, err)
+			assert.Equal(t, fen, b.FEN())
+		})
+	}
+}
+
+func TestBoard_FEN_AfterMoves(t *testing.T) {
+	b := NewBoard()
+	require.NoError(t, b.ApplyMove(Move{Color: White, Symbol: Pawn, From: 35, To: 55}))
+	assert.Equal(t, "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1", b.FEN())
+
+	require.NoError(t, b.ApplyMove(Move{Color: Black, Symbol: Knight, From: 97, To: 76}))
+	assert.Equal(t, "rnbqkb1r/pppppppp/5n2/8/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 1 2", b.FEN())
+}
+
+func TestNewBoardFromFEN_Castling(t *testing.T) {
+	b, err := NewBoardFromFEN("r3k2r/8/8/8/8/8/8/R3K2R w Q - 0 1")
+	require.NoError(t, err)
+
+	king, ok := b.Piece(White, King, 25)
+	require.True(t, ok)
+	castling := b.generateCastlingMoves(king)
+	require.Len(t, castling, 1)
+	assert.Equal(t, 21, castling[0].RookFrom)
+
+	bKing, ok := b.Piece(Black, King, 95)
+	require.True(t, ok)
+	assert.Empty(t, b.generateCastlingMoves(bKing))
+}
+
+func TestNewBoardFromFEN_EnPassant(t *testing.T) {
+	b, err := NewBoardFromFEN("4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 2")
+	require.NoError(t, err)
+
+	pawn, ok := b.Piece(White, Pawn, 65)
+	require.True(t, ok)
+	moves, err := b.GeneratePieceLegalMoves(pawn)
+	require.NoError(t, err)
+	assert.Contains(t, moves, Move{
+		Color:       White,
+		Symbol:      Pawn,
+		From:        65,
+		To:          74,
+		Captured:    Pawn,
+		IsEnPassant: true,
+	})
+
+	_, found := b.LastMove()
+	assert.False(t, found, "en passant square should not appear as a played move")
+}
+
+func TestNewBoardFromFEN_Errors(t *testing.T) {
+	tt := []struct {
+		name string
+		fen  string
+	}{
+		{name: "too few fields", fen: "8/8/8/8/8/8/8/8 w"},
+		{name: "seven ranks", fen: "8/8/8/8/8/8/8 w - - 0 1"},
+		{name: "rank overflow", fen: "9/8/8/8/8/8/8/8 w - - 0 1"},
+		{name: "short rank", fen: "7/8/8/8/8/8/8/8 w - - 0 1"},
+		{name: "invalid piece", fen: "x7/8/8/8/8/8/8/8 w - - 0 1"},
+		{name: "invalid color", fen: "8/8/8/8/8/8/8/8 x - - 0 1"},
+		{name: "invalid castling", fen: "8/8/8/8/8/8/8/8 w X - 0 1"},
+		{name: "invalid en passant", fen: "8/8/8/8/8/8/8/8 w - z9 0 1"},
+		{name: "invalid halfmove", fen: "8/8/8/8/8/8/8/8 w - - x 1"},
+		{name: "invalid fullmove", fen: "8/8/8/8/8/8/8/8 w - - 0 0"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewBoardFromFEN(tc.fen)
+			assert.ErrorIs(t, err, ErrInvalidFEN)
+		})
+	}
+}
+
+func TestBoard_StartFEN(t *testing.T) {
+	fen := "rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3"
+	b, err := NewBoardFromFEN(fen)
+	require.NoError(t, err)
+	for _, san := range []string{"exf6", "Nxf6", "Nf3"} {
+		m, err := b.ParseSAN(san)
+		require.NoError(t, err)
+		require.NoError(t, b.ApplyMove(m))
+	}
+
+	assert.Equal(t, fen, b.StartFEN())
+	assert.Len(t, b.History(), 3)
+	assert.Equal(t, "rnbqkb1r/ppp1p1pp/5n2/3p4/8/5N2/PPPP1PPP/RNBQKB1R b KQkq - 1 4", b.FEN(), "board unchanged")
+	assert.Equal(t, StartFEN, NewBoard().StartFEN())
+}
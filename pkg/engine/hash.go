@@ -25,6 +25,12 @@ func (b *Board) calculateBoardStateHash(lastMove Move, active Color) uint64 {
 		byte(epEast),
 	)
 
+	if b.variant.HasPockets() {
+		for _, s := range DropSymbols {
+			hashBytes = append(hashBytes, byte(b.whitePocket[s]), byte(b.blackPocket[s]))
+		}
+	}
+
 	// implementation doesn't return any error
 	_, _ = h.Write(hashBytes)
 
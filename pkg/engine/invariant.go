@@ -0,0 +1,336 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvariantViolated = errors.New("board invariant violated")
+
+const (
+	InvariantSentinel      = "sentinel"
+	InvariantCellPiece     = "cell_piece"
+	InvariantPieceCell     = "piece_cell"
+	InvariantKingPosition  = "king_position"
+	InvariantGraveyard     = "graveyard"
+	InvariantHistory       = "history"
+	InvariantHashCount     = "hash_count"
+	InvariantDuplicatePos  = "duplicate_position"
+	InvariantPieceColor    = "piece_color"
+	InvariantActiveColor   = "active_color"
+	InvariantGraveyardSize = "graveyard_size"
+	InvariantPocket        = "pocket"
+)
+
+// InvariantError describes the first mismatch found by CheckInvariants.
+type InvariantError struct {
+	Invariant string
+	// Position mailbox position of the mismatch, 0 if not applicable
+	Position int
+	Expected string
+	Actual   string
+	// Board full board and piece list dump at the time of the check
+	Board string
+}
+
+func (e *InvariantError) Error() string {
+	location := ""
+	if e.Position != 0 {
+		location = fmt.Sprintf(" at %s(%d)", SquareName(e.Position), e.Position)
+	}
+	return fmt.Sprintf("%v: %s%s: expected %s, actual %s\n%s",
+		ErrInvariantViolated, e.Invariant, location, e.Expected, e.Actual, e.Board)
+}
+
+func (e *InvariantError) Unwrap() error {
+	return ErrInvariantViolated
+}
+
+// SetInvariantChecks enables checking invariants after every ApplyMove and UndoLastMove,
+// a violation panics with an error naming the operation, the *InvariantError is available
+// through errors.As. Enabled by default when built with -tags chessdebug.
+func (b *Board) SetInvariantChecks(enabled bool) {
+	b.invariantChecks = enabled
+}
+
+// CheckInvariants verifies that cells, piece lists, king positions, graveyard,
+// history and hash counts agree. Returns *InvariantError describing the first mismatch.
+func (b *Board) CheckInvariants() error {
+	checks := []func() *InvariantError{
+		b.checkSentinels,
+		b.checkPieceLists,
+		b.checkCells,
+		b.checkKingPositions,
+		b.checkHistory,
+		b.checkGraveyard,
+		b.checkHashCounts,
+		b.checkPockets,
+	}
+	for _, check := range checks {
+		if err := check(); err != nil {
+			err.Board = b.dump()
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Board) mustCheckInvariants(operation string) {
+	if !b.invariantChecks {
+		return
+	}
+	if err := b.CheckInvariants(); err != nil {
+		panic(fmt.Errorf("after %s: %w", operation, err))
+	}
+}
+
+func (b *Board) checkSentinels() *InvariantError {
+	for pos := 0; pos < boardSize; pos++ {
+		isSentinel := calculateBlankBoardValue(pos) == SentinelCell
+		if isSentinel != (b.cells[pos] == SentinelCell) {
+			return &InvariantError{
+				Invariant: InvariantSentinel,
+				Position:  pos,
+				Expected:  fmt.Sprintf("sentinel=%t", isSentinel),
+				Actual:    fmt.Sprintf("cell value %d", b.cells[pos]),
+			}
+		}
+	}
+	return nil
+}
+
+// checkPieceLists every listed piece is on a matching cell, once.
+func (b *Board) checkPieceLists() *InvariantError {
+	seen := make(map[int]Piece, 32)
+	for _, color := range Colors {
+		for _, p := range b.Pieces(color) {
+			if p.color != color {
+				return &InvariantError{
+					Invariant: InvariantPieceColor,
+					Position:  p.position,
+					Expected:  fmt.Sprintf("%v piece in %v list", color, color),
+					Actual:    describePiece(p),
+				}
+			}
+			if other, dup := seen[p.position]; dup {
+				return &InvariantError{
+					Invariant: InvariantDuplicatePos,
+					Position:  p.position,
+					Expected:  "one piece per position",
+					Actual:    fmt.Sprintf("%s and %s", describePiece(other), describePiece(p)),
+				}
+			}
+			seen[p.position] = p
+
+			if p.position < 0 || p.position >= boardSize || b.cells[p.position] != boardSymbolPiece(p) {
+				actual := "position out of board"
+				if p.position >= 0 && p.position < boardSize {
+					actual = fmt.Sprintf("cell value %d", b.cells[p.position])
+				}
+				return &InvariantError{
+					Invariant: InvariantPieceCell,
+					Position:  p.position,
+					Expected:  fmt.Sprintf("cell value %d for %s", boardSymbolPiece(p), describePiece(p)),
+					Actual:    actual,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkCells every occupied cell has a piece in the list of its color.
+func (b *Board) checkCells() *InvariantError {
+	for _, pos := range indexToMailbox {
+		if b.IsEmpty(pos) {
+			continue
+		}
+		if _, ok := b.Piece(b.Color(pos), b.Symbol(pos), pos); !ok {
+			return &InvariantError{
+				Invariant: InvariantCellPiece,
+				Position:  pos,
+				Expected:  fmt.Sprintf("%v %v in piece list", b.Color(pos), b.Symbol(pos)),
+				Actual:    "no matching piece",
+			}
+		}
+	}
+	return nil
+}
+
+func (b *Board) checkKingPositions() *InvariantError {
+	// kings are ordinary pieces, promotions may create more
+	if !b.variant.royalKing() {
+		return nil
+	}
+	for _, color := range Colors {
+		kingPos := b.kingPosition(color)
+		kings := 0
+		matched := false
+		for _, p := range b.Pieces(color) {
+			if p.symbol != King {
+				continue
+			}
+			kings++
+			if p.position == kingPos {
+				matched = true
+			}
+		}
+		// playground boards may not have a king
+		if kings == 0 && kingPos == 0 {
+			continue
+		}
+		if !matched {
+			return &InvariantError{
+				Invariant: InvariantKingPosition,
+				Position:  kingPos,
+				Expected:  fmt.Sprintf("%v king at tracked position", color),
+				Actual:    fmt.Sprintf("%d %v kings in piece list, none at %s", kings, color, SquareName(kingPos)),
+			}
+		}
+	}
+	return nil
+}
+
+// checkHistory moves alternate color and the last move was made by the inactive color.
+func (b *Board) checkHistory() *InvariantError {
+	expected := b.activeColor
+	for i := len(b.roundHistory) - 1; i >= 0; i-- {
+		expected = expected.Opposite()
+		m := b.roundHistory[i].Move
+		if m.Color != expected {
+			return &InvariantError{
+				Invariant: InvariantHistory,
+				Position:  m.To,
+				Expected:  fmt.Sprintf("round %d played by %v", i+1, expected),
+				Actual:    fmt.Sprintf("played by %v", m.Color),
+			}
+		}
+	}
+	return nil
+}
+
+// checkGraveyard graveyard holds one piece per capture in history, in order.
+func (b *Board) checkGraveyard() *InvariantError {
+	captures := make([]Move, 0, len(b.graveyard))
+	for _, r := range b.roundHistory {
+		if r.Move.hasCaptured() {
+			captures = append(captures, r.Move)
+		}
+	}
+
+	if len(captures) != len(b.graveyard) {
+		return &InvariantError{
+			Invariant: InvariantGraveyardSize,
+			Expected:  fmt.Sprintf("%d captured pieces", len(captures)),
+			Actual:    fmt.Sprintf("%d pieces in graveyard", len(b.graveyard)),
+		}
+	}
+
+	for i, m := range captures {
+		p := b.graveyard[i]
+		if p.symbol != m.Captured || p.color != m.Color.Opposite() {
+			return &InvariantError{
+				Invariant: InvariantGraveyard,
+				Position:  m.To,
+				Expected:  fmt.Sprintf("graveyard[%d] %v %v", i, m.Color.Opposite(), m.Captured),
+				Actual:    describePiece(p),
+			}
+		}
+	}
+	return nil
+}
+
+// checkHashCounts every round hash is counted once per occurrence in history.
+func (b *Board) checkHashCounts() *InvariantError {
+	expected := make(map[uint64]int, len(b.roundHistory))
+	for _, r := range b.roundHistory {
+		expected[r.BoardStateHash]++
+	}
+
+	for hash, count := range b.boardStateHashMapCount {
+		if count != expected[hash] {
+			return &InvariantError{
+				Invariant: InvariantHashCount,
+				Expected:  fmt.Sprintf("hash %x counted %d times", hash, expected[hash]),
+				Actual:    fmt.Sprintf("%d", count),
+			}
+		}
+	}
+	for hash, count := range expected {
+		if b.boardStateHashMapCount[hash] != count {
+			return &InvariantError{
+				Invariant: InvariantHashCount,
+				Expected:  fmt.Sprintf("hash %x counted %d times", hash, count),
+				Actual:    fmt.Sprintf("%d", b.boardStateHashMapCount[hash]),
+			}
+		}
+	}
+	return nil
+}
+
+// checkPockets pocket holds at least the captured pieces not yet dropped, VariantCrazyhouse.
+// Pieces already in the pocket before the first move are unknown,
+// bughouse pockets are filled from the partner board so only drops are counted.
+func (b *Board) checkPockets() *InvariantError {
+	if !b.variant.HasPockets() {
+		return nil
+	}
+
+	var held [2]pocket
+	index := func(c Color) int { return (1 - int(c)) / 2 }
+	captureIndex := 0
+	for _, r := range b.roundHistory {
+		m := r.Move
+		if m.IsDrop {
+			held[index(m.Color)][m.Symbol]--
+		}
+		if m.hasCaptured() && captureIndex < len(b.graveyard) {
+			if b.variant == VariantCrazyhouse {
+				held[index(m.Color)][b.graveyard[captureIndex].PocketSymbol()]++
+			}
+			captureIndex++
+		}
+	}
+
+	for _, color := range Colors {
+		p := b.pocket(color)
+		for _, s := range DropSymbols {
+			if p[s] < 0 || p[s] < held[index(color)][s] {
+				return &InvariantError{
+					Invariant: InvariantPocket,
+					Expected:  fmt.Sprintf("%v pocket at least %d %v", color, max(held[index(color)][s], 0), s),
+					Actual:    fmt.Sprintf("%d", p[s]),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (b *Board) dump() string {
+	sb := strings.Builder{}
+	sb.WriteString(b.GridFull())
+	for _, color := range Colors {
+		sb.WriteString(fmt.Sprintf("%v pieces:", color))
+		for _, p := range b.Pieces(color) {
+			sb.WriteString(" ")
+			sb.WriteString(describePiece(p))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("graveyard:")
+	for _, p := range b.graveyard {
+		sb.WriteString(" ")
+		sb.WriteString(describePiece(p))
+	}
+	sb.WriteString(fmt.Sprintf("\nactive: %v rounds: %d\n", b.activeColor, len(b.roundHistory)))
+	if b.variant.HasPockets() {
+		sb.WriteString(fmt.Sprintf("pockets: white %v black %v\n", b.Pocket(White), b.Pocket(Black)))
+	}
+	return sb.String()
+}
+
+func describePiece(p Piece) string {
+	return fmt.Sprintf("%v %v@%s(%d)", p.color, p.symbol, SquareName(p.position), p.position)
+}
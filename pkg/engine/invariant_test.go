@@ -0,0 +1,160 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoard_CheckInvariants_Valid(t *testing.T) {
+	b := NewBoard()
+	require.NoError(t, b.CheckInvariants())
+
+	moves := []string{"e4", "d5", "exd5", "Qxd5", "Nc3", "Qa5", "d4", "c6", "Bd2", "Bf5"}
+	for _, san := range moves {
+		m, err := b.ParseSAN(san)
+		require.NoError(t, err, san)
+		require.NoError(t, b.ApplyMove(m), san)
+		require.NoError(t, b.CheckInvariants(), san)
+	}
+
+	for b.UndoLastMove() {
+		require.NoError(t, b.CheckInvariants())
+	}
+
+	fb, err := NewBoardFromFEN("rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2")
+	require.NoError(t, err)
+	assert.NoError(t, fb.CheckInvariants())
+}
+
+func TestBoard_CheckInvariants_Violations(t *testing.T) {
+	tt := []struct {
+		name      string
+		corrupt   func(b *Board)
+		invariant string
+		position  int
+	}{
+		{
+			name:      "sentinel overwritten",
+			corrupt:   func(b *Board) { b.cells[0] = 0 },
+			invariant: InvariantSentinel,
+			position:  0,
+		},
+		{
+			name:      "cell cleared under piece",
+			corrupt:   func(b *Board) { b.cells[22] = 0 },
+			invariant: InvariantPieceCell,
+			position:  22,
+		},
+		{
+			name:      "cell without piece",
+			corrupt:   func(b *Board) { b.cells[56] = -2 },
+			invariant: InvariantCellPiece,
+			position:  56,
+		},
+		{
+			name:      "king position out of sync",
+			corrupt:   func(b *Board) { b.whiteKingPos = 26 },
+			invariant: InvariantKingPosition,
+			position:  26,
+		},
+		{
+			name:      "duplicate piece",
+			corrupt:   func(b *Board) { b.whitePieces = append(b.whitePieces, b.whitePieces[0]) },
+			invariant: InvariantDuplicatePos,
+		},
+		{
+			name:      "graveyard without capture",
+			corrupt:   func(b *Board) { b.graveyard = append(b.graveyard, NewPiece(Pawn, Black, 0)) },
+			invariant: InvariantGraveyardSize,
+		},
+		{
+			name:      "wrong active color",
+			corrupt:   func(b *Board) { b.activeColor = b.activeColor.Opposite() },
+			invariant: InvariantHistory,
+		},
+		{
+			name:      "hash count out of sync",
+			corrupt:   func(b *Board) { b.boardStateHashMapCount[1]++ },
+			invariant: InvariantHashCount,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b := NewBoard()
+			m, err := b.ParseSAN("e4")
+			require.NoError(t, err)
+			require.NoError(t, b.ApplyMove(m))
+
+			tc.corrupt(b)
+
+			err = b.CheckInvariants()
+			require.ErrorIs(t, err, ErrInvariantViolated)
+			ie, ok := err.(*InvariantError)
+			require.True(t, ok)
+			assert.Equal(t, tc.invariant, ie.Invariant)
+			if tc.position != 0 {
+				assert.Equal(t, tc.position, ie.Position)
+			}
+			assert.NotEmpty(t, ie.Board)
+		})
+	}
+}
+
+func TestBoard_CheckInvariants_GraveyardMismatch(t *testing.T) {
+	b := NewBoard()
+	for _, san := range []string{"e4", "d5", "exd5"} {
+		m, err := b.ParseSAN(san)
+		require.NoError(t, err)
+		require.NoError(t, b.ApplyMove(m))
+	}
+	b.graveyard[0] = NewPiece(Knight, Black, 0)
+
+	err := b.CheckInvariants()
+	ie, ok := err.(*InvariantError)
+	require.True(t, ok)
+	assert.Equal(t, InvariantGraveyard, ie.Invariant)
+	assert.Equal(t, 64, ie.Position)
+}
+
+func TestBoard_SetInvariantChecks(t *testing.T) {
+	b := NewBoard()
+	b.SetInvariantChecks(true)
+
+	m, err := b.ParseSAN("e4")
+	require.NoError(t, err)
+	require.NoError(t, b.ApplyMove(m))
+
+	b.cells[56] = -2
+	defer func() {
+		err, ok := recover().(error)
+		require.True(t, ok, "panics with an error")
+		assert.ErrorContains(t, err, "after UndoLastMove")
+		ie, ok := errors.AsType[*InvariantError](err)
+		require.True(t, ok)
+		assert.Equal(t, InvariantCellPiece, ie.Invariant)
+	}()
+	b.UndoLastMove()
+	t.Fatal("expected a panic")
+}
+
+func TestBoard_GobDecode_Invariants(t *testing.T) {
+	b := NewBoard()
+	b.cells[55] = -2
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(b))
+
+	loaded := NewEmptyBoard()
+	err := loaded.Load(&buf)
+	if debugInvariants {
+		assert.ErrorIs(t, err, ErrInvariantViolated)
+	} else {
+		assert.NoError(t, err)
+	}
+}
@@ -1,5 +1,7 @@
 package engine
 
+import "slices"
+
 type Move struct {
 	Color  Color
 	Symbol Symbol
@@ -13,6 +15,9 @@ type Move struct {
 	Captured    Symbol
 	Promotion   Symbol
 	IsEnPassant bool
+
+	// IsDrop pocket piece Symbol dropped on To, From is unused
+	IsDrop bool
 }
 
 func (m Move) hasCaptured() bool {
@@ -46,7 +51,7 @@ func (b *Board) generatePseudoLegalMoves(color Color) []Move {
 		}
 		moves = append(moves, pieceMoves...)
 	}
-	return moves
+	return append(moves, b.generateDropMoves(color)...)
 }
 
 // HasLegalMoves optimize version to check if legal moves are available.
@@ -67,6 +72,12 @@ func (b *Board) HasLegalMoves(color Color) bool {
 		}
 	}
 
+	for _, m := range b.generateDropMoves(color) {
+		if b.isLegalMove(m) {
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -80,6 +91,10 @@ func (b *Board) GeneratePieceLegalMoves(piece Piece) ([]Move, error) {
 }
 
 func (b *Board) FilterLegalMoves(moves []Move) []Move {
+	if b.variant == VariantAntichess && len(moves) > 0 && b.hasCapture(moves[0].Color) {
+		moves = slices.DeleteFunc(moves, func(m Move) bool { return !m.hasCaptured() })
+	}
+
 	legalCount := 0
 	for i, m := range moves {
 		if b.isLegalMove(m) {
@@ -91,6 +106,18 @@ func (b *Board) FilterLegalMoves(moves []Move) []Move {
 }
 
 func (b *Board) isLegalMove(m Move) bool {
+	switch b.variant {
+	case VariantAntichess:
+		// forced captures are filtered in FilterLegalMoves
+		return true
+	case VariantDarkChess:
+		// king may move into or stay in check
+		return true
+	case VariantAtomic:
+		return b.isLegalAtomicMove(m)
+	default:
+	}
+
 	isLegal := false
 
 	b.applyMovePos(m)
@@ -156,10 +183,10 @@ func (b *Board) generatePiecePseudoLegalMoves(piece Piece) []Move {
 // - if king is King and if it hasn't moved
 // - if the path between king and rook is clear and rooks haven't moved
 // - if king is not checked
-// - if king destination is not under attack
+// - if squares king passes through and its destination are not under attack
 // Generates castling moves if all conditions are met
 func (b *Board) generateCastlingMoves(king Piece) []Move {
-	if king.symbol != King || king.HasMoved() {
+	if king.symbol != King || king.HasMoved() || !b.variant.allowsCastling() {
 		return nil
 	}
 	pieces := b.Pieces(king.color)
@@ -197,8 +224,13 @@ func (b *Board) generateCastlingMoves(king Piece) []Move {
 			continue
 		}
 
+		// king may not pass through an attacked square
+		if b.variant.hasCheck() && b.isUnderAttack(king.position+int(direction), king.color) {
+			continue
+		}
+
 		kingNextPos := king.position + int(direction)*2
-		if b.isUnderAttack(kingNextPos, king.color) {
+		if b.variant.hasCheck() && b.isUnderAttack(kingNextPos, king.color) {
 			continue
 		}
 
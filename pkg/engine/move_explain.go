@@ -0,0 +1,230 @@
+package engine
+
+import "slices"
+
+type IllegalMoveReason string
+
+const (
+	IllegalPieceMovement        IllegalMoveReason = "invalid_piece_movement"
+	IllegalPathBlocked          IllegalMoveReason = "path_blocked"
+	IllegalPinned               IllegalMoveReason = "pinned"
+	IllegalKingInCheck          IllegalMoveReason = "king_in_check"
+	IllegalCastlingOutOfCheck   IllegalMoveReason = "castling_out_of_check"
+	IllegalCastlingThroughCheck IllegalMoveReason = "castling_through_check"
+	IllegalCastlingRightsLost   IllegalMoveReason = "castling_rights_lost"
+	IllegalPromotionMissing     IllegalMoveReason = "promotion_missing"
+	IllegalPromotionInvalid     IllegalMoveReason = "promotion_invalid"
+	IllegalCaptureRequired      IllegalMoveReason = "capture_required"
+	IllegalKingCapture          IllegalMoveReason = "king_cannot_capture"
+	IllegalOwnKingExploded      IllegalMoveReason = "own_king_exploded"
+	IllegalDropNotInPocket      IllegalMoveReason = "drop_not_in_pocket"
+	IllegalDropOccupied         IllegalMoveReason = "drop_occupied"
+	IllegalDropPawnRank         IllegalMoveReason = "drop_pawn_rank"
+)
+
+// IllegalMove explains why a move was rejected.
+// Position is the mailbox position of the piece responsible, blocking piece, pinner or checker,
+// or the attacked square for castling through check. 0 if not applicable.
+type IllegalMove struct {
+	Reason   IllegalMoveReason
+	Position int
+	Symbol   Symbol
+	Color    Color
+	// Promotions pieces a pawn may promote to in the variant, set for IllegalPromotionInvalid
+	Promotions []Symbol
+}
+
+// ExplainIllegalMove returns the reason move m cannot be played by the piece on m.From.
+// Only Color, Symbol, From, To, Promotion and IsDrop of m are considered.
+// Returns false if the move is legal or no piece of m.Symbol and m.Color is on m.From.
+func (b *Board) ExplainIllegalMove(m Move) (IllegalMove, bool) {
+	if m.IsDrop {
+		return b.explainIllegalDrop(m)
+	}
+
+	piece, ok := b.Piece(m.Color, m.Symbol, m.From)
+	if !ok {
+		return IllegalMove{}, false
+	}
+
+	if m.To < 0 || m.To >= boardSize || b.IsSentinel(m.To) {
+		return IllegalMove{Reason: IllegalPieceMovement}, true
+	}
+
+	if m.Promotion != 0 && !slices.Contains(b.promotionSymbols(), m.Promotion) {
+		return IllegalMove{Reason: IllegalPromotionInvalid, Promotions: slices.Clone(b.promotionSymbols())}, true
+	}
+
+	if b.isCastlingAttempt(piece, m.To) {
+		return b.explainCastling(piece, m.To)
+	}
+
+	moves, err := b.GeneratePiecePseudoLegalMoves(piece)
+	if err != nil {
+		return IllegalMove{}, false
+	}
+	moves = slices.DeleteFunc(moves, func(pm Move) bool {
+		return pm.To != m.To || pm.IsCastling
+	})
+	if len(moves) == 0 {
+		return b.explainUnreachable(piece, m.To), true
+	}
+
+	if b.variant == VariantAntichess && !moves[0].hasCaptured() && b.hasCapture(m.Color) {
+		return IllegalMove{Reason: IllegalCaptureRequired}, true
+	}
+
+	if !b.isLegalMove(moves[0]) {
+		if b.variant == VariantAtomic {
+			return b.explainAtomic(moves[0]), true
+		}
+		return b.explainSelfCheck(moves[0]), true
+	}
+
+	if moves[0].hasPromotion() && m.Promotion == 0 {
+		return IllegalMove{Reason: IllegalPromotionMissing}, true
+	}
+
+	return IllegalMove{}, false
+}
+
+func (b *Board) isCastlingAttempt(piece Piece, to int) bool {
+	return piece.symbol == King && rankOf(piece.position) == rankOf(to) &&
+		(to-piece.position == 2 || piece.position-to == 2)
+}
+
+func (b *Board) explainCastling(king Piece, to int) (IllegalMove, bool) {
+	direction := E
+	rookPos := king.position + 3
+	if to < king.position {
+		direction = W
+		rookPos = king.position - 4
+	}
+
+	rook, hasRook := b.Piece(king.color, Rook, rookPos)
+	if king.HasMoved() || !hasRook || rook.HasMoved() {
+		return IllegalMove{Reason: IllegalCastlingRightsLost}, true
+	}
+
+	for pos := king.position + int(direction); pos != rookPos; pos += int(direction) {
+		if !b.IsEmpty(pos) {
+			return b.illegalMoveAt(IllegalPathBlocked, pos), true
+		}
+	}
+
+	if !b.variant.hasCheck() {
+		return IllegalMove{}, false
+	}
+
+	if checkers := b.attackers(king.position, king.color); len(checkers) > 0 {
+		return b.illegalMoveAt(IllegalCastlingOutOfCheck, checkers[0]), true
+	}
+
+	for _, pos := range []int{king.position + int(direction), to} {
+		if attackers := b.attackers(pos, king.color); len(attackers) > 0 {
+			im := b.illegalMoveAt(IllegalCastlingThroughCheck, attackers[0])
+			im.Position = pos
+			return im, true
+		}
+	}
+
+	return IllegalMove{}, false
+}
+
+// explainUnreachable explains a move that is not in the pseudo legal move list
+func (b *Board) explainUnreachable(piece Piece, to int) IllegalMove {
+	if piece.symbol == Pawn {
+		return b.explainUnreachablePawn(piece, to)
+	}
+
+	if !b.IsEmpty(to) && b.Color(to) == piece.color {
+		return b.illegalMoveAt(IllegalPathBlocked, to)
+	}
+
+	if !isSlidingPiece[piece.symbol] {
+		return IllegalMove{Reason: IllegalPieceMovement}
+	}
+
+	for _, direction := range pieceDirections[piece.symbol] {
+		for pos := piece.position + int(direction); !b.IsSentinel(pos); pos += int(direction) {
+			if pos == to {
+				// on the line but not generated, find first blocker
+				for blocker := piece.position + int(direction); blocker != to; blocker += int(direction) {
+					if !b.IsEmpty(blocker) {
+						return b.illegalMoveAt(IllegalPathBlocked, blocker)
+					}
+				}
+			}
+		}
+	}
+
+	return IllegalMove{Reason: IllegalPieceMovement}
+}
+
+func (b *Board) explainUnreachablePawn(piece Piece, to int) IllegalMove {
+	forward := int(pawnMoveDirections(piece.color, true)[0])
+
+	if to == piece.position+forward {
+		return b.illegalMoveAt(IllegalPathBlocked, to)
+	}
+
+	if to == piece.position+2*forward && !piece.HasMoved() {
+		if !b.IsEmpty(piece.position + forward) {
+			return b.illegalMoveAt(IllegalPathBlocked, piece.position+forward)
+		}
+		return b.illegalMoveAt(IllegalPathBlocked, to)
+	}
+
+	for _, direction := range pawnCaptureDirections(piece.color) {
+		if to == piece.position+int(direction) && !b.IsEmpty(to) && b.Color(to) == piece.color {
+			return b.illegalMoveAt(IllegalPathBlocked, to)
+		}
+	}
+
+	return IllegalMove{Reason: IllegalPieceMovement}
+}
+
+// explainSelfCheck explains a pseudo legal move that leaves the king in check.
+// A piece is considered pinned if the attacker was not attacking the king before the move.
+func (b *Board) explainSelfCheck(m Move) IllegalMove {
+	kingPos := b.kingPosition(m.Color)
+	before := b.attackers(kingPos, m.Color)
+
+	b.applyMovePos(m)
+	after := b.attackers(b.kingPosition(m.Color), m.Color)
+	b.undoMovePos(m)
+
+	if len(after) == 0 {
+		return IllegalMove{Reason: IllegalKingInCheck}
+	}
+
+	if m.Symbol != King {
+		for _, pos := range after {
+			if !slices.Contains(before, pos) {
+				return b.illegalMoveAt(IllegalPinned, pos)
+			}
+		}
+	}
+
+	return b.illegalMoveAt(IllegalKingInCheck, after[0])
+}
+
+// explainAtomic explains an illegal atomic move, falls back to explainSelfCheck
+func (b *Board) explainAtomic(m Move) IllegalMove {
+	if m.Symbol == King && m.hasCaptured() {
+		return IllegalMove{Reason: IllegalKingCapture}
+	}
+	if m.hasCaptured() && slices.Contains(b.explosionPositions(m.To), b.kingPosition(m.Color)) {
+		return b.illegalMoveAt(IllegalOwnKingExploded, b.kingPosition(m.Color))
+	}
+	return b.explainSelfCheck(m)
+}
+
+func (b *Board) illegalMoveAt(reason IllegalMoveReason, pos int) IllegalMove {
+	return IllegalMove{
+		Reason:   reason,
+		Position: pos,
+		Symbol:   b.Symbol(pos),
+		Color:    b.Color(pos),
+	}
+}
@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoard_ExplainIllegalMove(t *testing.T) {
+	tt := []struct {
+		name   string
+		fen    string
+		move   Move
+		expect IllegalMove
+	}{
+		{
+			name:   "knight cannot move that way",
+			fen:    StartFEN,
+			move:   Move{Color: White, Symbol: Knight, From: 27, To: 47},
+			expect: IllegalMove{Reason: IllegalPieceMovement},
+		},
+		{
+			name:   "pawn cannot move backward",
+			fen:    StartFEN,
+			move:   Move{Color: White, Symbol: Pawn, From: 31, To: 21},
+			expect: IllegalMove{Reason: IllegalPieceMovement},
+		},
+		{
+			name:   "pawn cannot capture empty square",
+			fen:    StartFEN,
+			move:   Move{Color: White, Symbol: Pawn, From: 35, To: 46},
+			expect: IllegalMove{Reason: IllegalPieceMovement},
+		},
+		{
+			name:   "bishop path blocked by own pawn",
+			fen:    StartFEN,
+			move:   Move{Color: White, Symbol: Bishop, From: 26, To: 53},
+			expect: IllegalMove{Reason: IllegalPathBlocked, Position: 35, Symbol: Pawn, Color: White},
+		},
+		{
+			name:   "rook destination occupied by own piece",
+			fen:    StartFEN,
+			move:   Move{Color: White, Symbol: Rook, From: 21, To: 22},
+			expect: IllegalMove{Reason: IllegalPathBlocked, Position: 22, Symbol: Knight, Color: White},
+		},
+		{
+			name:   "pawn double move blocked",
+			fen:    "4k3/8/8/8/8/4n3/4P3/4K3 w - - 0 1",
+			move:   Move{Color: White, Symbol: Pawn, From: 35, To: 55},
+			expect: IllegalMove{Reason: IllegalPathBlocked, Position: 45, Symbol: Knight, Color: Black},
+		},
+		{
+			name:   "piece pinned to king",
+			fen:    "4k3/8/8/8/1b6/2N5/8/4K3 w - - 0 1",
+			move:   Move{Color: White, Symbol: Knight, From: 43, To: 64},
+			expect: IllegalMove{Reason: IllegalPinned, Position: 52, Symbol: Bishop, Color: Black},
+		},
+		{
+			name:   "king in check must be resolved",
+			fen:    "4k3/8/8/8/7q/8/P7/4K3 w - - 0 1",
+			move:   Move{Color: White, Symbol: Pawn, From: 31, To: 41},
+			expect: IllegalMove{Reason: IllegalKingInCheck, Position: 58, Symbol: Queen, Color: Black},
+		},
+		{
+			name:   "king moves into check",
+			fen:    "4k3/8/8/8/8/8/3r4/4K3 w - - 0 1",
+			move:   Move{Color: White, Symbol: King, From: 25, To: 24},
+			expect: IllegalMove{Reason: IllegalKingInCheck, Position: 34, Symbol: Rook, Color: Black},
+		},
+		{
+			name:   "castling out of check",
+			fen:    "4r1k1/8/8/8/8/8/8/4K2R w K - 0 1",
+			move:   Move{Color: White, Symbol: King, From: 25, To: 27},
+			expect: IllegalMove{Reason: IllegalCastlingOutOfCheck, Position: 95, Symbol: Rook, Color: Black},
+		},
+		{
+			name:   "castling through check",
+			fen:    "5rk1/8/8/8/8/8/8/4K2R w K - 0 1",
+			move:   Move{Color: White, Symbol: King, From: 25, To: 27},
+			expect: IllegalMove{Reason: IllegalCastlingThroughCheck, Position: 26, Symbol: Rook, Color: Black},
+		},
+		{
+			name:   "castling path blocked",
+			fen:    "4k3/8/8/8/8/8/8/4KB1R w K - 0 1",
+			move:   Move{Color: White, Symbol: King, From: 25, To: 27},
+			expect: IllegalMove{Reason: IllegalPathBlocked, Position: 26, Symbol: Bishop, Color: White},
+		},
+		{
+			name:   "castling rights lost",
+			fen:    "4k3/8/8/8/8/8/8/4K2R w - - 0 1",
+			move:   Move{Color: White, Symbol: King, From: 25, To: 27},
+			expect: IllegalMove{Reason: IllegalCastlingRightsLost},
+		},
+		{
+			name:   "promotion missing",
+			fen:    "4k3/P7/8/8/8/8/8/4K3 w - - 0 1",
+			move:   Move{Color: White, Symbol: Pawn, From: 81, To: 91},
+			expect: IllegalMove{Reason: IllegalPromotionMissing},
+		},
+		{
+			name:   "promotion invalid",
+			fen:    "4k3/P7/8/8/8/8/8/4K3 w - - 0 1",
+			move:   Move{Color: White, Symbol: Pawn, From: 81, To: 91, Promotion: King},
+			expect: IllegalMove{Reason: IllegalPromotionInvalid, Promotions: PromotionSymbols},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := NewBoardFromFEN(tc.fen)
+			require.NoError(t, err)
+			before := b.FEN()
+
+			im, illegal := b.ExplainIllegalMove(tc.move)
+			require.True(t, illegal)
+			assert.Equal(t, tc.expect, im)
+			assert.Equal(t, before, b.FEN(), "board should be restored")
+		})
+	}
+}
+
+func TestBoard_ExplainIllegalMove_Legal(t *testing.T) {
+	b := NewBoard()
+
+	_, illegal := b.ExplainIllegalMove(Move{Color: White, Symbol: Pawn, From: 35, To: 55})
+	assert.False(t, illegal)
+
+	_, illegal = b.ExplainIllegalMove(Move{Color: White, Symbol: Queen, From: 35, To: 55})
+	assert.False(t, illegal, "piece not found")
+}
+
+func TestBoard_CastlingThroughCheck(t *testing.T) {
+	b, err := NewBoardFromFEN("5rk1/8/8/8/8/8/8/4K2R w K - 0 1")
+	require.NoError(t, err)
+
+	king, ok := b.Piece(White, King, 25)
+	require.True(t, ok)
+	assert.Empty(t, b.generateCastlingMoves(king))
+}
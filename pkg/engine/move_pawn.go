@@ -103,8 +103,9 @@ func (b *Board) generatePawnPromotionMoves(
 	nextPos int,
 	captured Symbol,
 ) []Move {
-	moves := make([]Move, 0, 4)
-	for _, promoSymbol := range PromotionSymbols {
+	promotionSymbols := b.promotionSymbols()
+	moves := make([]Move, 0, len(promotionSymbols))
+	for _, promoSymbol := range promotionSymbols {
 		moves = append(moves, Move{
 			Color:     piece.color,
 			Symbol:    piece.symbol,
@@ -118,7 +119,7 @@ func (b *Board) generatePawnPromotionMoves(
 }
 
 func (b *Board) generateEnPassantMovesIfEligible(piece Piece) (Move, bool) {
-	lastMove, found := b.LastMove()
+	lastMove, found := b.lastMoveOrStart()
 	if !found {
 		return Move{}, false
 	}
@@ -0,0 +1,95 @@
+package engine
+
+import "fmt"
+
+// Odds handicap preset, white gives the odds unless stated otherwise.
+type Odds int
+
+const (
+	OddsNone Odds = iota
+	// OddsPawn white plays without the f2 pawn
+	OddsPawn
+	// OddsPawnAndMove black plays without the f7 pawn, white moves first
+	OddsPawnAndMove
+	// OddsKnight white plays without the b1 knight
+	OddsKnight
+	// OddsRook white plays without the a1 rook
+	OddsRook
+	// OddsQueen white plays without the queen
+	OddsQueen
+	// OddsTwoKnights white plays without both knights
+	OddsTwoKnights
+)
+
+const (
+	oddsNoneStr        = "none"
+	oddsPawnStr        = "pawn"
+	oddsPawnAndMoveStr = "pawn_and_move"
+	oddsKnightStr      = "knight"
+	oddsRookStr        = "rook"
+	oddsQueenStr       = "queen"
+	oddsTwoKnightsStr  = "two_knights"
+	oddsUnknownStr     = "unknown"
+)
+
+var OddsPresets = []Odds{
+	OddsNone,
+	OddsPawn,
+	OddsPawnAndMove,
+	OddsKnight,
+	OddsRook,
+	OddsQueen,
+	OddsTwoKnights,
+}
+
+var oddsFEN = map[Odds]string{
+	OddsNone:        StartFEN,
+	OddsPawn:        "rnbqkbnr/pppppppp/8/8/8/8/PPPPP1PP/RNBQKBNR w KQkq - 0 1",
+	OddsPawnAndMove: "rnbqkbnr/ppppp1pp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+	OddsKnight:      "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/R1BQKBNR w KQkq - 0 1",
+	OddsRook:        "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/1NBQKBNR w Kkq - 0 1",
+	OddsQueen:       "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNB1KBNR w KQkq - 0 1",
+	OddsTwoKnights:  "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/R1BQKB1R w KQkq - 0 1",
+}
+
+func (o Odds) String() string {
+	switch o {
+	case OddsNone:
+		return oddsNoneStr
+	case OddsPawn:
+		return oddsPawnStr
+	case OddsPawnAndMove:
+		return oddsPawnAndMoveStr
+	case OddsKnight:
+		return oddsKnightStr
+	case OddsRook:
+		return oddsRookStr
+	case OddsQueen:
+		return oddsQueenStr
+	case OddsTwoKnights:
+		return oddsTwoKnightsStr
+	default:
+		return oddsUnknownStr
+	}
+}
+
+// FEN starting position of the preset
+func (o Odds) FEN() string {
+	return oddsFEN[o]
+}
+
+func (o Odds) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (o *Odds) UnmarshalText(text []byte) error {
+	str := string(text)
+	for _, odds := range OddsPresets {
+		if odds.String() == str {
+			*o = odds
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown odds: %s valid odds(none,pawn,pawn_and_move,knight,rook,queen,two_knights)", str)
+}
@@ -0,0 +1,28 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOdds_FEN(t *testing.T) {
+	for _, o := range OddsPresets {
+		t.Run(o.String(), func(t *testing.T) {
+			b, err := NewBoardFromFEN(o.FEN())
+			require.NoError(t, err)
+			assert.Empty(t, b.Validate())
+			assert.Equal(t, o.FEN(), b.FEN())
+
+			text, err := o.MarshalText()
+			require.NoError(t, err)
+			var got Odds
+			require.NoError(t, got.UnmarshalText(text))
+			assert.Equal(t, o, got)
+		})
+	}
+
+	var o Odds
+	assert.Error(t, o.UnmarshalText([]byte("bishop")))
+}
@@ -4,20 +4,34 @@ import (
 	"bytes"
 	"encoding/gob"
 	"io"
+
+	"github.com/dyxj/chess/pkg/versionx"
 )
 
-// Save serializes the board state using gob encoding.
+// Save serializes the board as a versioned JSON document, see BoardFormatVersion.
 func (b *Board) Save(w io.Writer) error {
-	encoder := gob.NewEncoder(w)
-	return encoder.Encode(b)
+	data, err := b.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
 }
 
-// Load deserializes the board state using gob encoding.
+// Load deserializes a board written by Save, older document versions are migrated.
+// Legacy gob snapshots are still read, r is read to the end.
 func (b *Board) Load(r io.Reader) error {
-	decoder := gob.NewDecoder(r)
-	return decoder.Decode(b)
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if versionx.IsDocument(data) {
+		return b.UnmarshalJSON(data)
+	}
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(b)
 }
 
+// GobEncode legacy format, kept to read and write gob snapshots of earlier releases.
 func (b *Board) GobEncode() ([]byte, error) {
 	var buf bytes.Buffer
 	enc := gob.NewEncoder(&buf)
@@ -33,6 +47,11 @@ func (b *Board) GobEncode() ([]byte, error) {
 		Graveyard:              b.graveyard,
 		DrawCounter:            b.drawCounter,
 		BoardStateHashMapCount: b.boardStateHashMapCount,
+		StartLastMove:          b.startLastMove,
+		StartPly:               b.startPly,
+		Variant:                b.variant,
+		WhitePocket:            b.whitePocket,
+		BlackPocket:            b.blackPocket,
 	})
 	return buf.Bytes(), err
 }
@@ -53,6 +72,15 @@ func (b *Board) GobDecode(data []byte) error {
 	b.graveyard = setCapIfNil(d.Graveyard, 32)
 	b.drawCounter = d.DrawCounter
 	b.boardStateHashMapCount = setMapCapIfNil(d.BoardStateHashMapCount, 256)
+	b.startLastMove = d.StartLastMove
+	b.startPly = d.StartPly
+	b.variant = d.Variant
+	b.whitePocket = d.WhitePocket
+	b.blackPocket = d.BlackPocket
+	b.invariantChecks = debugInvariants
+	if b.invariantChecks {
+		return b.CheckInvariants()
+	}
 	return nil
 }
 
@@ -65,6 +93,7 @@ func (p *Piece) GobEncode() ([]byte, error) {
 		Color:     p.color,
 		Position:  p.position,
 		MoveCount: p.moveCount,
+		Promoted:  p.promoted,
 	})
 	return buf.Bytes(), err
 }
@@ -79,6 +108,7 @@ func (p *Piece) GobDecode(data []byte) error {
 	p.color = d.Color
 	p.position = d.Position
 	p.moveCount = d.MoveCount
+	p.promoted = d.Promoted
 	return nil
 }
 
@@ -93,6 +123,11 @@ type boardData struct {
 	Graveyard              []Piece
 	DrawCounter            int
 	BoardStateHashMapCount map[uint64]int
+	StartLastMove          Move
+	StartPly               int
+	Variant                Variant
+	WhitePocket            pocket
+	BlackPocket            pocket
 }
 
 type pieceData struct {
@@ -100,6 +135,7 @@ type pieceData struct {
 	Color     Color
 	Position  int
 	MoveCount int
+	Promoted  bool
 }
 
 func setCapIfNil[T any](slice []T, capacity int) []T {
@@ -0,0 +1,290 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dyxj/chess/pkg/versionx"
+)
+
+// BoardFormatVersion version of the board document written by Save and MarshalJSON.
+const BoardFormatVersion = 1
+
+// boardMigrations upgrade older board documents, see versionx.Upgrade.
+// Bump BoardFormatVersion and append a migration whenever boardDocument changes.
+var boardMigrations = []versionx.Migration{}
+
+// boardDocument version 1 of the board format. Squares are algebraic names,
+// symbols are 1 pawn, 2 knight, 3 bishop, 4 rook, 5 queen and 6 king.
+// Repetition hashes are not stored, they are recalculated from the history on load.
+type boardDocument struct {
+	Version     int     `json:"version"`
+	Variant     Variant `json:"variant"`
+	ActiveColor Color   `json:"activeColor"`
+	// DrawCounter half moves since the last capture or pawn move
+	DrawCounter int `json:"drawCounter"`
+	// StartPly half moves played before the starting position
+	StartPly int `json:"startPly"`
+	// StartLastMove move implied by the en passant square of the starting position
+	StartLastMove *moveDocument             `json:"startLastMove,omitempty"`
+	Pieces        map[Color][]pieceDocument `json:"pieces"`
+	// Graveyard captured pieces in capture order
+	Graveyard []pieceDocument    `json:"graveyard"`
+	Pockets   map[Color][]Symbol `json:"pockets,omitempty"`
+	// History moves played from the starting position
+	History []roundDocument `json:"history"`
+}
+
+type pieceDocument struct {
+	Symbol    Symbol `json:"symbol"`
+	Color     Color  `json:"color"`
+	Square    string `json:"square"`
+	MoveCount int    `json:"moveCount"`
+	Promoted  bool   `json:"promoted,omitempty"`
+}
+
+type moveDocument struct {
+	Color       Color  `json:"color"`
+	Symbol      Symbol `json:"symbol"`
+	From        string `json:"from,omitempty"`
+	To          string `json:"to"`
+	IsCastling  bool   `json:"isCastling,omitempty"`
+	RookFrom    string `json:"rookFrom,omitempty"`
+	RookTo      string `json:"rookTo,omitempty"`
+	Captured    Symbol `json:"captured,omitempty"`
+	Promotion   Symbol `json:"promotion,omitempty"`
+	IsEnPassant bool   `json:"isEnPassant,omitempty"`
+	IsDrop      bool   `json:"isDrop,omitempty"`
+}
+
+type roundDocument struct {
+	Move            moveDocument `json:"move"`
+	PrevDrawCounter int          `json:"prevDrawCounter"`
+	// Check move gave check, VariantThreeCheck only
+	Check bool `json:"check,omitempty"`
+	// Exploded pieces removed by an atomic capture
+	Exploded []pieceDocument `json:"exploded,omitempty"`
+}
+
+// MarshalJSON encodes the board as a versioned document independent of the board layout.
+func (b *Board) MarshalJSON() ([]byte, error) {
+	d := boardDocument{
+		Version:     BoardFormatVersion,
+		Variant:     b.variant,
+		ActiveColor: b.activeColor,
+		DrawCounter: b.drawCounter,
+		StartPly:    b.startPly,
+		Pieces: map[Color][]pieceDocument{
+			White: toPieceDocuments(b.whitePieces),
+			Black: toPieceDocuments(b.blackPieces),
+		},
+		Graveyard: toPieceDocuments(b.graveyard),
+		History:   make([]roundDocument, len(b.roundHistory)),
+	}
+	if b.startLastMove != (Move{}) {
+		d.StartLastMove = new(toMoveDocument(b.startLastMove))
+	}
+	if b.variant.HasPockets() {
+		d.Pockets = map[Color][]Symbol{
+			White: b.Pocket(White),
+			Black: b.Pocket(Black),
+		}
+	}
+	for i, r := range b.roundHistory {
+		d.History[i] = roundDocument{
+			Move:            toMoveDocument(r.Move),
+			PrevDrawCounter: r.PrevDrawCounter,
+			Check:           r.Check,
+			Exploded:        toPieceDocuments(r.Exploded),
+		}
+	}
+	return json.Marshal(d)
+}
+
+// UnmarshalJSON decodes a board document, older versions are migrated first.
+func (b *Board) UnmarshalJSON(data []byte) error {
+	data, err := versionx.Upgrade(data, BoardFormatVersion, boardMigrations)
+	if err != nil {
+		return err
+	}
+	var d boardDocument
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+
+	nb, err := d.board()
+	if err != nil {
+		return err
+	}
+	// replayed separately as UndoLastMove modifies the board
+	walk, err := d.board()
+	if err != nil {
+		return err
+	}
+	walk.invariantChecks = false
+	for i := len(walk.roundHistory) - 1; i >= 0; i-- {
+		hash := walk.calculateBoardStateHash(walk.roundHistory[i].Move, walk.activeColor)
+		nb.roundHistory[i].BoardStateHash = hash
+		nb.boardStateHashMapCount[hash]++
+		walk.UndoLastMove()
+	}
+
+	*b = *nb
+	if b.invariantChecks {
+		return b.CheckInvariants()
+	}
+	return nil
+}
+
+// board without repetition hashes
+func (d boardDocument) board() (*Board, error) {
+	b := NewEmptyBoard(d.ActiveColor)
+	b.variant = d.Variant
+	b.drawCounter = d.DrawCounter
+	b.startPly = d.StartPly
+	if d.StartLastMove != nil {
+		m, err := d.StartLastMove.move()
+		if err != nil {
+			return nil, err
+		}
+		b.startLastMove = m
+	}
+
+	for _, color := range Colors {
+		pp, err := fromPieceDocuments(d.Pieces[color])
+		if err != nil {
+			return nil, err
+		}
+		if err := b.LoadPieces(pp); err != nil {
+			return nil, fmt.Errorf("failed to load %v pieces: %w", color, err)
+		}
+	}
+
+	graveyard, err := fromPieceDocuments(d.Graveyard)
+	if err != nil {
+		return nil, err
+	}
+	b.graveyard = append(b.graveyard, graveyard...)
+
+	for color, symbols := range d.Pockets {
+		for _, s := range symbols {
+			if err := b.AddToPocket(color, s); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, rd := range d.History {
+		m, err := rd.Move.move()
+		if err != nil {
+			return nil, err
+		}
+		exploded, err := fromPieceDocuments(rd.Exploded)
+		if err != nil {
+			return nil, err
+		}
+		b.roundHistory = append(b.roundHistory, round{
+			Move:            m,
+			PrevDrawCounter: rd.PrevDrawCounter,
+			Check:           rd.Check,
+			Exploded:        exploded,
+		})
+	}
+	return b, nil
+}
+
+func toPieceDocuments(pp []Piece) []pieceDocument {
+	if pp == nil {
+		return nil
+	}
+	docs := make([]pieceDocument, len(pp))
+	for i, p := range pp {
+		docs[i] = pieceDocument{
+			Symbol:    p.symbol,
+			Color:     p.color,
+			Square:    SquareName(p.position),
+			MoveCount: p.moveCount,
+			Promoted:  p.promoted,
+		}
+	}
+	return docs
+}
+
+// fromPieceDocuments nil if docs is empty
+func fromPieceDocuments(docs []pieceDocument) ([]Piece, error) {
+	if len(docs) == 0 {
+		return nil, nil
+	}
+	pp := make([]Piece, len(docs))
+	for i, d := range docs {
+		pos, err := ParseSquare(d.Square)
+		if err != nil {
+			return nil, err
+		}
+		pp[i] = Piece{
+			symbol:    d.Symbol,
+			color:     d.Color,
+			position:  pos,
+			moveCount: d.MoveCount,
+			promoted:  d.Promoted,
+		}
+	}
+	return pp, nil
+}
+
+func toMoveDocument(m Move) moveDocument {
+	return moveDocument{
+		Color:       m.Color,
+		Symbol:      m.Symbol,
+		From:        optionalSquareName(m.From),
+		To:          SquareName(m.To),
+		IsCastling:  m.IsCastling,
+		RookFrom:    optionalSquareName(m.RookFrom),
+		RookTo:      optionalSquareName(m.RookTo),
+		Captured:    m.Captured,
+		Promotion:   m.Promotion,
+		IsEnPassant: m.IsEnPassant,
+		IsDrop:      m.IsDrop,
+	}
+}
+
+func (d moveDocument) move() (Move, error) {
+	m := Move{
+		Color:       d.Color,
+		Symbol:      d.Symbol,
+		IsCastling:  d.IsCastling,
+		Captured:    d.Captured,
+		Promotion:   d.Promotion,
+		IsEnPassant: d.IsEnPassant,
+		IsDrop:      d.IsDrop,
+	}
+	var err error
+	if m.To, err = ParseSquare(d.To); err != nil {
+		return Move{}, err
+	}
+	if m.From, err = parseOptionalSquare(d.From); err != nil {
+		return Move{}, err
+	}
+	if m.RookFrom, err = parseOptionalSquare(d.RookFrom); err != nil {
+		return Move{}, err
+	}
+	if m.RookTo, err = parseOptionalSquare(d.RookTo); err != nil {
+		return Move{}, err
+	}
+	return m, nil
+}
+
+// optionalSquareName empty for position 0, unused squares of a move
+func optionalSquareName(pos int) string {
+	if pos == 0 {
+		return ""
+	}
+	return SquareName(pos)
+}
+
+func parseOptionalSquare(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return ParseSquare(s)
+}
@@ -0,0 +1,37 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type persistScenario struct {
+	name    string
+	fen     string
+	variant Variant
+	sans    []string
+}
+
+// persistScenarios positions covered by the golden files in testdata/board
+var persistScenarios = []persistScenario{
+	{name: "standard", fen: StartFEN, sans: []string{"e4", "e5", "Nf3", "Nc6", "Bb5", "a6", "Bxc6", "dxc6", "O-O"}},
+	{name: "fen_en_passant", fen: "rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3", sans: []string{"exf6", "Nxf6"}},
+	{name: "promotion", fen: "r1k5/1P6/8/8/8/8/8/4K3 w - - 0 40", sans: []string{"bxa8=Q+", "Kc7", "Qb7+"}},
+	{name: "crazyhouse", fen: StartFEN, variant: VariantCrazyhouse, sans: []string{"e4", "d5", "exd5", "Qxd5", "Nc3", "Qa5", "P@d4"}},
+	{name: "atomic", fen: StartFEN, variant: VariantAtomic, sans: []string{"e4", "d5", "exd5", "Nf6"}},
+	{name: "three_check", fen: StartFEN, variant: VariantThreeCheck, sans: []string{"e4", "f6", "Qh5+", "g6"}},
+}
+
+func (sc persistScenario) build(t *testing.T) *Board {
+	t.Helper()
+	b, err := NewBoardFromFEN(sc.fen)
+	require.NoError(t, err)
+	b.SetVariant(sc.variant)
+	for _, san := range sc.sans {
+		m, err := b.ParseSAN(san)
+		require.NoError(t, err, san)
+		require.NoError(t, b.ApplyMove(m), san)
+	}
+	return b
+}
@@ -2,11 +2,19 @@ package engine
 
 import (
 	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/dyxj/chess/pkg/versionx"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
 func TestBoard_SaveAndLoad(t *testing.T) {
 	originalBoard := NewBoard()
 	moves := []Move{
@@ -40,3 +48,71 @@ func TestBoard_SaveAndLoad(t *testing.T) {
 	assert.Equal(t, originalBoard.drawCounter, loadedBoard.drawCounter)
 	assert.Equal(t, originalBoard.boardStateHashMapCount, loadedBoard.boardStateHashMapCount)
 }
+
+func assertBoardEqual(t *testing.T, expected *Board, actual *Board) {
+	t.Helper()
+	assert.Equal(t, expected.cells, actual.cells)
+	assert.Equal(t, expected.whitePieces, actual.whitePieces)
+	assert.Equal(t, expected.blackPieces, actual.blackPieces)
+	assert.Equal(t, expected.whiteKingPos, actual.whiteKingPos)
+	assert.Equal(t, expected.blackKingPos, actual.blackKingPos)
+	assert.Equal(t, expected.roundHistory, actual.roundHistory)
+	assert.Equal(t, expected.activeColor, actual.activeColor)
+	assert.Equal(t, expected.graveyard, actual.graveyard)
+	assert.Equal(t, expected.drawCounter, actual.drawCounter)
+	assert.Equal(t, expected.boardStateHashMapCount, actual.boardStateHashMapCount)
+	assert.Equal(t, expected.startLastMove, actual.startLastMove)
+	assert.Equal(t, expected.startPly, actual.startPly)
+	assert.Equal(t, expected.variant, actual.variant)
+	assert.Equal(t, expected.whitePocket, actual.whitePocket)
+	assert.Equal(t, expected.blackPocket, actual.blackPocket)
+	assert.Equal(t, expected.FEN(), actual.FEN())
+}
+
+// TestBoard_SaveGolden documents of the current version, run with -update to rewrite them.
+// Golden files of released versions must keep loading, see TestBoard_LoadGolden.
+func TestBoard_SaveGolden(t *testing.T) {
+	for _, sc := range persistScenarios {
+		t.Run(sc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, sc.build(t).Save(&buf))
+
+			var indented bytes.Buffer
+			require.NoError(t, json.Indent(&indented, buf.Bytes(), "", "  "))
+			indented.WriteByte('\n')
+
+			path := filepath.Join("testdata", "board", sc.name+".json")
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(path, indented.Bytes(), 0o644))
+			}
+			golden, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, string(golden), indented.String())
+		})
+	}
+}
+
+func TestBoard_LoadGolden(t *testing.T) {
+	for _, ext := range []string{".json", ".gob"} {
+		for _, sc := range persistScenarios {
+			t.Run(sc.name+ext, func(t *testing.T) {
+				f, err := os.Open(filepath.Join("testdata", "board", sc.name+ext))
+				require.NoError(t, err)
+				defer f.Close()
+
+				loaded := NewEmptyBoard()
+				require.NoError(t, loaded.Load(f))
+				assertBoardEqual(t, sc.build(t), loaded)
+				assert.NoError(t, loaded.CheckInvariants())
+			})
+		}
+	}
+}
+
+func TestBoard_LoadUnsupportedVersion(t *testing.T) {
+	b := NewEmptyBoard()
+	err := b.Load(bytes.NewReader([]byte(`{"version":99}`)))
+	assert.ErrorIs(t, err, versionx.ErrUnsupportedVersion)
+
+	assert.Len(t, boardMigrations, BoardFormatVersion-1, "one migration per version")
+}
@@ -26,11 +26,49 @@ const (
 
 var Symbols = []Symbol{Pawn, Knight, Bishop, Rook, Queen, King}
 
+func (s Symbol) String() string {
+	switch s {
+	case Pawn:
+		return "pawn"
+	case Knight:
+		return "knight"
+	case Bishop:
+		return "bishop"
+	case Rook:
+		return "rook"
+	case Queen:
+		return "queen"
+	case King:
+		return "king"
+	default:
+		return "unknown"
+	}
+}
+
+// Value material value of the symbol, pawn 1, knight and bishop 3, rook 5, queen 9.
+// The king has no material value.
+func (s Symbol) Value() int {
+	switch s {
+	case Pawn:
+		return 1
+	case Knight, Bishop:
+		return 3
+	case Rook:
+		return 5
+	case Queen:
+		return 9
+	default:
+		return 0
+	}
+}
+
 type Piece struct {
 	symbol    Symbol
 	color     Color
 	position  int
 	moveCount int
+	// promoted piece was promoted from a pawn
+	promoted bool
 }
 
 func NewPiece(
@@ -67,12 +105,17 @@ func (p *Piece) HasMoved() bool {
 	return p.moveCount > 0
 }
 
+func (p *Piece) Promoted() bool {
+	return p.promoted
+}
+
 func (p *Piece) WithPosition(pos int) Piece {
 	return Piece{
 		symbol:    p.symbol,
 		color:     p.color,
 		position:  pos,
 		moveCount: p.moveCount,
+		promoted:  p.promoted,
 	}
 }
 
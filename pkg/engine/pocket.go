@@ -0,0 +1,137 @@
+package engine
+
+// DropSymbols pieces that can be held in a pocket and dropped, VariantCrazyhouse and VariantBughouse
+var DropSymbols = []Symbol{Pawn, Knight, Bishop, Rook, Queen}
+
+// pocket piece counts indexed by Symbol
+type pocket [King + 1]int
+
+func (b *Board) pocket(color Color) *pocket {
+	if color == White {
+		return &b.whitePocket
+	}
+	return &b.blackPocket
+}
+
+// Pocket pieces held by color, ordered as DropSymbols.
+func (b *Board) Pocket(color Color) []Symbol {
+	p := b.pocket(color)
+	symbols := make([]Symbol, 0, 8)
+	for _, s := range DropSymbols {
+		for range p[s] {
+			symbols = append(symbols, s)
+		}
+	}
+	return symbols
+}
+
+// AddToPocket adds s to the pocket of color,
+// for custom setups and pieces captured on the partner board in bughouse.
+func (b *Board) AddToPocket(color Color, s Symbol) error {
+	if s < Pawn || s >= King {
+		return ErrInvalidDrop
+	}
+	b.pocket(color)[s]++
+	return nil
+}
+
+// PocketSymbol symbol added to a pocket when p is captured,
+// captured promoted pieces revert to pawns.
+func (p Piece) PocketSymbol() Symbol {
+	if p.promoted {
+		return Pawn
+	}
+	return p.symbol
+}
+
+// generateDropMoves pseudo legal drops of pocket pieces onto empty squares,
+// pawns may not be dropped on the first or last rank.
+func (b *Board) generateDropMoves(color Color) []Move {
+	if !b.variant.HasPockets() {
+		return nil
+	}
+
+	p := b.pocket(color)
+	moves := make([]Move, 0, 64)
+	for _, s := range DropSymbols {
+		if p[s] == 0 {
+			continue
+		}
+		for _, pos := range indexToMailbox {
+			if !b.IsEmpty(pos) || !isDropRank(s, pos) {
+				continue
+			}
+			moves = append(moves, Move{
+				Color:  color,
+				Symbol: s,
+				To:     pos,
+				IsDrop: true,
+			})
+		}
+	}
+	return moves
+}
+
+// GenerateLegalDropMoves legal drops of color, empty unless the variant has pockets.
+func (b *Board) GenerateLegalDropMoves(color Color) []Move {
+	return b.FilterLegalMoves(b.generateDropMoves(color))
+}
+
+func isDropRank(s Symbol, pos int) bool {
+	rank := rankOf(pos)
+	return s != Pawn || (rank != 0 && rank != 7)
+}
+
+func (b *Board) validateDropToApply(m Move) error {
+	if !b.variant.HasPockets() {
+		return ErrInvalidDrop
+	}
+	if m.To < 0 || m.To > len(b.cells)-1 || b.IsSentinel(m.To) {
+		return ErrOutOfBoard
+	}
+	if !b.IsEmpty(m.To) {
+		return ErrOccupied
+	}
+	if m.Symbol < Pawn || m.Symbol >= King || b.pocket(m.Color)[m.Symbol] == 0 {
+		return ErrInvalidDrop
+	}
+	if !isDropRank(m.Symbol, m.To) {
+		return ErrInvalidDrop
+	}
+	return nil
+}
+
+// newDroppedPiece dropped pawns on their start rank may double move,
+// dropped rooks never grant castling rights.
+func newDroppedPiece(m Move) Piece {
+	hasMoved := true
+	if m.Symbol == Pawn {
+		startRank := 1
+		if m.Color == Black {
+			startRank = 6
+		}
+		hasMoved = rankOf(m.To) != startRank
+	}
+	return NewPiece(m.Symbol, m.Color, m.To, hasMoved)
+}
+
+// explainIllegalDrop explains why drop m cannot be played.
+func (b *Board) explainIllegalDrop(m Move) (IllegalMove, bool) {
+	switch {
+	case !b.variant.HasPockets() || m.Symbol < Pawn || m.Symbol >= King ||
+		b.pocket(m.Color)[m.Symbol] == 0:
+		return IllegalMove{Reason: IllegalDropNotInPocket}, true
+	case m.To < 0 || m.To >= boardSize || b.IsSentinel(m.To):
+		return IllegalMove{Reason: IllegalPieceMovement}, true
+	case !b.IsEmpty(m.To):
+		return b.illegalMoveAt(IllegalDropOccupied, m.To), true
+	case !isDropRank(m.Symbol, m.To):
+		return IllegalMove{Reason: IllegalDropPawnRank}, true
+	}
+
+	drop := Move{Color: m.Color, Symbol: m.Symbol, To: m.To, IsDrop: true}
+	if !b.isLegalMove(drop) {
+		return b.explainSelfCheck(drop), true
+	}
+	return IllegalMove{}, false
+}
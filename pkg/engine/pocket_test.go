@@ -0,0 +1,182 @@
+package engine
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrazyhouse_CaptureToPocket(t *testing.T) {
+	b := NewVariantBoard(VariantCrazyhouse)
+	applySANs(t, b, "e4", "d5", "exd5")
+
+	assert.Equal(t, []Symbol{Pawn}, b.Pocket(White))
+	assert.Empty(t, b.Pocket(Black))
+
+	applySANs(t, b, "Qxd5")
+	assert.Equal(t, []Symbol{Pawn}, b.Pocket(Black))
+
+	require.True(t, b.UndoLastMove())
+	assert.Empty(t, b.Pocket(Black))
+	assert.NoError(t, b.CheckInvariants())
+
+	sb := NewBoard()
+	applySANs(t, sb, "e4", "d5", "exd5")
+	assert.Empty(t, sb.Pocket(White), "standard games do not fill pockets")
+}
+
+func TestCrazyhouse_Drop(t *testing.T) {
+	b := NewVariantBoard(VariantCrazyhouse)
+	applySANs(t, b, "e4", "d5", "exd5", "Nf6")
+
+	drops := b.GenerateLegalDropMoves(White)
+	// 32 empty squares, pawns may not drop on the first or last rank
+	assert.Len(t, drops, 32)
+
+	applySANs(t, b, "P@e6")
+	assert.Equal(t, boardSymbol(Pawn, White), b.Value(75))
+	assert.Empty(t, b.Pocket(White))
+	assert.NoError(t, b.CheckInvariants())
+
+	m, ok := b.LastMove()
+	require.True(t, ok)
+	assert.True(t, m.IsDrop)
+	assert.Equal(t, "P@e6", b.sanWithoutSuffix(m, nil))
+
+	require.True(t, b.UndoLastMove())
+	assert.True(t, b.IsEmpty(75))
+	assert.Equal(t, []Symbol{Pawn}, b.Pocket(White))
+	assert.NoError(t, b.CheckInvariants())
+}
+
+func TestCrazyhouse_DroppedPawnOnStartRankMayDoubleMove(t *testing.T) {
+	b := newVariantBoardFromFEN(t, VariantCrazyhouse, "4k3/8/8/8/8/8/8/4K3 w - - 0 1")
+	require.NoError(t, b.AddToPocket(White, Pawn))
+	require.NoError(t, b.AddToPocket(White, Pawn))
+
+	applySANs(t, b, "@a2", "Kd7", "a4", "Ke7", "@b3", "Kd7")
+
+	_, err := b.ParseSAN("b5")
+	assert.ErrorIs(t, err, ErrInvalidSAN)
+}
+
+func TestCrazyhouse_PromotedPieceRevertsToPawn(t *testing.T) {
+	b := newVariantBoardFromFEN(t, VariantCrazyhouse, "1r2k3/P7/8/8/8/8/8/4K3 w - - 0 1")
+	applySANs(t, b, "a8=Q", "Rxa8")
+
+	assert.Equal(t, []Symbol{Pawn}, b.Pocket(Black))
+	assert.NoError(t, b.CheckInvariants())
+
+	require.True(t, b.UndoLastMove())
+	queen, ok := b.Piece(White, Queen, 91)
+	require.True(t, ok)
+	assert.True(t, queen.Promoted())
+	assert.Empty(t, b.Pocket(Black))
+}
+
+func TestCrazyhouse_DropBlocksMate(t *testing.T) {
+	fen := "7k/6pp/8/8/8/8/8/R3K3 w - - 0 1"
+
+	b := newVariantBoardFromFEN(t, VariantCrazyhouse, fen)
+	applySANs(t, b, "Ra8")
+	assert.False(t, b.HasLegalMoves(Black), "mate without pocket pieces")
+
+	b = newVariantBoardFromFEN(t, VariantCrazyhouse, fen)
+	require.NoError(t, b.AddToPocket(Black, Knight))
+	applySANs(t, b, "Ra8")
+	require.True(t, b.HasLegalMoves(Black))
+
+	drops := b.GenerateLegalDropMoves(Black)
+	require.Len(t, drops, 6, "b8 to g8")
+	assert.Equal(t, "N@b8", b.sanWithoutSuffix(drops[0], drops))
+}
+
+func TestCrazyhouse_ExplainIllegalDrop(t *testing.T) {
+	b := newVariantBoardFromFEN(t, VariantCrazyhouse, "4k3/8/8/8/8/8/4P3/4K2r w - - 0 1")
+	require.NoError(t, b.AddToPocket(White, Pawn))
+
+	tt := []struct {
+		name   string
+		move   Move
+		reason IllegalMoveReason
+	}{
+		{
+			name:   "not in pocket",
+			move:   Move{Color: White, Symbol: Knight, To: 55, IsDrop: true},
+			reason: IllegalDropNotInPocket,
+		},
+		{
+			name:   "occupied",
+			move:   Move{Color: White, Symbol: Pawn, To: 35, IsDrop: true},
+			reason: IllegalDropOccupied,
+		},
+		{
+			name:   "pawn on last rank",
+			move:   Move{Color: White, Symbol: Pawn, To: 91, IsDrop: true},
+			reason: IllegalDropPawnRank,
+		},
+		{
+			name:   "king left in check",
+			move:   Move{Color: White, Symbol: Pawn, To: 55, IsDrop: true},
+			reason: IllegalKingInCheck,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			im, illegal := b.ExplainIllegalMove(tc.move)
+			require.True(t, illegal)
+			assert.Equal(t, tc.reason, im.Reason)
+		})
+	}
+
+	assert.ErrorIs(t, b.ApplyMove(tt[0].move), ErrInvalidDrop)
+	assert.ErrorIs(t, b.ApplyMove(tt[1].move), ErrOccupied)
+	assert.ErrorIs(t, b.ApplyMove(tt[2].move), ErrInvalidDrop)
+}
+
+func TestCrazyhouse_SaveAndLoad(t *testing.T) {
+	b := newVariantBoardFromFEN(t, VariantCrazyhouse, "1r2k3/P7/8/8/8/8/8/4K3 w - - 0 1")
+	applySANs(t, b, "a8=Q", "Rxa8")
+
+	var buf bytes.Buffer
+	require.NoError(t, b.Save(&buf))
+
+	loaded := NewEmptyBoard()
+	require.NoError(t, loaded.Load(&buf))
+
+	assert.Equal(t, VariantCrazyhouse, loaded.Variant())
+	assert.Equal(t, []Symbol{Pawn}, loaded.Pocket(Black))
+	require.True(t, loaded.UndoLastMove())
+	queen, ok := loaded.Piece(White, Queen, 91)
+	require.True(t, ok)
+	assert.True(t, queen.Promoted())
+}
+
+func TestBughouse_CaptureNotInOwnPocket(t *testing.T) {
+	b := NewVariantBoard(VariantBughouse)
+	applySANs(t, b, "e4", "d5")
+
+	_, ok := b.LastCaptured()
+	assert.False(t, ok)
+
+	applySANs(t, b, "exd5")
+	assert.Empty(t, b.Pocket(White), "captures go to the partner board")
+
+	captured, ok := b.LastCaptured()
+	require.True(t, ok)
+	assert.Equal(t, Pawn, captured.PocketSymbol())
+	assert.Equal(t, Black, captured.Color())
+
+	// piece passed from the partner board
+	require.NoError(t, b.AddToPocket(Black, Knight))
+	applySANs(t, b, "N@e5")
+	assert.Empty(t, b.Pocket(Black))
+	assert.NoError(t, b.CheckInvariants())
+
+	require.True(t, b.UndoLastMove())
+	assert.Equal(t, []Symbol{Knight}, b.Pocket(Black))
+	assert.NoError(t, b.CheckInvariants())
+}
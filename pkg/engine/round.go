@@ -4,8 +4,12 @@ package engine
 // Move: applied move
 // PrevDrawCounter draw counter from previous round
 // BoardStateHash calculated after move applied and with opposite color
+// Check move gave check, only tracked for VariantThreeCheck
+// Exploded pieces removed by an atomic capture, excluding the captured piece
 type round struct {
 	Move            Move
 	PrevDrawCounter int
 	BoardStateHash  uint64
+	Check           bool
+	Exploded        []Piece
 }
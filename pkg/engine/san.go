@@ -0,0 +1,133 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SAN returns Standard Algebraic Notation of a legal move in the current position,
+// including check(+) and checkmate(#) suffixes.
+func (b *Board) SAN(m Move) string {
+	san := b.sanWithoutSuffix(m, b.GenerateLegalMoves(m.Color))
+
+	if err := b.ApplyMove(m); err != nil {
+		return san
+	}
+	defer b.UndoLastMove()
+
+	xColor := m.Color.Opposite()
+	if b.IsCheck(xColor) {
+		if b.HasLegalMoves(xColor) {
+			return san + "+"
+		}
+		return san + "#"
+	}
+	return san
+}
+
+func (b *Board) sanWithoutSuffix(m Move, legalMoves []Move) string {
+	if m.IsCastling {
+		if m.RookFrom > m.From {
+			return "O-O"
+		}
+		return "O-O-O"
+	}
+
+	sb := strings.Builder{}
+	if m.IsDrop {
+		sb.WriteByte(pieceLetter(m.Symbol, White))
+		sb.WriteByte('@')
+		sb.WriteString(SquareName(m.To))
+		return sb.String()
+	}
+
+	if m.Symbol == Pawn {
+		if m.hasCaptured() {
+			sb.WriteByte(SquareName(m.From)[0])
+		}
+	} else {
+		sb.WriteByte(pieceLetter(m.Symbol, White))
+		sb.WriteString(disambiguation(m, legalMoves))
+	}
+
+	if m.hasCaptured() {
+		sb.WriteByte('x')
+	}
+	sb.WriteString(SquareName(m.To))
+
+	if m.hasPromotion() {
+		sb.WriteByte('=')
+		sb.WriteByte(pieceLetter(m.Promotion, White))
+	}
+
+	return sb.String()
+}
+
+// disambiguation returns the from file, rank or square required to distinguish m
+// from other legal moves of the same piece type to the same square.
+func disambiguation(m Move, legalMoves []Move) string {
+	ambiguous, sameFile, sameRank := false, false, false
+	for _, other := range legalMoves {
+		if other.Symbol != m.Symbol || other.To != m.To || other.From == m.From {
+			continue
+		}
+		ambiguous = true
+		if fileOf(other.From) == fileOf(m.From) {
+			sameFile = true
+		}
+		if rankOf(other.From) == rankOf(m.From) {
+			sameRank = true
+		}
+	}
+
+	from := SquareName(m.From)
+	switch {
+	case !ambiguous:
+		return ""
+	case !sameFile:
+		return from[:1]
+	case !sameRank:
+		return from[1:]
+	default:
+		return from
+	}
+}
+
+// ParseSAN finds the legal move of the active color described by san.
+// Check, checkmate and annotation suffixes are ignored, castling may use either O or 0
+// and the promotion "=" and capture "x" markers are optional.
+func (b *Board) ParseSAN(san string) (Move, error) {
+	want := normalizeSAN(san)
+	if want == "" {
+		return Move{}, fmt.Errorf("%w: empty move", ErrInvalidSAN)
+	}
+
+	moves := b.GenerateLegalMoves(b.activeColor)
+	for _, m := range moves {
+		if normalizeSAN(b.sanWithoutSuffix(m, moves)) == want {
+			return m, nil
+		}
+	}
+
+	// lenient match for notations omitting or adding the capture marker
+	want = strings.ReplaceAll(want, "x", "")
+	for _, m := range moves {
+		if strings.ReplaceAll(normalizeSAN(b.sanWithoutSuffix(m, moves)), "x", "") == want {
+			return m, nil
+		}
+	}
+
+	return Move{}, fmt.Errorf("%w: %q is not a legal move", ErrInvalidSAN, san)
+}
+
+func normalizeSAN(san string) string {
+	san = strings.TrimSpace(san)
+	san = strings.TrimRight(san, "+#!?")
+	san = strings.ReplaceAll(san, "0", "O")
+	san = strings.ReplaceAll(san, "=", "")
+	// pawn drops may omit the piece letter
+	if strings.HasPrefix(san, "@") {
+		san = "P" + san
+	}
+	return san
+}
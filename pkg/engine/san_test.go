@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoard_SAN(t *testing.T) {
+	tt := []struct {
+		name   string
+		fen    string
+		move   Move
+		expect string
+	}{
+		{
+			name:   "pawn push",
+			fen:    StartFEN,
+			move:   Move{Color: White, Symbol: Pawn, From: 35, To: 55},
+			expect: "e4",
+		},
+		{
+			name:   "knight",
+			fen:    StartFEN,
+			move:   Move{Color: White, Symbol: Knight, From: 27, To: 46},
+			expect: "Nf3",
+		},
+		{
+			name:   "pawn capture",
+			fen:    "4k3/8/8/3p4/4P3/8/8/4K3 w - - 0 1",
+			move:   Move{Color: White, Symbol: Pawn, From: 55, To: 64, Captured: Pawn},
+			expect: "exd5",
+		},
+		{
+			name:   "file disambiguation",
+			fen:    "4k3/8/8/8/8/8/8/R4RK1 w - - 0 1",
+			move:   Move{Color: White, Symbol: Rook, From: 21, To: 24},
+			expect: "Rad1",
+		},
+		{
+			name:   "rank disambiguation",
+			fen:    "4k3/8/8/R7/8/8/8/R3K3 w - - 0 1",
+			move:   Move{Color: White, Symbol: Rook, From: 21, To: 41},
+			expect: "R1a3",
+		},
+		{
+			name:   "castling king side",
+			fen:    "5k2/8/8/8/8/8/8/4K2R w K - 0 1",
+			move:   Move{Color: White, Symbol: King, From: 25, To: 27, IsCastling: true, RookFrom: 28, RookTo: 26},
+			expect: "O-O+",
+		},
+		{
+			name:   "promotion with mate",
+			fen:    "k7/2P5/1K6/8/8/8/8/8 w - - 0 1",
+			move:   Move{Color: White, Symbol: Pawn, From: 83, To: 93, Promotion: Queen},
+			expect: "c8=Q#",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := NewBoardFromFEN(tc.fen)
+			require.NoError(t, err)
+			before := b.FEN()
+
+			assert.Equal(t, tc.expect, b.SAN(tc.move))
+			assert.Equal(t, before, b.FEN(), "board should be restored")
+
+			parsed, err := b.ParseSAN(tc.expect)
+			require.NoError(t, err)
+			assert.Equal(t, tc.move, parsed)
+		})
+	}
+}
+
+func TestBoard_ParseSAN_Lenient(t *testing.T) {
+	b, err := NewBoardFromFEN("k7/2P5/1K6/8/8/8/8/8 w - - 0 1")
+	require.NoError(t, err)
+
+	m, err := b.ParseSAN("c8Q")
+	require.NoError(t, err)
+	assert.Equal(t, Queen, m.Promotion)
+
+	b = NewBoard()
+	_, err = b.ParseSAN("e5")
+	assert.ErrorIs(t, err, ErrInvalidSAN)
+}
@@ -0,0 +1,33 @@
+package engine
+
+import "fmt"
+
+// SquareName returns the algebraic name of a mailbox position, e.g. 21 -> "a1".
+// Returns "-" if position is not on the board.
+func SquareName(pos int) string {
+	idx := MailboxToIndex(pos)
+	if idx < 0 {
+		return "-"
+	}
+	return string([]byte{byte('a' + idx%8), byte('1' + idx/8)})
+}
+
+// ParseSquare converts an algebraic square name, e.g. "e4", to a mailbox position.
+func ParseSquare(s string) (int, error) {
+	if len(s) != 2 || s[0] < 'a' || s[0] > 'h' || s[1] < '1' || s[1] > '8' {
+		return 0, fmt.Errorf("%w: invalid square %q", ErrOutOfBoard, s)
+	}
+	file := int(s[0] - 'a')
+	rank := int(s[1] - '1')
+	return indexToMailbox[rank*8+file], nil
+}
+
+// rankOf returns rank 0-7 of a mailbox position
+func rankOf(pos int) int {
+	return pos/boardWidth - 2
+}
+
+// fileOf returns file 0-7 of a mailbox position
+func fileOf(pos int) int {
+	return pos%boardWidth - 1
+}
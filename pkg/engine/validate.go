@@ -0,0 +1,259 @@
+package engine
+
+import "fmt"
+
+type PositionProblemCode string
+
+const (
+	ProblemKingCount        PositionProblemCode = "king_count"
+	ProblemPawnOnBackRank   PositionProblemCode = "pawn_on_back_rank"
+	ProblemTooManyPieces    PositionProblemCode = "too_many_pieces"
+	ProblemTooManyPawns     PositionProblemCode = "too_many_pawns"
+	ProblemTooManyPromoted  PositionProblemCode = "too_many_promoted"
+	ProblemInactiveInCheck  PositionProblemCode = "inactive_side_in_check"
+	ProblemCastlingRights   PositionProblemCode = "castling_rights"
+	ProblemEnPassantInvalid PositionProblemCode = "en_passant_invalid"
+)
+
+// PositionProblem describes why a position cannot arise in a legal game.
+// Position is the mailbox position of the offending piece or square, 0 if not applicable.
+type PositionProblem struct {
+	Code     PositionProblemCode
+	Color    Color
+	Position int
+	Message  string
+}
+
+func (p PositionProblem) String() string {
+	return fmt.Sprintf("%s: %s", p.Code, p.Message)
+}
+
+// maximum number of each piece in the start position
+var startPieceCount = map[Symbol]int{
+	Knight: 2,
+	Bishop: 2,
+	Rook:   2,
+	Queen:  1,
+}
+
+// Validate checks that the position could be reached in a legal game.
+// It is intended for custom setups, returns nil if no problems are found.
+//
+// Checks
+// - each side has exactly one king, any number for antichess
+// - no pawns on the first or last rank
+// - piece counts, extra pieces must be covered by missing pawns through promotion
+// - side not to move is not in check
+// - unmoved kings and rooks, which grant castling rights, are on their start squares
+// - castling rights of a loaded position have the king and rook on their start squares
+// - en passant square of a loaded position follows a pawn double move
+func (b *Board) Validate() []PositionProblem {
+	var problems []PositionProblem
+	for _, color := range Colors {
+		problems = append(problems, b.validatePieces(color)...)
+		problems = append(problems, b.validateCastlingRights(color)...)
+	}
+
+	inactive := b.activeColor.Opposite()
+	if b.countSymbol(inactive, King) == 1 && b.IsCheck(inactive) {
+		problems = append(problems, PositionProblem{
+			Code:     ProblemInactiveInCheck,
+			Color:    inactive,
+			Position: b.kingPosition(inactive),
+			Message:  fmt.Sprintf("%v is in check but it is %v to move", inactive, b.activeColor),
+		})
+	}
+
+	problems = append(problems, b.validateEnPassant()...)
+
+	return problems
+}
+
+func (b *Board) validatePieces(color Color) []PositionProblem {
+	var problems []PositionProblem
+
+	if kings := b.countSymbol(color, King); kings != 1 && b.variant.royalKing() {
+		problems = append(problems, PositionProblem{
+			Code:    ProblemKingCount,
+			Color:   color,
+			Message: fmt.Sprintf("%v has %d kings, expected 1", color, kings),
+		})
+	}
+
+	for _, p := range b.Pieces(color) {
+		rank := rankOf(p.position)
+		if p.symbol == Pawn && (rank == 0 || rank == 7) {
+			problems = append(problems, PositionProblem{
+				Code:     ProblemPawnOnBackRank,
+				Color:    color,
+				Position: p.position,
+				Message:  fmt.Sprintf("%v pawn on %s", color, SquareName(p.position)),
+			})
+		}
+	}
+
+	if count := len(b.Pieces(color)); count > 16 {
+		problems = append(problems, PositionProblem{
+			Code:    ProblemTooManyPieces,
+			Color:   color,
+			Message: fmt.Sprintf("%v has %d pieces, maximum is 16", color, count),
+		})
+	}
+
+	pawns := b.countSymbol(color, Pawn)
+	if pawns > 8 {
+		problems = append(problems, PositionProblem{
+			Code:    ProblemTooManyPawns,
+			Color:   color,
+			Message: fmt.Sprintf("%v has %d pawns, maximum is 8", color, pawns),
+		})
+	}
+
+	promoted := 0
+	for symbol, limit := range startPieceCount {
+		if extra := b.countSymbol(color, symbol) - limit; extra > 0 {
+			promoted += extra
+		}
+	}
+	if missingPawns := max(8-pawns, 0); promoted > missingPawns {
+		problems = append(problems, PositionProblem{
+			Code:  ProblemTooManyPromoted,
+			Color: color,
+			Message: fmt.Sprintf("%v has %d pieces beyond the starting set but only %d missing pawns",
+				color, promoted, missingPawns),
+		})
+	}
+
+	return problems
+}
+
+// validateCastlingRights unmoved kings and rooks are treated as having castling rights,
+// so they must be on their start squares.
+func (b *Board) validateCastlingRights(color Color) []PositionProblem {
+	var problems []PositionProblem
+
+	base := 20
+	if color == Black {
+		base = 90
+	}
+
+	for _, p := range b.Pieces(color) {
+		if p.HasMoved() {
+			continue
+		}
+		if p.symbol == King && p.position != base+5 {
+			problems = append(problems, PositionProblem{
+				Code:     ProblemCastlingRights,
+				Color:    color,
+				Position: p.position,
+				Message:  fmt.Sprintf("%v king with castling rights is on %s", color, SquareName(p.position)),
+			})
+		}
+		if p.symbol == Rook && rankOf(p.position) == rankOf(base+1) &&
+			p.position != base+1 && p.position != base+8 {
+			problems = append(problems, PositionProblem{
+				Code:     ProblemCastlingRights,
+				Color:    color,
+				Position: p.position,
+				Message:  fmt.Sprintf("%v rook with castling rights is on %s", color, SquareName(p.position)),
+			})
+		}
+	}
+
+	return append(problems, b.validateStartCastling(color)...)
+}
+
+// validateStartCastling rights claimed by the castling field of a loaded position
+// require the king and the rook of the side on their start squares.
+func (b *Board) validateStartCastling(color Color) []PositionProblem {
+	if len(b.roundHistory) > 0 || b.startCastling == "" {
+		return nil
+	}
+
+	base := 20
+	rights := map[rune]int{'K': base + 8, 'Q': base + 1}
+	if color == Black {
+		base = 90
+		rights = map[rune]int{'k': base + 8, 'q': base + 1}
+	}
+
+	var problems []PositionProblem
+	kingMissing := false
+	for _, right := range b.startCastling {
+		rookPos, ok := rights[right]
+		if !ok {
+			continue
+		}
+		if !b.hasPieceAt(color, King, base+5) && !kingMissing {
+			kingMissing = true
+			problems = append(problems, PositionProblem{
+				Code:     ProblemCastlingRights,
+				Color:    color,
+				Position: base + 5,
+				Message: fmt.Sprintf("%v has castling rights %c but no king on %s",
+					color, right, SquareName(base+5)),
+			})
+		}
+		if !b.hasPieceAt(color, Rook, rookPos) {
+			problems = append(problems, PositionProblem{
+				Code:     ProblemCastlingRights,
+				Color:    color,
+				Position: rookPos,
+				Message: fmt.Sprintf("%v has castling rights %c but no rook on %s",
+					color, right, SquareName(rookPos)),
+			})
+		}
+	}
+	return problems
+}
+
+func (b *Board) hasPieceAt(color Color, symbol Symbol, pos int) bool {
+	return b.Color(pos) == color && b.Symbol(pos) == symbol
+}
+
+// validateEnPassant only the en passant square of a loaded position is checked,
+// moves in history have been validated when applied.
+func (b *Board) validateEnPassant() []PositionProblem {
+	if len(b.roundHistory) > 0 || b.startLastMove.Symbol == 0 {
+		return nil
+	}
+
+	m := b.startLastMove
+	target := (m.From + m.To) / 2
+	problem := PositionProblem{
+		Code:     ProblemEnPassantInvalid,
+		Color:    m.Color,
+		Position: target,
+	}
+
+	expectedRank := 2
+	if m.Color == Black {
+		expectedRank = 5
+	}
+	if rankOf(target) != expectedRank {
+		problem.Message = fmt.Sprintf("en passant square %s is not on rank %d", SquareName(target), expectedRank+1)
+		return []PositionProblem{problem}
+	}
+
+	if b.Symbol(m.To) != Pawn || b.Color(m.To) != m.Color {
+		problem.Message = fmt.Sprintf("en passant square %s has no %v pawn in front of it", SquareName(target), m.Color)
+		return []PositionProblem{problem}
+	}
+
+	if !b.IsEmpty(target) || !b.IsEmpty(m.From) {
+		problem.Message = fmt.Sprintf("en passant square %s or the square behind it is occupied", SquareName(target))
+		return []PositionProblem{problem}
+	}
+
+	return nil
+}
+
+func (b *Board) countSymbol(color Color, symbol Symbol) int {
+	count := 0
+	for _, p := range b.Pieces(color) {
+		if p.symbol == symbol {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoard_Validate_Valid(t *testing.T) {
+	tt := []string{
+		StartFEN,
+		"rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2",
+		"4k3/8/8/8/8/8/8/4K3 w - - 0 1",
+		// two queens with a missing pawn
+		"4k3/8/8/8/8/8/1PPPPPPP/QQ2K3 b - - 0 1",
+	}
+
+	for _, fen := range tt {
+		t.Run(fen, func(t *testing.T) {
+			b, err := NewBoardFromFEN(fen)
+			require.NoError(t, err)
+			assert.Empty(t, b.Validate())
+		})
+	}
+
+	t.Run("after moves", func(t *testing.T) {
+		b := NewBoard()
+		require.NoError(t, b.ApplyMove(Move{Color: White, Symbol: Pawn, From: 35, To: 55}))
+		assert.Empty(t, b.Validate())
+	})
+}
+
+func TestBoard_Validate_Problems(t *testing.T) {
+	tt := []struct {
+		name   string
+		fen    string
+		expect []PositionProblemCode
+	}{
+		{
+			name:   "two white kings",
+			fen:    "4k3/8/8/8/8/8/8/3KK3 w - - 0 1",
+			expect: []PositionProblemCode{ProblemKingCount},
+		},
+		{
+			name:   "missing black king",
+			fen:    "8/8/8/8/8/8/8/4K3 w - - 0 1",
+			expect: []PositionProblemCode{ProblemKingCount},
+		},
+		{
+			name:   "pawn on first rank",
+			fen:    "4k3/8/8/8/8/8/8/P3K3 w - - 0 1",
+			expect: []PositionProblemCode{ProblemPawnOnBackRank},
+		},
+		{
+			name:   "nine pawns",
+			fen:    "4k3/8/8/8/8/P7/PPPPPPPP/4K3 w - - 0 1",
+			expect: []PositionProblemCode{ProblemTooManyPawns},
+		},
+		{
+			name:   "promoted pieces without missing pawns",
+			fen:    "4k3/8/8/8/8/8/PPPPPPPP/QQ2K3 w - - 0 1",
+			expect: []PositionProblemCode{ProblemTooManyPromoted},
+		},
+		{
+			name:   "side not to move not in check",
+			fen:    "4k3/8/8/8/8/8/8/4KR2 w - - 0 1",
+			expect: nil,
+		},
+		{
+			name:   "side not to move in check",
+			fen:    "4k3/8/8/8/8/8/8/4R1K1 w - - 0 1",
+			expect: []PositionProblemCode{ProblemInactiveInCheck},
+		},
+		{
+			name:   "en passant without pawn",
+			fen:    "4k3/8/8/8/8/8/8/4K3 b - e3 0 1",
+			expect: []PositionProblemCode{ProblemEnPassantInvalid},
+		},
+		{
+			name:   "en passant on wrong rank",
+			fen:    "4k3/8/8/4P3/8/8/8/4K3 b - e4 0 1",
+			expect: []PositionProblemCode{ProblemEnPassantInvalid},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := NewBoardFromFEN(tc.fen)
+			require.NoError(t, err)
+
+			var codes []PositionProblemCode
+			for _, p := range b.Validate() {
+				codes = append(codes, p.Code)
+			}
+			assert.Equal(t, tc.expect, codes)
+		})
+	}
+}
+
+func TestBoard_Validate_CastlingRights(t *testing.T) {
+	b := NewEmptyBoard()
+	err := b.LoadPieces([]Piece{
+		NewPiece(King, White, 54),
+		NewPiece(Rook, White, 22),
+		NewPiece(King, Black, 95, true),
+	})
+	require.NoError(t, err)
+
+	problems := b.Validate()
+	require.Len(t, problems, 2)
+	assert.Equal(t, PositionProblem{
+		Code:     ProblemCastlingRights,
+		Color:    White,
+		Position: 54,
+		Message:  "white king with castling rights is on d4",
+	}, problems[0])
+	assert.Equal(t, ProblemCastlingRights, problems[1].Code)
+	assert.Equal(t, 22, problems[1].Position)
+}
+
+func TestBoard_Validate_StartCastling(t *testing.T) {
+	tt := []struct {
+		name   string
+		fen    string
+		expect []PositionProblem
+	}{
+		{
+			name: "king off its start square",
+			fen:  "r3k2r/8/8/8/8/8/8/R2K3R w KQkq - 0 1",
+			expect: []PositionProblem{{
+				Code:     ProblemCastlingRights,
+				Color:    White,
+				Position: 25,
+				Message:  "white has castling rights K but no king on e1",
+			}},
+		},
+		{
+			name: "rook off its start square",
+			fen:  "r3k2r/8/8/8/8/8/8/1R2K2R w KQkq - 0 1",
+			expect: []PositionProblem{{
+				Code:     ProblemCastlingRights,
+				Color:    White,
+				Position: 21,
+				Message:  "white has castling rights Q but no rook on a1",
+			}},
+		},
+		{
+			name: "black rook missing",
+			fen:  "4k2r/8/8/8/8/8/8/R3K2R b KQkq - 0 1",
+			expect: []PositionProblem{{
+				Code:     ProblemCastlingRights,
+				Color:    Black,
+				Position: 91,
+				Message:  "black has castling rights q but no rook on a8",
+			}},
+		},
+		{
+			name:   "king and rooks on their start squares",
+			fen:    "4k3/8/8/8/8/8/8/R3K2R w KQ - 0 1",
+			expect: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := NewBoardFromFEN(tc.fen)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, b.Validate())
+		})
+	}
+
+	t.Run("only the loaded position is checked", func(t *testing.T) {
+		b, err := NewBoardFromFEN("r3k2r/8/8/8/8/8/8/R2K3R w KQkq - 0 1")
+		require.NoError(t, err)
+		require.NoError(t, b.ApplyMove(Move{Color: White, Symbol: King, From: 24, To: 34}))
+		assert.Empty(t, b.Validate())
+	})
+}
@@ -0,0 +1,343 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/dyxj/chess/pkg/mathx"
+)
+
+// Variant rule set consulted by move generation and win detection.
+type Variant int
+
+const (
+	VariantStandard Variant = iota
+	// VariantKingOfTheHill king reaching a center square wins
+	VariantKingOfTheHill
+	// VariantThreeCheck third check wins
+	VariantThreeCheck
+	// VariantAntichess captures are forced, king is not royal,
+	// side without legal moves wins
+	VariantAntichess
+	// VariantAtomic captures explode all non-pawn pieces around the capture square,
+	// exploding the opponent king wins
+	VariantAtomic
+	// VariantCrazyhouse captured pieces join the capturer's pocket and can be dropped
+	VariantCrazyhouse
+	// VariantDarkChess players only see squares their pieces can move to,
+	// there is no check and capturing the king wins
+	VariantDarkChess
+	// VariantBughouse crazyhouse drops, pockets are filled by the partner's captures
+	// on a linked board instead of own captures
+	VariantBughouse
+)
+
+const (
+	variantStandardStr      = "standard"
+	variantKingOfTheHillStr = "king_of_the_hill"
+	variantThreeCheckStr    = "three_check"
+	variantAntichessStr     = "antichess"
+	variantAtomicStr        = "atomic"
+	variantCrazyhouseStr    = "crazyhouse"
+	variantDarkChessStr     = "dark_chess"
+	variantBughouseStr      = "bughouse"
+	variantUnknownStr       = "unknown"
+)
+
+var Variants = []Variant{
+	VariantStandard,
+	VariantKingOfTheHill,
+	VariantThreeCheck,
+	VariantAntichess,
+	VariantAtomic,
+	VariantCrazyhouse,
+	VariantDarkChess,
+	VariantBughouse,
+}
+
+func (v Variant) String() string {
+	switch v {
+	case VariantStandard:
+		return variantStandardStr
+	case VariantKingOfTheHill:
+		return variantKingOfTheHillStr
+	case VariantThreeCheck:
+		return variantThreeCheckStr
+	case VariantAntichess:
+		return variantAntichessStr
+	case VariantAtomic:
+		return variantAtomicStr
+	case VariantCrazyhouse:
+		return variantCrazyhouseStr
+	case VariantDarkChess:
+		return variantDarkChessStr
+	case VariantBughouse:
+		return variantBughouseStr
+	default:
+		return variantUnknownStr
+	}
+}
+
+func (v Variant) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (v *Variant) UnmarshalText(text []byte) error {
+	str := string(text)
+	for _, variant := range Variants {
+		if variant.String() == str {
+			*v = variant
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown variant: %s valid variant(standard,king_of_the_hill,three_check,antichess,atomic,crazyhouse,dark_chess,bughouse)", str)
+}
+
+// royalKing each side has exactly one king which is tracked
+func (v Variant) royalKing() bool {
+	return v != VariantAntichess
+}
+
+// hasCheck king can be checked and must not be left in check
+func (v Variant) hasCheck() bool {
+	return v != VariantAntichess && v != VariantDarkChess
+}
+
+// HasPockets pieces can be dropped from a pocket
+func (v Variant) HasPockets() bool {
+	return v == VariantCrazyhouse || v == VariantBughouse
+}
+
+func (v Variant) allowsCastling() bool {
+	return v != VariantAntichess
+}
+
+// VariantWin variant specific win condition
+type VariantWin int
+
+const (
+	VariantWinNone VariantWin = iota
+	// VariantWinKingOfTheHill king reached d4, e4, d5 or e5
+	VariantWinKingOfTheHill
+	// VariantWinThreeCheck third check given
+	VariantWinThreeCheck
+	// VariantWinNoMoves antichess side to move has no pieces or no legal moves
+	VariantWinNoMoves
+	// VariantWinExplosion atomic opponent king exploded
+	VariantWinExplosion
+	// VariantWinKingCaptured dark chess opponent king captured
+	VariantWinKingCaptured
+)
+
+const threeCheckLimit = 3
+
+// hillSquares d4, e4, d5, e5
+var hillSquares = []int{54, 55, 64, 65}
+
+// NewVariantBoard creates a board with the initial pieces for variant v.
+func NewVariantBoard(v Variant) *Board {
+	b := NewBoard()
+	b.variant = v
+	return b
+}
+
+func (b *Board) Variant() Variant {
+	return b.variant
+}
+
+// SetVariant sets the rule set, expected to be called before any move is applied.
+func (b *Board) SetVariant(v Variant) {
+	b.variant = v
+}
+
+// ChecksGiven number of checks given by color, only tracked for VariantThreeCheck.
+func (b *Board) ChecksGiven(color Color) int {
+	count := 0
+	for _, r := range b.roundHistory {
+		if r.Move.Color == color && r.Check {
+			count++
+		}
+	}
+	return count
+}
+
+// VariantWinner returns the winner if a variant specific win condition is reached.
+// Checkmate and stalemate are not considered.
+func (b *Board) VariantWinner() (Color, VariantWin) {
+	switch b.variant {
+	case VariantKingOfTheHill:
+		for _, color := range Colors {
+			for _, pos := range hillSquares {
+				if b.kingPosition(color) == pos && b.cells[pos] == boardSymbol(King, color) {
+					return color, VariantWinKingOfTheHill
+				}
+			}
+		}
+	case VariantThreeCheck:
+		for _, color := range Colors {
+			if b.ChecksGiven(color) >= threeCheckLimit {
+				return color, VariantWinThreeCheck
+			}
+		}
+	case VariantAntichess:
+		if !b.HasLegalMoves(b.activeColor) {
+			return b.activeColor, VariantWinNoMoves
+		}
+	case VariantAtomic:
+		for _, color := range Colors {
+			if b.kingPosition(color) == 0 && b.kingPosition(color.Opposite()) != 0 {
+				return color.Opposite(), VariantWinExplosion
+			}
+		}
+	case VariantDarkChess:
+		for _, color := range Colors {
+			if b.kingPosition(color) == 0 && b.kingPosition(color.Opposite()) != 0 {
+				return color.Opposite(), VariantWinKingCaptured
+			}
+		}
+	default:
+	}
+	return 0, VariantWinNone
+}
+
+// hasCapture color has at least one pseudo legal capture
+func (b *Board) hasCapture(color Color) bool {
+	for _, p := range b.Pieces(color) {
+		moves, err := b.GeneratePiecePseudoLegalMoves(p)
+		if err != nil {
+			// panic used here as it is a programmer error if b and piece list is out of sync
+			panic(err)
+		}
+		for _, m := range moves {
+			if m.hasCaptured() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (b *Board) promotionSymbols() []Symbol {
+	if b.variant == VariantAntichess {
+		return antichessPromotionSymbols
+	}
+	return PromotionSymbols
+}
+
+var antichessPromotionSymbols = []Symbol{Queen, Rook, Bishop, Knight, King}
+
+// kingsAdjacent atomic kings touching can not be checked,
+// capturing one would explode both
+func (b *Board) kingsAdjacent() bool {
+	wk, bk := b.kingPosition(White), b.kingPosition(Black)
+	if wk == 0 || bk == 0 {
+		return false
+	}
+	for _, direction := range pieceDirections[King] {
+		if wk+int(direction) == bk {
+			return true
+		}
+	}
+	return false
+}
+
+// explosionPositions capture square and all adjacent non-pawn pieces
+func (b *Board) explosionPositions(center int) []int {
+	positions := make([]int, 0, 9)
+	if !b.IsEmpty(center) {
+		positions = append(positions, center)
+	}
+	for _, direction := range pieceDirections[King] {
+		pos := center + int(direction)
+		if b.IsEmpty(pos) || b.IsSentinel(pos) || b.Symbol(pos) == Pawn {
+			continue
+		}
+		positions = append(positions, pos)
+	}
+	return positions
+}
+
+// explode removes exploded pieces from cells and piece lists,
+// returns removed pieces to be restored on undo
+func (b *Board) explode(center int) []Piece {
+	exploded := make([]Piece, 0, 9)
+	for _, pos := range b.explosionPositions(center) {
+		color := b.Color(pos)
+		pp := b.Pieces(color)
+		for i := 0; i < len(pp); i++ {
+			if pp[i].position == pos {
+				exploded = append(exploded, pp[i])
+				pp = append(pp[:i], pp[i+1:]...)
+				break
+			}
+		}
+		b.setPieces(color, pp)
+
+		if b.Symbol(pos) == King {
+			b.setKingPosition(color, 0)
+		}
+		b.cells[pos] = EmptyCell
+	}
+	return exploded
+}
+
+func (b *Board) undoExplode(exploded []Piece) {
+	for i := len(exploded) - 1; i >= 0; i-- {
+		p := exploded[i]
+		b.cells[p.position] = boardSymbolPiece(p)
+		b.setPieces(p.color, append(b.Pieces(p.color), p))
+		if p.symbol == King {
+			b.setKingPosition(p.color, p.position)
+		}
+	}
+}
+
+// isLegalAtomicMove kings may not capture, own king may not explode,
+// exploding the opponent king is legal even if own king is left in check.
+func (b *Board) isLegalAtomicMove(m Move) bool {
+	if m.Symbol == King && m.hasCaptured() {
+		return false
+	}
+
+	opponent := m.Color.Opposite()
+	hasKing := b.kingPosition(m.Color) != 0
+	opponentHasKing := b.kingPosition(opponent) != 0
+
+	b.applyMovePos(m)
+
+	type cell struct{ pos, value int }
+	var exploded []cell
+	if m.hasCaptured() {
+		for _, pos := range b.explosionPositions(m.To) {
+			exploded = append(exploded, cell{pos, b.cells[pos]})
+			if b.Symbol(pos) == King {
+				b.setKingPosition(b.Color(pos), 0)
+			}
+			b.cells[pos] = EmptyCell
+		}
+	}
+
+	isLegal := false
+	switch {
+	case hasKing && b.kingPosition(m.Color) == 0:
+		isLegal = false
+	case opponentHasKing && b.kingPosition(opponent) == 0:
+		isLegal = true
+	default:
+		isLegal = !b.IsCheck(m.Color)
+	}
+
+	for i := len(exploded) - 1; i >= 0; i-- {
+		b.cells[exploded[i].pos] = exploded[i].value
+		if Symbol(mathx.AbsInt(exploded[i].value)) == King {
+			color := White
+			if exploded[i].value < 0 {
+				color = Black
+			}
+			b.setKingPosition(color, exploded[i].pos)
+		}
+	}
+	b.undoMovePos(m)
+
+	return isLegal
+}
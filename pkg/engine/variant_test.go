@@ -0,0 +1,211 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newVariantBoardFromFEN(t *testing.T, v Variant, fen string) *Board {
+	t.Helper()
+	b, err := NewBoardFromFEN(fen)
+	require.NoError(t, err)
+	b.SetVariant(v)
+	return b
+}
+
+func applySANs(t *testing.T, b *Board, sans ...string) {
+	t.Helper()
+	for _, san := range sans {
+		m, err := b.ParseSAN(san)
+		require.NoError(t, err, san)
+		require.NoError(t, b.ApplyMove(m), san)
+	}
+}
+
+func TestVariant_Text(t *testing.T) {
+	for _, v := range Variants {
+		text, err := v.MarshalText()
+		require.NoError(t, err)
+
+		var got Variant
+		require.NoError(t, got.UnmarshalText(text))
+		assert.Equal(t, v, got)
+	}
+
+	var v Variant
+	assert.Error(t, v.UnmarshalText([]byte("chess960")))
+}
+
+func TestVariantWinner_Standard(t *testing.T) {
+	b := newVariantBoardFromFEN(t, VariantStandard, "4k3/8/8/8/8/3K4/8/8 w - - 0 1")
+	applySANs(t, b, "Kd4")
+
+	_, win := b.VariantWinner()
+	assert.Equal(t, VariantWinNone, win)
+}
+
+func TestVariantWinner_KingOfTheHill(t *testing.T) {
+	b := newVariantBoardFromFEN(t, VariantKingOfTheHill, "4k3/8/8/8/8/3K4/8/8 w - - 0 1")
+	applySANs(t, b, "Kc4")
+
+	_, win := b.VariantWinner()
+	assert.Equal(t, VariantWinNone, win)
+
+	applySANs(t, b, "Ke7", "Kd4")
+
+	winner, win := b.VariantWinner()
+	assert.Equal(t, VariantWinKingOfTheHill, win)
+	assert.Equal(t, White, winner)
+}
+
+func TestVariantWinner_ThreeCheck(t *testing.T) {
+	b := newVariantBoardFromFEN(t, VariantThreeCheck, "7k/8/8/8/8/8/8/R3K3 w - - 0 1")
+	applySANs(t, b, "Ra8+", "Kh7", "Ra7+", "Kg8")
+
+	assert.Equal(t, 2, b.ChecksGiven(White))
+	_, win := b.VariantWinner()
+	assert.Equal(t, VariantWinNone, win)
+
+	applySANs(t, b, "Ra8+")
+
+	assert.Equal(t, 3, b.ChecksGiven(White))
+	assert.Equal(t, 0, b.ChecksGiven(Black))
+	winner, win := b.VariantWinner()
+	assert.Equal(t, VariantWinThreeCheck, win)
+	assert.Equal(t, White, winner)
+
+	require.True(t, b.UndoLastMove())
+	assert.Equal(t, 2, b.ChecksGiven(White))
+}
+
+func TestVariant_Antichess(t *testing.T) {
+	t.Run("capture is forced", func(t *testing.T) {
+		b := newVariantBoardFromFEN(t, VariantAntichess, "4k3/8/8/3p4/4P3/8/8/R3K3 w - - 0 1")
+
+		moves := b.GenerateLegalMoves(White)
+		require.Len(t, moves, 1)
+		assert.Equal(t, Pawn, moves[0].Captured)
+
+		im, illegal := b.ExplainIllegalMove(Move{Color: White, Symbol: Rook, From: 21, To: 31})
+		require.True(t, illegal)
+		assert.Equal(t, IllegalCaptureRequired, im.Reason)
+	})
+
+	t.Run("king is not royal", func(t *testing.T) {
+		b := newVariantBoardFromFEN(t, VariantAntichess, "4k3/8/8/8/8/8/8/r3K3 w - - 0 1")
+		assert.False(t, b.IsCheck(White))
+
+		// king must capture the rook even though it would be in check in standard chess
+		b = newVariantBoardFromFEN(t, VariantAntichess, "8/8/8/8/8/8/8/3rK2R w K - 0 1")
+		moves := b.GenerateLegalMoves(White)
+		require.Len(t, moves, 1)
+		assert.Equal(t, King, moves[0].Symbol)
+		assert.False(t, moves[0].IsCastling)
+	})
+
+	t.Run("promotion to king", func(t *testing.T) {
+		b := newVariantBoardFromFEN(t, VariantAntichess, "8/4P3/8/8/8/8/8/k7 w - - 0 1")
+		moves := b.GenerateLegalMoves(White)
+		assert.Len(t, moves, 5)
+
+		applySANs(t, b, "e8=K")
+		assert.NoError(t, b.CheckInvariants())
+	})
+
+	t.Run("side without pieces wins", func(t *testing.T) {
+		b := newVariantBoardFromFEN(t, VariantAntichess, "8/8/8/3p4/4P3/8/8/8 w - - 0 1")
+		_, win := b.VariantWinner()
+		assert.Equal(t, VariantWinNone, win)
+
+		applySANs(t, b, "exd5")
+
+		winner, win := b.VariantWinner()
+		assert.Equal(t, VariantWinNoMoves, win)
+		assert.Equal(t, Black, winner)
+	})
+}
+
+func TestVariant_Atomic(t *testing.T) {
+	t.Run("explosion wins", func(t *testing.T) {
+		fen := "4k3/3p4/8/8/8/8/8/3QK3 w - - 0 1"
+		b := newVariantBoardFromFEN(t, VariantAtomic, fen)
+		applySANs(t, b, "Qxd7")
+
+		assert.True(t, b.IsEmpty(84), "capturing queen explodes")
+		assert.True(t, b.IsEmpty(95), "adjacent king explodes")
+		assert.Empty(t, b.Pieces(Black))
+		require.NoError(t, b.CheckInvariants())
+
+		winner, win := b.VariantWinner()
+		assert.Equal(t, VariantWinExplosion, win)
+		assert.Equal(t, White, winner)
+
+		require.True(t, b.UndoLastMove())
+		assert.Equal(t, fen, b.FEN())
+		assert.NoError(t, b.CheckInvariants())
+	})
+
+	t.Run("pawns next to the capture survive", func(t *testing.T) {
+		b := newVariantBoardFromFEN(t, VariantAtomic, "4k3/8/8/2pnp3/8/4N3/8/4K3 w - - 0 1")
+		applySANs(t, b, "Nxd5")
+
+		assert.Equal(t, "4k3/8/8/2p1p3/8/8/8/4K3 b - - 0 1", b.FEN())
+		_, win := b.VariantWinner()
+		assert.Equal(t, VariantWinNone, win)
+	})
+
+	t.Run("king cannot capture", func(t *testing.T) {
+		b := newVariantBoardFromFEN(t, VariantAtomic, "4k3/8/8/8/8/8/3n4/1N2K3 w - - 0 1")
+
+		im, illegal := b.ExplainIllegalMove(Move{Color: White, Symbol: King, From: 25, To: 34})
+		require.True(t, illegal)
+		assert.Equal(t, IllegalKingCapture, im.Reason)
+
+		im, illegal = b.ExplainIllegalMove(Move{Color: White, Symbol: Knight, From: 22, To: 34})
+		require.True(t, illegal)
+		assert.Equal(t, IllegalOwnKingExploded, im.Reason)
+		assert.Equal(t, 25, im.Position)
+	})
+
+	t.Run("adjacent kings are never in check", func(t *testing.T) {
+		b := newVariantBoardFromFEN(t, VariantAtomic, "8/8/8/8/8/8/3k4/r3K3 w - - 0 1")
+		assert.False(t, b.IsCheck(White))
+	})
+}
+
+func TestVariant_DarkChess(t *testing.T) {
+	t.Run("king may move into check", func(t *testing.T) {
+		b := newVariantBoardFromFEN(t, VariantDarkChess, "4k3/8/8/8/8/8/8/r3K3 w - - 0 1")
+		assert.False(t, b.IsCheck(White))
+
+		moves := b.GenerateLegalMoves(White)
+		assert.Len(t, moves, 5)
+	})
+
+	t.Run("castling through attacked squares", func(t *testing.T) {
+		b := newVariantBoardFromFEN(t, VariantDarkChess, "4k3/8/8/8/8/8/5r2/4K2R w K - 0 1")
+		applySANs(t, b, "O-O")
+		assert.Equal(t, "4k3/8/8/8/8/8/5r2/5RK1 b - - 1 1", b.FEN())
+	})
+
+	t.Run("capturing the king wins", func(t *testing.T) {
+		fen := "4k3/8/8/8/8/8/8/4RK2 w - - 0 1"
+		b := newVariantBoardFromFEN(t, VariantDarkChess, fen)
+		_, win := b.VariantWinner()
+		assert.Equal(t, VariantWinNone, win)
+
+		applySANs(t, b, "Rxe8")
+		assert.Equal(t, 0, b.kingPosition(Black))
+		require.NoError(t, b.CheckInvariants())
+
+		winner, win := b.VariantWinner()
+		assert.Equal(t, VariantWinKingCaptured, win)
+		assert.Equal(t, White, winner)
+
+		require.True(t, b.UndoLastMove())
+		assert.Equal(t, 95, b.kingPosition(Black))
+		assert.Equal(t, fen, b.FEN())
+	})
+}
@@ -0,0 +1,25 @@
+package engine
+
+// Visibility squares visible to color in the same 0-63 order as GridRaw.
+// A square is visible if it is occupied by a piece of color
+// or a piece of color has a pseudo legal move to it.
+// Used for VariantDarkChess, where check is not considered.
+func (b *Board) Visibility(color Color) [64]bool {
+	visible := [64]bool{}
+	for _, p := range b.Pieces(color) {
+		visible[MailboxToIndex(p.position)] = true
+
+		moves, err := b.GeneratePiecePseudoLegalMoves(p)
+		if err != nil {
+			// panic used here as it is a programmer error if b and piece list is out of sync
+			panic(err)
+		}
+		for _, m := range moves {
+			visible[MailboxToIndex(m.To)] = true
+			if m.IsEnPassant {
+				visible[MailboxToIndex(m.calculateEnPassantCapturedPos())] = true
+			}
+		}
+	}
+	return visible
+}
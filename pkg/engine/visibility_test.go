@@ -0,0 +1,29 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoard_Visibility(t *testing.T) {
+	b := NewVariantBoard(VariantDarkChess)
+
+	visible := b.Visibility(White)
+	for i := 0; i < 64; i++ {
+		// own pieces on rank 1 and 2, pawn pushes and knight moves reach rank 3 and 4
+		expected := i < 32
+		assert.Equal(t, expected, visible[i], SquareName(indexToMailbox[i]))
+	}
+
+	applySANs(t, b, "e4", "d5")
+
+	visible = b.Visibility(White)
+	assert.True(t, visible[MailboxToIndex(64)], "capture square d5 is visible")
+	assert.False(t, visible[MailboxToIndex(66)], "empty diagonal f5 is not visible")
+	assert.False(t, visible[MailboxToIndex(94)], "d8 is hidden")
+
+	visible = b.Visibility(Black)
+	assert.True(t, visible[MailboxToIndex(55)], "capture square e4 is visible")
+	assert.False(t, visible[MailboxToIndex(35)], "e2 is hidden")
+}
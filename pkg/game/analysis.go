@@ -0,0 +1,231 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/search"
+)
+
+const defaultAnalysisDepth = 3
+
+// centipawns lost by a move to be classified
+const (
+	inaccuracyLoss = 50
+	mistakeLoss    = 100
+	blunderLoss    = 300
+)
+
+// analysisEvalCap evaluations are capped so a missed mate counts as a large
+// but finite loss in the average centipawn loss
+const analysisEvalCap = 1000
+
+// NAGs of classified moves
+const (
+	nagMistake    = 2
+	nagBlunder    = 4
+	nagInaccuracy = 6
+)
+
+type Classification int
+
+const (
+	ClassificationNone Classification = iota
+	ClassificationInaccuracy
+	ClassificationMistake
+	ClassificationBlunder
+)
+
+const (
+	classificationNoneStr       = "none"
+	classificationInaccuracyStr = "inaccuracy"
+	classificationMistakeStr    = "mistake"
+	classificationBlunderStr    = "blunder"
+)
+
+func (c Classification) String() string {
+	switch c {
+	case ClassificationInaccuracy:
+		return classificationInaccuracyStr
+	case ClassificationMistake:
+		return classificationMistakeStr
+	case ClassificationBlunder:
+		return classificationBlunderStr
+	default:
+		return classificationNoneStr
+	}
+}
+
+func (c Classification) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (c *Classification) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case classificationNoneStr:
+		*c = ClassificationNone
+	case classificationInaccuracyStr:
+		*c = ClassificationInaccuracy
+	case classificationMistakeStr:
+		*c = ClassificationMistake
+	case classificationBlunderStr:
+		*c = ClassificationBlunder
+	default:
+		return fmt.Errorf("invalid classification: %s", text)
+	}
+	return nil
+}
+
+func classify(loss int) Classification {
+	switch {
+	case loss >= blunderLoss:
+		return ClassificationBlunder
+	case loss >= mistakeLoss:
+		return ClassificationMistake
+	case loss >= inaccuracyLoss:
+		return ClassificationInaccuracy
+	default:
+		return ClassificationNone
+	}
+}
+
+func (c Classification) nag() int {
+	switch c {
+	case ClassificationInaccuracy:
+		return nagInaccuracy
+	case ClassificationMistake:
+		return nagMistake
+	default:
+		return nagBlunder
+	}
+}
+
+// AnalysisOptions Depth of the search of each position, defaults to defaultAnalysisDepth.
+type AnalysisOptions struct {
+	Depth int
+}
+
+// MoveAnalysis evaluation of a move of the mainline, scores are in centipawns.
+type MoveAnalysis struct {
+	Ply   int          `json:"ply"`
+	Color engine.Color `json:"color"`
+	SAN   string       `json:"san"`
+	// Best SAN of the best move found in the position before the move
+	Best string `json:"best"`
+	// Eval of the position after the move from the perspective of white
+	Eval int `json:"eval"`
+	// Loss evaluation lost by not playing Best
+	Loss           int            `json:"loss"`
+	Classification Classification `json:"classification,omitempty"`
+}
+
+// PlayerAnalysis summary of the moves of a color.
+type PlayerAnalysis struct {
+	// ACPL average centipawn loss, 0 if the color has not moved
+	ACPL         int `json:"acpl"`
+	Inaccuracies int `json:"inaccuracies"`
+	Mistakes     int `json:"mistakes"`
+	Blunders     int `json:"blunders"`
+
+	moves int
+	loss  int
+}
+
+func (p *PlayerAnalysis) add(m MoveAnalysis) {
+	p.moves++
+	p.loss += m.Loss
+	p.ACPL = (p.loss + p.moves/2) / p.moves
+	switch m.Classification {
+	case ClassificationInaccuracy:
+		p.Inaccuracies++
+	case ClassificationMistake:
+		p.Mistakes++
+	case ClassificationBlunder:
+		p.Blunders++
+	}
+}
+
+// Analysis of the mainline of a Tree, see Tree.Analyze.
+type Analysis struct {
+	Depth int            `json:"depth"`
+	Moves []MoveAnalysis `json:"moves"`
+	White PlayerAnalysis `json:"white"`
+	Black PlayerAnalysis `json:"black"`
+	// PGN of the tree annotated with NAGs and the best move of classified moves
+	PGN string `json:"pgn"`
+}
+
+// Analyze evaluates the position before and after each move of the mainline with a
+// fixed depth search. A move loses the difference between the best move found and the
+// move played, moves losing enough are classified as inaccuracy, mistake or blunder.
+// Classified nodes are annotated in place with a NAG and a comment naming the best move.
+// Only standard chess can be analyzed as the search ignores variant rules.
+func (t *Tree) Analyze(opts ...AnalysisOptions) (Analysis, error) {
+	if t.variant != engine.VariantStandard {
+		return Analysis{}, ErrAnalysisUnavailable
+	}
+	depth := defaultAnalysisDepth
+	if len(opts) > 0 && opts[0].Depth > 0 {
+		depth = opts[0].Depth
+	}
+
+	b, err := t.newBoard()
+	if err != nil {
+		return Analysis{}, err
+	}
+
+	line := t.Mainline()
+	a := Analysis{
+		Depth: depth,
+		Moves: make([]MoveAnalysis, 0, len(line)),
+	}
+	best := search.Search(b, search.Limits{Depth: depth})
+	for _, n := range line {
+		color := b.ActiveColor()
+		bestSAN := ""
+		if best.Move.Symbol != 0 {
+			bestSAN = b.SAN(best.Move)
+		}
+		if err := b.ApplyMove(n.move); err != nil {
+			return Analysis{}, fmt.Errorf("failed to replay ply %d: %w", n.ply, err)
+		}
+
+		next := search.Search(b, search.Limits{Depth: depth})
+		played := -capEval(next.Score)
+		loss := 0
+		if !sameMove(n.move, best.Move) {
+			loss = max(0, capEval(best.Score)-played)
+		}
+
+		m := MoveAnalysis{
+			Ply:            n.ply,
+			Color:          color,
+			SAN:            n.san,
+			Best:           bestSAN,
+			Eval:           played * int(color),
+			Loss:           loss,
+			Classification: classify(loss),
+		}
+		if m.Classification != ClassificationNone {
+			if err := n.AddNAG(m.Classification.nag()); err != nil {
+				return Analysis{}, err
+			}
+			n.SetComment(fmt.Sprintf("%s %s was best.", n.comment, bestSAN))
+		}
+		a.Moves = append(a.Moves, m)
+		if color == engine.White {
+			a.White.add(m)
+		} else {
+			a.Black.add(m)
+		}
+		best = next
+	}
+
+	a.PGN = t.PGN()
+	return a, nil
+}
+
+func capEval(score int) int {
+	return min(max(score, -analysisEvalCap), analysisEvalCap)
+}
@@ -0,0 +1,98 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGame_Tree(t *testing.T) {
+	g := NewGame(engine.NewBoard())
+	for _, m := range []string{"e2e4", "e7e5", "g1f3"} {
+		_, err := g.ApplyMoveWithFileRank(m)
+		require.NoError(t, err)
+	}
+
+	tree, err := g.Tree()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"e4", "e5", "Nf3"}, mainlineSANs(tree))
+	assert.Equal(t, 3, tree.Current().Ply())
+	assert.Equal(t, engine.Black, tree.ActiveColor())
+
+	_, err = NewGame(engine.NewVariantBoard(engine.VariantBughouse)).Tree()
+	assert.ErrorIs(t, err, ErrUnsupportedTree)
+}
+
+func TestTree_Analyze(t *testing.T) {
+	// 3...Nf6?? allows mate, Qe7 defends f7
+	tree := newTestTree(t, engine.StartFEN, "e4", "e5", "Bc4", "Nc6", "Qh5", "Nf6", "Qxf7#")
+
+	a, err := tree.Analyze(AnalysisOptions{Depth: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 2, a.Depth)
+	require.Len(t, a.Moves, 7)
+
+	nf6 := a.Moves[5]
+	assert.Equal(t, 6, nf6.Ply)
+	assert.Equal(t, engine.Black, nf6.Color)
+	assert.Equal(t, "Nf6", nf6.SAN)
+	assert.NotEqual(t, "Nf6", nf6.Best)
+	assert.Equal(t, ClassificationBlunder, nf6.Classification)
+	assert.GreaterOrEqual(t, nf6.Loss, blunderLoss)
+
+	mate := a.Moves[6]
+	assert.Equal(t, "Qxf7#", mate.SAN)
+	assert.Equal(t, "Qxf7#", mate.Best)
+	assert.Equal(t, 0, mate.Loss)
+	assert.Equal(t, analysisEvalCap, mate.Eval)
+	assert.Equal(t, ClassificationNone, mate.Classification)
+
+	assert.Equal(t, 1, a.Black.Blunders)
+	assert.Zero(t, a.White.Blunders)
+	assert.Greater(t, a.Black.ACPL, a.White.ACPL)
+
+	node := tree.Mainline()[5]
+	assert.Equal(t, []int{nagBlunder}, node.NAGs())
+	assert.Equal(t, nf6.Best+" was best.", node.Comment())
+	assert.Contains(t, a.PGN, "3. Qh5 Nf6 $4 {"+nf6.Best+" was best.} 4. Qxf7# 1-0")
+}
+
+func TestTree_AnalyzeUnavailable(t *testing.T) {
+	tree, err := NewTree(engine.StartFEN, engine.VariantAntichess)
+	require.NoError(t, err)
+
+	_, err = tree.Analyze()
+	assert.ErrorIs(t, err, ErrAnalysisUnavailable)
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		loss int
+		want Classification
+		json string
+	}{
+		{name: "best move", loss: 0, want: ClassificationNone, json: `"none"`},
+		{name: "below inaccuracy", loss: 49, want: ClassificationNone, json: `"none"`},
+		{name: "inaccuracy", loss: 50, want: ClassificationInaccuracy, json: `"inaccuracy"`},
+		{name: "mistake", loss: 100, want: ClassificationMistake, json: `"mistake"`},
+		{name: "blunder", loss: 300, want: ClassificationBlunder, json: `"blunder"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := classify(tt.loss)
+			assert.Equal(t, tt.want, c)
+
+			data, err := json.Marshal(c)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.json, string(data))
+
+			var got Classification
+			require.NoError(t, json.Unmarshal(data, &got))
+			assert.Equal(t, c, got)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package game
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dyxj/chess/pkg/engine"
+)
+
+// BughouseBoards number of linked boards in a bughouse match
+const BughouseBoards = 2
+
+// Bughouse two linked games, pieces captured on one board are added to the pocket
+// of the capturer's partner, who plays the opposite color on the other board.
+// The match is over once either game is over.
+type Bughouse struct {
+	mu    sync.Mutex
+	games [BughouseBoards]*Game
+}
+
+func NewBughouse() *Bughouse {
+	bh := &Bughouse{}
+	for i := range bh.games {
+		bh.games[i] = NewGame(engine.NewVariantBoard(engine.VariantBughouse))
+	}
+	return bh
+}
+
+// BughouseResult round of the board the move was applied to.
+// PartnerRound is the round of the other board if a captured piece was added to its pocket.
+type BughouseResult struct {
+	Board        int
+	Round        RoundResult
+	PartnerRound *RoundResult
+}
+
+func (bh *Bughouse) Game(board int) (*Game, error) {
+	if board < 0 || board >= BughouseBoards {
+		return nil, ErrInvalidBoard
+	}
+	return bh.games[board], nil
+}
+
+// ApplyMove applies m to board and passes a captured piece to the partner board.
+func (bh *Bughouse) ApplyMove(board int, m Move) (BughouseResult, error) {
+	bh.mu.Lock()
+	defer bh.mu.Unlock()
+
+	g, err := bh.Game(board)
+	if err != nil {
+		return BughouseResult{}, err
+	}
+
+	if _, over := bh.over(); over {
+		return BughouseResult{}, fmt.Errorf("%w: match is over", ErrInvalidMove)
+	}
+
+	rr, captured, err := g.applyMoveAndCapture(m)
+	if err != nil {
+		return BughouseResult{}, err
+	}
+
+	result := BughouseResult{Board: board, Round: rr}
+	if captured == 0 {
+		return result, nil
+	}
+
+	// the partner plays the color of the captured piece
+	pr, err := bh.games[1-board].addToPocket(m.Color.Opposite(), captured)
+	if err != nil {
+		return BughouseResult{}, err
+	}
+	result.PartnerRound = &pr
+
+	return result, nil
+}
+
+// Resign resigns color on board, which ends the match.
+func (bh *Bughouse) Resign(board int, color engine.Color) error {
+	bh.mu.Lock()
+	defer bh.mu.Unlock()
+
+	g, err := bh.Game(board)
+	if err != nil {
+		return err
+	}
+
+	if _, over := bh.over(); over {
+		return fmt.Errorf("%w: match is over", ErrInvalidMove)
+	}
+
+	return g.Resign(color)
+}
+
+// Over returns the board that ended the match.
+func (bh *Bughouse) Over() (board int, over bool) {
+	bh.mu.Lock()
+	defer bh.mu.Unlock()
+
+	return bh.over()
+}
+
+func (bh *Bughouse) over() (int, bool) {
+	for i, g := range bh.games {
+		if g.State().IsGameOver() {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// applyMoveAndCapture returns the pocket symbol of the captured piece, 0 if none
+func (g *Game) applyMoveAndCapture(m Move) (RoundResult, engine.Symbol, error) {
+	g.mu.Lock()
+	defer g.unlockAndNotify(g.state)
+
+	rr, err := g.applyMove(m)
+	if err != nil {
+		return RoundResult{}, 0, err
+	}
+
+	captured, ok := g.b.LastCaptured()
+	if !ok {
+		return rr, 0, nil
+	}
+	return rr, captured.PocketSymbol(), nil
+}
+
+func (g *Game) addToPocket(color engine.Color, s engine.Symbol) (RoundResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.b.AddToPocket(color, s); err != nil {
+		return RoundResult{}, err
+	}
+	return g.round(), nil
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBughouse_CaptureFeedsPartner(t *testing.T) {
+	bh := NewBughouse()
+
+	moves := []Move{
+		{Color: engine.White, Symbol: engine.Pawn, From: 12, To: 28}, // e4
+		{Color: engine.Black, Symbol: engine.Pawn, From: 51, To: 35}, // d5
+	}
+	for _, m := range moves {
+		result, err := bh.ApplyMove(0, m)
+		require.NoError(t, err)
+		assert.Nil(t, result.PartnerRound)
+	}
+
+	// exd5
+	result, err := bh.ApplyMove(0, Move{Color: engine.White, Symbol: engine.Pawn, From: 28, To: 35})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Board)
+	assert.Empty(t, result.Round.Pockets[engine.White], "capturer does not keep the piece")
+
+	require.NotNil(t, result.PartnerRound)
+	assert.Equal(t, []engine.Symbol{engine.Pawn}, result.PartnerRound.Pockets[engine.Black])
+	assert.Empty(t, result.PartnerRound.Pockets[engine.White])
+
+	_, err = bh.ApplyMove(1, Move{Color: engine.White, Symbol: engine.Pawn, From: 12, To: 28})
+	require.NoError(t, err)
+
+	// partner drops the captured pawn on e5
+	result, err = bh.ApplyMove(1, Move{Color: engine.Black, Symbol: engine.Pawn, To: 36, IsDrop: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Board)
+	assert.Equal(t, -1, result.Round.Grid[36])
+	assert.Empty(t, result.Round.Pockets[engine.Black])
+}
+
+func TestBughouse_MatchOver(t *testing.T) {
+	bh := NewBughouse()
+
+	_, err := bh.Game(2)
+	assert.ErrorIs(t, err, ErrInvalidBoard)
+
+	_, err = bh.ApplyMove(-1, Move{})
+	assert.ErrorIs(t, err, ErrInvalidBoard)
+
+	// fool's mate on board 1
+	g, err := bh.Game(1)
+	require.NoError(t, err)
+	for _, m := range []string{"f2f3", "e7e5", "g2g4"} {
+		_, err := g.ApplyMoveWithFileRank(m)
+		require.NoError(t, err)
+	}
+	_, over := bh.Over()
+	assert.False(t, over)
+
+	result, err := bh.ApplyMove(1, Move{Color: engine.Black, Symbol: engine.Queen, From: 59, To: 31})
+	require.NoError(t, err)
+	assert.Equal(t, StateCheckmate, result.Round.State)
+
+	board, over := bh.Over()
+	assert.True(t, over)
+	assert.Equal(t, 1, board)
+
+	_, err = bh.ApplyMove(0, Move{Color: engine.White, Symbol: engine.Pawn, From: 12, To: 28})
+	assert.ErrorIs(t, err, ErrInvalidMove)
+	assert.ErrorIs(t, bh.Resign(0, engine.White), ErrInvalidMove)
+}
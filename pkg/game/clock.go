@@ -0,0 +1,269 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+)
+
+// TimeSource current time, injectable so clocks can be tested without sleeping.
+type TimeSource interface {
+	Now() time.Time
+}
+
+// SystemTime TimeSource of the system clock
+type SystemTime struct{}
+
+func (SystemTime) Now() time.Time {
+	return time.Now()
+}
+
+// BonusMode how time is given back to a player after a move.
+type BonusMode int
+
+const (
+	// BonusNone sudden death
+	BonusNone BonusMode = iota
+	// BonusFischer increment is added after every move
+	BonusFischer
+	// BonusBronstein time used, up to the increment, is added back after every move
+	BonusBronstein
+	// BonusDelay simple (US) delay, clock starts running after the increment has passed
+	BonusDelay
+)
+
+const (
+	bonusNoneStr      = "none"
+	bonusFischerStr   = "fischer"
+	bonusBronsteinStr = "bronstein"
+	bonusDelayStr     = "delay"
+	bonusUnknownStr   = "unknown"
+)
+
+func (b BonusMode) String() string {
+	switch b {
+	case BonusNone:
+		return bonusNoneStr
+	case BonusFischer:
+		return bonusFischerStr
+	case BonusBronstein:
+		return bonusBronsteinStr
+	case BonusDelay:
+		return bonusDelayStr
+	default:
+		return bonusUnknownStr
+	}
+}
+
+func (b BonusMode) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (b *BonusMode) UnmarshalText(text []byte) error {
+	str := string(text)
+	switch str {
+	case bonusNoneStr:
+		*b = BonusNone
+	case bonusFischerStr:
+		*b = BonusFischer
+	case bonusBronsteinStr:
+		*b = BonusBronstein
+	case bonusDelayStr:
+		*b = BonusDelay
+	default:
+		return fmt.Errorf("unknown bonus: %s valid bonus(none,fischer,bronstein,delay)", str)
+	}
+	return nil
+}
+
+// Stage of a time control, Time is added to the clock when the stage starts.
+// Moves is the number of moves to be played in the stage, 0 for the final stage.
+type Stage struct {
+	Moves     int           `json:"moves"`
+	Time      time.Duration `json:"time"`
+	Increment time.Duration `json:"increment"`
+}
+
+// TimeControl of a game, the zero value is untimed.
+// 40/90+30 is BonusFischer with stages {40, 90m, 30s} and {0, 30m, 30s}.
+type TimeControl struct {
+	Bonus  BonusMode `json:"bonus"`
+	Stages []Stage   `json:"stages"`
+}
+
+func SuddenDeath(t time.Duration) TimeControl {
+	return TimeControl{Stages: []Stage{{Time: t}}}
+}
+
+func Fischer(t, increment time.Duration) TimeControl {
+	return TimeControl{Bonus: BonusFischer, Stages: []Stage{{Time: t, Increment: increment}}}
+}
+
+func Bronstein(t, delay time.Duration) TimeControl {
+	return TimeControl{Bonus: BonusBronstein, Stages: []Stage{{Time: t, Increment: delay}}}
+}
+
+func SimpleDelay(t, delay time.Duration) TimeControl {
+	return TimeControl{Bonus: BonusDelay, Stages: []Stage{{Time: t, Increment: delay}}}
+}
+
+func (tc TimeControl) IsTimed() bool {
+	return len(tc.Stages) > 0
+}
+
+func (tc TimeControl) Validate() error {
+	if tc.Bonus < BonusNone || tc.Bonus > BonusDelay {
+		return fmt.Errorf("%w: unknown bonus", ErrInvalidTimeControl)
+	}
+	for i, s := range tc.Stages {
+		last := i == len(tc.Stages)-1
+		if !last && s.Moves <= 0 {
+			return fmt.Errorf("%w: stage %d must have moves", ErrInvalidTimeControl, i+1)
+		}
+		if last && s.Moves != 0 {
+			return fmt.Errorf("%w: final stage cannot have moves", ErrInvalidTimeControl)
+		}
+		if s.Time < 0 || s.Increment < 0 {
+			return fmt.Errorf("%w: stage %d has negative time", ErrInvalidTimeControl, i+1)
+		}
+	}
+	if tc.IsTimed() && tc.Stages[0].Time == 0 {
+		return fmt.Errorf("%w: first stage must have time", ErrInvalidTimeControl)
+	}
+	return nil
+}
+
+// clock of both players, guarded by Game.mu.
+// Only the clock of running is counting down, from turnStart.
+type clock struct {
+	tc         TimeControl
+	ts         TimeSource
+	remaining  map[engine.Color]time.Duration
+	stage      map[engine.Color]int
+	stageMoves map[engine.Color]int
+	running    engine.Color
+	turnStart  time.Time
+	// history elapsed time of each move in order
+	history []time.Duration
+	// presses time added by each move in order, so it can be taken back
+	presses []press
+}
+
+// press time added to the clock of color by a move and the stage it was played in.
+// stageTime is the time of the next stage if the move completed its stage.
+type press struct {
+	color      engine.Color
+	bonus      time.Duration
+	stage      int
+	stageMoves int
+	stageTime  time.Duration
+}
+
+func newClock(tc TimeControl, ts TimeSource) *clock {
+	return &clock{
+		tc: tc,
+		ts: ts,
+		remaining: map[engine.Color]time.Duration{
+			engine.White: tc.Stages[0].Time,
+			engine.Black: tc.Stages[0].Time,
+		},
+		stage:      map[engine.Color]int{},
+		stageMoves: map[engine.Color]int{},
+	}
+}
+
+func (c *clock) start(color engine.Color, now time.Time) {
+	c.running = color
+	c.turnStart = now
+}
+
+func (c *clock) stop(now time.Time) {
+	if c.running == 0 {
+		return
+	}
+	c.remaining[c.running] = c.remainingAt(c.running, now)
+	c.running = 0
+}
+
+// used time deducted from the clock of the running player
+func (c *clock) used(now time.Time) time.Duration {
+	used := now.Sub(c.turnStart)
+	if c.tc.Bonus == BonusDelay {
+		used -= c.tc.Stages[c.stage[c.running]].Increment
+	}
+	return max(used, 0)
+}
+
+func (c *clock) remainingAt(color engine.Color, now time.Time) time.Duration {
+	if color != c.running {
+		return c.remaining[color]
+	}
+	return max(c.remaining[color]-c.used(now), 0)
+}
+
+func (c *clock) isFlagged(now time.Time) bool {
+	return c.running != 0 && c.remainingAt(c.running, now) == 0
+}
+
+// press ends the move of color and starts the clock of the opponent.
+// The first move of a game is played before the clock is started, takes no time and earns no bonus.
+func (c *clock) press(color engine.Color, now time.Time) {
+	p := press{
+		color:      color,
+		stage:      c.stage[color],
+		stageMoves: c.stageMoves[color],
+	}
+
+	var elapsed time.Duration
+	if c.running == color {
+		elapsed = now.Sub(c.turnStart)
+		c.remaining[color] = c.remainingAt(color, now)
+
+		stage := c.tc.Stages[c.stage[color]]
+		switch c.tc.Bonus {
+		case BonusFischer:
+			p.bonus = stage.Increment
+		case BonusBronstein:
+			p.bonus = min(stage.Increment, elapsed)
+		default:
+		}
+		c.remaining[color] += p.bonus
+	}
+	c.history = append(c.history, elapsed)
+
+	stage := c.tc.Stages[c.stage[color]]
+	c.stageMoves[color]++
+	if stage.Moves > 0 && c.stageMoves[color] >= stage.Moves {
+		c.stage[color]++
+		c.stageMoves[color] = 0
+		p.stageTime = c.tc.Stages[c.stage[color]].Time
+		c.remaining[color] += p.stageTime
+	}
+	c.presses = append(c.presses, p)
+
+	c.start(color.Opposite(), now)
+}
+
+// undo restarts the clock of color whose move was taken back, time used is not returned.
+// The bonus and the time of a stage completed by the move are taken back, the stage is restored.
+func (c *clock) undo(color engine.Color, now time.Time) {
+	if len(c.history) > 0 {
+		c.history = c.history[:len(c.history)-1]
+	}
+	c.stop(now)
+
+	// clocks restored with RestoreClock do not know the time added by earlier moves
+	if n := len(c.presses); n > 0 && c.presses[n-1].color == color {
+		p := c.presses[n-1]
+		c.presses = c.presses[:n-1]
+		c.remaining[color] = max(c.remaining[color]-p.bonus-p.stageTime, 0)
+		c.stage[color] = p.stage
+		c.stageMoves[color] = p.stageMoves
+	} else if c.stageMoves[color] > 0 {
+		c.stageMoves[color]--
+	}
+
+	c.start(color, now)
+}
@@ -0,0 +1,371 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTime struct {
+	now time.Time
+}
+
+func newFakeTime() *fakeTime {
+	return &fakeTime{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (f *fakeTime) Now() time.Time {
+	return f.now
+}
+
+func (f *fakeTime) advance(d time.Duration) {
+	f.now = f.now.Add(d)
+}
+
+func newTimedGame(t *testing.T, tc TimeControl, fen ...string) (*Game, *fakeTime) {
+	t.Helper()
+	b := engine.NewBoard()
+	if len(fen) > 0 {
+		var err error
+		b, err = engine.NewBoardFromFEN(fen[0])
+		require.NoError(t, err)
+	}
+	g := NewGame(b, Rules{TimeControl: tc})
+	ft := newFakeTime()
+	g.SetTimeSource(ft)
+	g.StartClock()
+	return g, ft
+}
+
+func TestGame_ClockBonus(t *testing.T) {
+	tt := []struct {
+		name string
+		tc   TimeControl
+		// white moves after each elapsed duration, black replies instantly
+		elapsed   []time.Duration
+		remaining time.Duration
+	}{
+		{
+			name:      "sudden death",
+			tc:        SuddenDeath(time.Minute),
+			elapsed:   []time.Duration{10 * time.Second, 5 * time.Second},
+			remaining: 45 * time.Second,
+		},
+		{
+			name:      "fischer",
+			tc:        Fischer(time.Minute, 2*time.Second),
+			elapsed:   []time.Duration{10 * time.Second, 5 * time.Second},
+			remaining: 49 * time.Second,
+		},
+		{
+			name:      "bronstein",
+			tc:        Bronstein(time.Minute, 5*time.Second),
+			elapsed:   []time.Duration{3 * time.Second, 10 * time.Second},
+			remaining: 55 * time.Second,
+		},
+		{
+			name:      "simple delay",
+			tc:        SimpleDelay(time.Minute, 5*time.Second),
+			elapsed:   []time.Duration{3 * time.Second, 10 * time.Second},
+			remaining: 55 * time.Second,
+		},
+		{
+			name: "multi stage",
+			tc: TimeControl{Stages: []Stage{
+				{Moves: 2, Time: time.Minute},
+				{Time: 30 * time.Second},
+			}},
+			elapsed:   []time.Duration{10 * time.Second, 10 * time.Second},
+			remaining: 70 * time.Second,
+		},
+	}
+
+	moves := [][2]string{{"g1f3", "g8f6"}, {"f3g1", "f6g8"}}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, tc.tc.Validate())
+			g, ft := newTimedGame(t, tc.tc)
+
+			for i, elapsed := range tc.elapsed {
+				ft.advance(elapsed)
+				_, err := g.ApplyMoveWithFileRank(moves[i][0])
+				require.NoError(t, err)
+				_, err = g.ApplyMoveWithFileRank(moves[i][1])
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.remaining, g.Remaining(engine.White))
+			assert.Equal(t, []time.Duration{tc.elapsed[0], 0, tc.elapsed[1], 0}, g.MoveTimes())
+		})
+	}
+}
+
+func TestGame_ClockRunning(t *testing.T) {
+	g, ft := newTimedGame(t, SimpleDelay(time.Minute, 5*time.Second))
+
+	ft.advance(4 * time.Second)
+	assert.Equal(t, time.Minute, g.Remaining(engine.White), "within delay")
+	ft.advance(6 * time.Second)
+	assert.Equal(t, 55*time.Second, g.Remaining(engine.White))
+	assert.Equal(t, time.Minute, g.Remaining(engine.Black))
+
+	rr, err := g.ApplyMoveWithFileRank("e2e4")
+	require.NoError(t, err)
+	assert.Equal(t, map[engine.Color]int64{engine.White: 55000, engine.Black: 60000}, rr.Remaining)
+
+	ft.advance(10 * time.Second)
+	assert.Equal(t, 55*time.Second, g.Remaining(engine.White), "stopped")
+	assert.Equal(t, 55*time.Second, g.Remaining(engine.Black))
+	assert.Equal(t, map[engine.Color]int64{engine.White: 55000, engine.Black: 55000}, g.Round().Remaining)
+}
+
+func TestGame_ClockStartsAfterFirstMove(t *testing.T) {
+	g := NewGame(engine.NewBoard(), Rules{TimeControl: SuddenDeath(time.Minute)})
+	ft := newFakeTime()
+	g.SetTimeSource(ft)
+
+	ft.advance(time.Hour)
+	assert.False(t, g.CheckTimeout())
+	_, err := g.ApplyMoveWithFileRank("e2e4")
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, g.Remaining(engine.White))
+
+	ft.advance(time.Second)
+	assert.Equal(t, 59*time.Second, g.Remaining(engine.Black))
+	assert.Equal(t, []time.Duration{0}, g.MoveTimes())
+}
+
+func TestGame_Timeout(t *testing.T) {
+	tt := []struct {
+		name   string
+		fen    string
+		state  State
+		winner engine.Color
+	}{
+		{
+			name:   "opponent wins",
+			fen:    engine.StartFEN,
+			state:  StateTimeout,
+			winner: engine.Black,
+		},
+		{
+			name:  "draw against insufficient material",
+			fen:   "4k3/8/8/8/8/8/8/R3K3 w - - 0 1",
+			state: StateDraw,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			g, ft := newTimedGame(t, SuddenDeath(time.Minute), tc.fen)
+
+			ft.advance(59 * time.Second)
+			assert.False(t, g.CheckTimeout())
+
+			ft.advance(time.Second)
+			_, err := g.ApplyMoveWithFileRank("e1d1")
+			assert.ErrorIs(t, err, ErrTimeout)
+			assert.Equal(t, tc.state, g.State())
+			assert.Equal(t, tc.winner, g.Winner())
+			assert.Equal(t, time.Duration(0), g.Remaining(engine.White))
+
+			assert.False(t, g.CheckTimeout(), "already over")
+			_, err = g.ApplyMoveWithFileRank("e1d1")
+			assert.ErrorIs(t, err, ErrInvalidMove)
+		})
+	}
+}
+
+func TestGame_Forfeit(t *testing.T) {
+	g := NewGame(engine.NewBoard())
+	require.NoError(t, g.Forfeit(engine.White))
+	assert.Equal(t, StateTimeout, g.State())
+	assert.Equal(t, engine.Black, g.Winner())
+	assert.ErrorIs(t, g.Forfeit(engine.Black), ErrInvalidMove)
+
+	b, err := engine.NewBoardFromFEN("4k3/8/8/8/8/8/8/R3K3 b - - 0 1")
+	require.NoError(t, err)
+	g = NewGame(b)
+	require.NoError(t, g.Forfeit(engine.White))
+	assert.Equal(t, StateDraw, g.State())
+	assert.True(t, g.IsDraw())
+}
+
+func TestGame_ClockUndo(t *testing.T) {
+	g, ft := newTimedGame(t, SuddenDeath(time.Minute))
+
+	ft.advance(10 * time.Second)
+	_, err := g.ApplyMoveWithFileRank("e2e4")
+	require.NoError(t, err)
+	ft.advance(5 * time.Second)
+	require.NoError(t, g.UndoLastMove())
+
+	assert.Empty(t, g.MoveTimes())
+	assert.Equal(t, 55*time.Second, g.Remaining(engine.Black))
+	ft.advance(time.Second)
+	assert.Equal(t, 49*time.Second, g.Remaining(engine.White))
+}
+
+func TestGame_ClockUndoBonus(t *testing.T) {
+	tt := []struct {
+		name string
+		tc   TimeControl
+	}{
+		{name: "fischer", tc: Fischer(time.Minute, 10*time.Second)},
+		{name: "bronstein", tc: Bronstein(time.Minute, 10*time.Second)},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			g, ft := newTimedGame(t, tc.tc)
+
+			for range 3 {
+				ft.advance(2 * time.Second)
+				_, err := g.ApplyMoveWithFileRank("e2e4")
+				require.NoError(t, err)
+				require.NoError(t, g.UndoLastMove())
+			}
+			assert.Equal(t, 54*time.Second, g.Remaining(engine.White), "takebacks keep no bonus")
+			assert.Equal(t, time.Minute, g.Remaining(engine.Black))
+			assert.Empty(t, g.MoveTimes())
+		})
+	}
+}
+
+func TestGame_ClockUndoStage(t *testing.T) {
+	g, ft := newTimedGame(t, TimeControl{Bonus: BonusFischer, Stages: []Stage{
+		{Moves: 2, Time: time.Minute, Increment: time.Second},
+		{Time: 30 * time.Second, Increment: 5 * time.Second},
+	}})
+
+	moves := []string{"g1f3", "g8f6", "f3g1"}
+	for _, m := range moves {
+		ft.advance(10 * time.Second)
+		_, err := g.ApplyMoveWithFileRank(m)
+		require.NoError(t, err)
+	}
+	// 60s - 10s + 1s - 10s + 1s + 30s
+	assert.Equal(t, 72*time.Second, g.Remaining(engine.White))
+
+	require.NoError(t, g.UndoLastMove())
+	assert.Equal(t, 41*time.Second, g.Remaining(engine.White), "stage time is taken back")
+
+	// the second move completes the first stage again with its increment
+	ft.advance(10 * time.Second)
+	_, err := g.ApplyMoveWithFileRank("f3g1")
+	require.NoError(t, err)
+	assert.Equal(t, 62*time.Second, g.Remaining(engine.White))
+
+	// the first move of the next stage earns its increment
+	ft.advance(10 * time.Second)
+	_, err = g.ApplyMoveWithFileRank("f6g8")
+	require.NoError(t, err)
+	ft.advance(10 * time.Second)
+	_, err = g.ApplyMoveWithFileRank("g1f3")
+	require.NoError(t, err)
+	assert.Equal(t, 57*time.Second, g.Remaining(engine.White))
+}
+
+func TestGame_ClockFirstMoveNoBonus(t *testing.T) {
+	g := NewGame(engine.NewBoard(), Rules{TimeControl: Fischer(time.Minute, 10*time.Second)})
+	ft := newFakeTime()
+	g.SetTimeSource(ft)
+
+	_, err := g.ApplyMoveWithFileRank("e2e4")
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, g.Remaining(engine.White))
+
+	ft.advance(5 * time.Second)
+	_, err = g.ApplyMoveWithFileRank("e7e5")
+	require.NoError(t, err)
+	assert.Equal(t, 65*time.Second, g.Remaining(engine.Black))
+}
+
+func TestGame_Untimed(t *testing.T) {
+	g := NewGame(engine.NewBoard())
+	g.StartClock()
+	_, err := g.ApplyMoveWithFileRank("e2e4")
+	require.NoError(t, err)
+
+	assert.False(t, g.CheckTimeout())
+	assert.Equal(t, time.Duration(0), g.Remaining(engine.White))
+	assert.Nil(t, g.MoveTimes())
+	assert.Nil(t, g.Round().Remaining)
+}
+
+func TestTimeControl_Validate(t *testing.T) {
+	tt := []struct {
+		name  string
+		tc    TimeControl
+		valid bool
+	}{
+		{name: "untimed", tc: TimeControl{}, valid: true},
+		{name: "fischer", tc: Fischer(3*time.Minute, 2*time.Second), valid: true},
+		{
+			name: "40/90+30",
+			tc: TimeControl{Bonus: BonusFischer, Stages: []Stage{
+				{Moves: 40, Time: 90 * time.Minute, Increment: 30 * time.Second},
+				{Time: 30 * time.Minute, Increment: 30 * time.Second},
+			}},
+			valid: true,
+		},
+		{name: "no time", tc: SuddenDeath(0)},
+		{name: "negative increment", tc: Fischer(time.Minute, -time.Second)},
+		{name: "unknown bonus", tc: TimeControl{Bonus: 9, Stages: []Stage{{Time: time.Minute}}}},
+		{
+			name: "stage without moves",
+			tc:   TimeControl{Stages: []Stage{{Time: time.Minute}, {Time: time.Minute}}},
+		},
+		{
+			name: "final stage with moves",
+			tc:   TimeControl{Stages: []Stage{{Moves: 40, Time: time.Minute}}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.tc.Validate()
+			if tc.valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidTimeControl)
+		})
+	}
+}
+
+func TestBonusMode_Text(t *testing.T) {
+	for _, b := range []BonusMode{BonusNone, BonusFischer, BonusBronstein, BonusDelay} {
+		text, err := b.MarshalText()
+		require.NoError(t, err)
+
+		var got BonusMode
+		require.NoError(t, got.UnmarshalText(text))
+		assert.Equal(t, b, got)
+	}
+}
+
+func TestGame_RestoreClock(t *testing.T) {
+	g, ft := newTimedGame(t, Fischer(time.Minute, 2*time.Second))
+	for _, m := range []string{"e2e4", "e7e5"} {
+		_, err := g.ApplyMoveWithFileRank(m)
+		require.NoError(t, err)
+	}
+
+	g.RestoreClock(
+		map[engine.Color]time.Duration{engine.White: 30 * time.Second, engine.Black: 40 * time.Second},
+		[]time.Duration{3 * time.Second, 5 * time.Second},
+	)
+	ft.advance(10 * time.Second)
+	assert.Equal(t, 30*time.Second, g.Remaining(engine.White), "stopped until started")
+
+	g.StartClock()
+	ft.advance(10 * time.Second)
+	assert.Equal(t, 20*time.Second, g.Remaining(engine.White))
+	assert.Equal(t, 40*time.Second, g.Remaining(engine.Black))
+	assert.Equal(t, []time.Duration{3 * time.Second, 5 * time.Second}, g.MoveTimes())
+}
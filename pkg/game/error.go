@@ -1,7 +1,126 @@
 package game
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dyxj/chess/pkg/engine"
+)
 
 var ErrIllegalMove = errors.New("illegal move")
 var ErrInvalidMove = errors.New("invalid move")
 var ErrNotEligibleToForceDraw = errors.New("not eligible to force draw")
+var ErrInvalidBoard = errors.New("invalid board")
+var ErrTakebackNotAllowed = errors.New("takeback not allowed")
+var ErrNoMoveToUndo = errors.New("no move to undo")
+var ErrDrawOfferTooEarly = errors.New("draw offer too early")
+var ErrInvalidTimeControl = errors.New("invalid time control")
+var ErrTimeout = errors.New("out of time")
+var ErrNodeNotInTree = errors.New("node not in tree")
+var ErrRootNode = errors.New("not allowed on the root node")
+var ErrInvalidNAG = errors.New("invalid nag")
+var ErrInvalidRecord = errors.New("invalid game record")
+var ErrUnsupportedRecord = errors.New("game can not be recorded")
+var ErrHintUnavailable = errors.New("hints are not available in this variant")
+var ErrUnsupportedTree = errors.New("game can not be converted to a tree")
+var ErrAbortNotAllowed = errors.New("game can only be aborted before both sides have moved")
+var ErrAnalysisUnavailable = errors.New("analysis is not available in this variant")
+
+// IllegalMoveError explains why a move is illegal, unwraps to ErrIllegalMove.
+type IllegalMoveError struct {
+	Reason engine.IllegalMoveReason
+	Move   Move
+	// Square of the blocking piece, pinner or checker, or the attacked square
+	// when castling through check. -1 if not applicable.
+	Square int
+	// Symbol and Color of the piece on or attacking Square
+	Symbol engine.Symbol
+	Color  engine.Color
+	// Promotions pieces a pawn may promote to in the variant, set for IllegalPromotionInvalid
+	Promotions []engine.Symbol
+}
+
+func newIllegalMoveError(m Move, im engine.IllegalMove) *IllegalMoveError {
+	square := -1
+	if im.Position != 0 {
+		square = engine.MailboxToIndex(im.Position)
+	}
+	return &IllegalMoveError{
+		Reason:     im.Reason,
+		Move:       m,
+		Square:     square,
+		Symbol:     im.Symbol,
+		Color:      im.Color,
+		Promotions: im.Promotions,
+	}
+}
+
+// Code stable identifier of the reason
+func (e *IllegalMoveError) Code() string {
+	return string(e.Reason)
+}
+
+func (e *IllegalMoveError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrIllegalMove, e.explanation())
+}
+
+func (e *IllegalMoveError) Unwrap() error {
+	return ErrIllegalMove
+}
+
+func (e *IllegalMoveError) explanation() string {
+	from := engine.SquareName(e.Move.mbFrom())
+	to := engine.SquareName(e.Move.mbTo())
+	square := ""
+	if e.Square >= 0 {
+		square = engine.SquareName(engine.IndexToMailbox(e.Square))
+	}
+
+	switch e.Reason {
+	case engine.IllegalPieceMovement:
+		return fmt.Sprintf("%v cannot move from %s to %s", e.Move.Symbol, from, to)
+	case engine.IllegalPathBlocked:
+		return fmt.Sprintf("path from %s to %s is blocked by %v %v on %s", from, to, e.Color, e.Symbol, square)
+	case engine.IllegalPinned:
+		return fmt.Sprintf("%v on %s is pinned to the king by %v %v on %s", e.Move.Symbol, from, e.Color, e.Symbol, square)
+	case engine.IllegalKingInCheck:
+		return fmt.Sprintf("king would be in check from %v %v on %s", e.Color, e.Symbol, square)
+	case engine.IllegalCastlingOutOfCheck:
+		return fmt.Sprintf("cannot castle out of check from %v %v on %s", e.Color, e.Symbol, square)
+	case engine.IllegalCastlingThroughCheck:
+		return fmt.Sprintf("cannot castle through check, %s is attacked by %v %v", square, e.Color, e.Symbol)
+	case engine.IllegalCastlingRightsLost:
+		return "castling rights lost, king or rook has already moved"
+	case engine.IllegalPromotionMissing:
+		return "promotion piece is required"
+	case engine.IllegalPromotionInvalid:
+		return "promotion piece must be " + joinSymbols(e.Promotions)
+	case engine.IllegalCaptureRequired:
+		return "a capture is available and must be played"
+	case engine.IllegalKingCapture:
+		return "king cannot capture"
+	case engine.IllegalDropNotInPocket:
+		return fmt.Sprintf("no %v in pocket", e.Move.Symbol)
+	case engine.IllegalDropOccupied:
+		return fmt.Sprintf("cannot drop on %s, occupied by %v %v", to, e.Color, e.Symbol)
+	case engine.IllegalDropPawnRank:
+		return "pawns cannot be dropped on the first or last rank"
+	case engine.IllegalOwnKingExploded:
+		return fmt.Sprintf("capture on %s would explode own king on %s", to, square)
+	default:
+		return string(e.Reason)
+	}
+}
+
+// joinSymbols lists symbols as "queen, rook or knight"
+func joinSymbols(symbols []engine.Symbol) string {
+	names := make([]string, len(symbols))
+	for i, s := range symbols {
+		names[i] = s.String()
+	}
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
@@ -11,35 +11,70 @@ import (
 )
 
 type Game struct {
-	mu          sync.Mutex
-	b           Board
-	state       State
-	winner      engine.Color
+	mu      sync.Mutex
+	b       Board
+	state   State
+	winner  engine.Color
+	variant engine.Variant
+	rules   Rules
+	// clock nil for untimed games
+	clock       *clock
 	CreatedTime time.Time
+	// legalMoves includes the legal moves of the side to move in rounds
+	legalMoves bool
+
+	listeners listeners
+	// changes pending notification while mu is held
+	changes changes
 }
 
+// NewGame creates a game, the variant is taken from b.
+// Default Rules are used if rules is not provided.
 func NewGame(
 	b Board,
+	rules ...Rules,
 ) *Game {
-	return &Game{
+	var r Rules
+	if len(rules) > 0 {
+		r = rules[0]
+	}
+	g := &Game{
 		b:           b,
 		state:       StateInProgress,
+		variant:     b.Variant(),
+		rules:       r,
 		CreatedTime: time.Now(),
 	}
+	if r.TimeControl.IsTimed() {
+		g.clock = newClock(r.TimeControl, SystemTime{})
+	}
+	return g
 }
 
 func (g *Game) ApplyMove(m Move) (RoundResult, error) {
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.unlockAndNotify(g.state)
 
 	return g.applyMove(m)
 }
 
-func (g *Game) UndoLastMove() bool {
+// UndoLastMove takes back the last move, disallowed by Rules.NoTakebacks.
+func (g *Game) UndoLastMove() error {
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.unlockAndNotify(g.state)
 
-	return g.b.UndoLastMove()
+	if g.rules.NoTakebacks {
+		return ErrTakebackNotAllowed
+	}
+	move, ok := g.b.LastMove()
+	if !ok || !g.b.UndoLastMove() {
+		return ErrNoMoveToUndo
+	}
+	if g.clock != nil && !g.state.IsGameOver() {
+		g.clock.undo(move.Color, g.clock.ts.Now())
+	}
+	g.changes.undo = true
+	return nil
 }
 
 func (g *Game) ActiveColor() engine.Color {
@@ -49,6 +84,14 @@ func (g *Game) ActiveColor() engine.Color {
 	return g.b.ActiveColor()
 }
 
+func (g *Game) Variant() engine.Variant {
+	return g.variant
+}
+
+func (g *Game) Rules() Rules {
+	return g.rules
+}
+
 func (g *Game) State() State {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -77,21 +120,35 @@ func (g *Game) Pieces(c engine.Color) []engine.Piece {
 }
 
 var promotionNotationSymbol = map[string]engine.Symbol{
+	// King promotion is only legal in antichess
+	"K": engine.King,
 	"Q": engine.Queen,
 	"R": engine.Rook,
 	"B": engine.Bishop,
 	"N": engine.Knight,
 }
 
-// ApplyMoveWithFileRank : format a2a3=N
+var dropNotationSymbol = map[string]engine.Symbol{
+	"P": engine.Pawn,
+	"N": engine.Knight,
+	"B": engine.Bishop,
+	"R": engine.Rook,
+	"Q": engine.Queen,
+}
+
+// ApplyMoveWithFileRank : format a2a3=N, or N@f3 for drops
 // removes all spaces and converts to Move
 // then calls ApplyMove
 func (g *Game) ApplyMoveWithFileRank(move string) (RoundResult, error) {
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.unlockAndNotify(g.state)
 
 	move = strings.ReplaceAll(move, " ", "")
 
+	if notation, square, isDrop := strings.Cut(move, "@"); isDrop {
+		return g.applyDropWithFileRank(notation, square)
+	}
+
 	split := strings.Split(move, "=")
 	fromTo := split[0]
 	if len(fromTo) != 4 {
@@ -127,17 +184,54 @@ func (g *Game) ApplyMoveWithFileRank(move string) (RoundResult, error) {
 	return g.applyMove(m)
 }
 
+// ForceDraw claims a draw by the 100 half move rule or threefold repetition.
 func (g *Game) ForceDraw() error {
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.unlockAndNotify(g.state)
 
 	if g.canForceDraw() {
-		g.state = StateDraw
+		g.state = g.draw(StateDraw)
+		g.stopClock()
 		return nil
 	}
 	return ErrNotEligibleToForceDraw
 }
 
+// AgreeDraw ends the game in a draw agreed by both players,
+// disallowed before Rules.DrawOfferMinMove full moves are played.
+func (g *Game) AgreeDraw() error {
+	g.mu.Lock()
+	defer g.unlockAndNotify(g.state)
+
+	if g.state.IsGameOver() {
+		return fmt.Errorf("%w: game is already over", ErrInvalidMove)
+	}
+	if !g.rules.canOfferDraw(g.b.MoveCount()) {
+		return ErrDrawOfferTooEarly
+	}
+
+	g.state = g.draw(StateDraw)
+	g.stopClock()
+	return nil
+}
+
+// CanOfferDraw reports whether a draw can be offered under the Sofia rule.
+func (g *Game) CanOfferDraw() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return !g.state.IsGameOver() && g.rules.canOfferDraw(g.b.MoveCount())
+}
+
+// IsDraw game ended in a draw or stalemate with no winner.
+// Always false in Armageddon where Black wins instead.
+func (g *Game) IsDraw() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return (g.state == StateDraw || g.state == StateStalemate) && g.winner == 0
+}
+
 func (g *Game) Winner() engine.Color {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -156,29 +250,37 @@ func (g *Game) Round() RoundResult {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	return g.round()
+}
+
+func (g *Game) round() RoundResult {
 	var mr *MoveResult
 	move, ok := g.b.LastMove()
 	if ok {
 		mr = new(fromEngine(move))
 	}
 
-	return RoundResult{
+	return g.withViews(g.withChecks(g.withMaterial(RoundResult{
 		Count:       g.b.MoveCount(),
 		MoveResult:  mr,
 		State:       g.state,
 		Grid:        g.b.GridRaw(),
 		ActiveColor: g.b.ActiveColor(),
-	}
+		Pockets:     g.pockets(),
+		Remaining:   g.remaining(),
+	})))
 }
 
 func (g *Game) Resign(color engine.Color) error {
 	g.mu.Lock()
-	defer g.mu.Unlock()
+	defer g.unlockAndNotify(g.state)
 
 	if g.state.IsGameOver() {
 		return fmt.Errorf("%w: game is already over", ErrInvalidMove)
 	}
 
+	g.stopClock()
+
 	if color == engine.White {
 		g.state = StateWhiteResign
 		g.winner = engine.Black
@@ -186,15 +288,124 @@ func (g *Game) Resign(color engine.Color) error {
 	}
 
 	g.state = StateBlackResign
-	g.winner = engine.Black
+	g.winner = engine.White
+
+	return nil
+}
+
+// Abort calls off the game without a result, only allowed until both sides have moved.
+func (g *Game) Abort() error {
+	g.mu.Lock()
+	defer g.unlockAndNotify(g.state)
+
+	if g.state.IsGameOver() {
+		return fmt.Errorf("%w: game is already over", ErrInvalidMove)
+	}
+	if len(g.b.History()) >= 2 {
+		return ErrAbortNotAllowed
+	}
 
+	g.stopClock()
+	g.state = StateAborted
 	return nil
 }
 
+// Forfeit ends the game as a loss on time for c, for deadlines enforced outside of the game clock.
+// The game is drawn instead if the opponent of c cannot checkmate.
+func (g *Game) Forfeit(c engine.Color) error {
+	g.mu.Lock()
+	defer g.unlockAndNotify(g.state)
+
+	if g.state.IsGameOver() {
+		return fmt.Errorf("%w: game is already over", ErrInvalidMove)
+	}
+
+	g.stopClock()
+	g.state = g.timeoutState(c)
+	return nil
+}
+
+// SetTimeSource replaces the time source of the clock, must be set before the clock starts.
+func (g *Game) SetTimeSource(ts TimeSource) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.clock != nil {
+		g.clock.ts = ts
+	}
+}
+
+// StartClock starts the clock of the active color,
+// otherwise the clock starts after the first move.
+func (g *Game) StartClock() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.clock == nil || g.clock.running != 0 || g.state.IsGameOver() {
+		return
+	}
+	g.clock.start(g.b.ActiveColor(), g.clock.ts.Now())
+}
+
+// Remaining time on the clock of color, 0 for untimed games.
+func (g *Game) Remaining(c engine.Color) time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.clock == nil {
+		return 0
+	}
+	return g.clock.remainingAt(c, g.clock.ts.Now())
+}
+
+// MoveTimes time taken by each move in order, nil for untimed games.
+func (g *Game) MoveTimes() []time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.clock == nil {
+		return nil
+	}
+	return slices.Clone(g.clock.history)
+}
+
+// RestoreClock stops the clock and restores the remaining time of both colors and
+// the time taken by each move, for games rebuilt by replaying their moves.
+// The clock of the active color starts again with StartClock.
+func (g *Game) RestoreClock(remaining map[engine.Color]time.Duration, moveTimes []time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.clock == nil {
+		return
+	}
+	g.clock.running = 0
+	for c, d := range remaining {
+		g.clock.remaining[c] = d
+	}
+	g.clock.history = slices.Clone(moveTimes)
+	g.clock.presses = nil
+}
+
+// CheckTimeout ends the game if the active color has run out of time.
+func (g *Game) CheckTimeout() bool {
+	g.mu.Lock()
+	defer g.unlockAndNotify(g.state)
+
+	return g.checkTimeout()
+}
+
 // ----- unexported ------- //
 // makes it easier to check concurrent access
 
 func (g *Game) applyMove(m Move) (RoundResult, error) {
+	if g.state.IsGameOver() {
+		return RoundResult{}, fmt.Errorf("%w: game is already over", ErrInvalidMove)
+	}
+	if g.checkTimeout() {
+		return RoundResult{}, ErrTimeout
+	}
+
 	engineMove, err := g.validateAndConvertMove(m)
 	if err != nil {
 		return RoundResult{}, err
@@ -205,15 +416,47 @@ func (g *Game) applyMove(m Move) (RoundResult, error) {
 		return RoundResult{}, err
 	}
 
+	if g.clock != nil {
+		g.clock.press(m.Color, g.clock.ts.Now())
+	}
+
 	g.state = g.calculateGameState()
+	if g.state.IsGameOver() {
+		g.stopClock()
+	}
 
-	return RoundResult{
+	rr := g.withViews(g.withChecks(g.withMaterial(RoundResult{
 		Count:       g.b.MoveCount(),
 		MoveResult:  new(fromEngine(engineMove)),
 		State:       g.state,
 		Grid:        g.b.GridRaw(),
 		ActiveColor: g.b.ActiveColor(),
-	}, nil
+		Pockets:     g.pockets(),
+		Remaining:   g.remaining(),
+	})))
+	g.changes = changes{move: true, round: &rr}
+	return rr, nil
+}
+
+func (g *Game) pockets() map[engine.Color][]engine.Symbol {
+	if !g.variant.HasPockets() {
+		return nil
+	}
+	return map[engine.Color][]engine.Symbol{
+		engine.White: g.b.Pocket(engine.White),
+		engine.Black: g.b.Pocket(engine.Black),
+	}
+}
+
+func (g *Game) remaining() map[engine.Color]int64 {
+	if g.clock == nil {
+		return nil
+	}
+	now := g.clock.ts.Now()
+	return map[engine.Color]int64{
+		engine.White: g.clock.remainingAt(engine.White, now).Milliseconds(),
+		engine.Black: g.clock.remainingAt(engine.Black, now).Milliseconds(),
+	}
 }
 
 func (g *Game) validateAndConvertMove(m Move) (engine.Move, error) {
@@ -221,6 +464,10 @@ func (g *Game) validateAndConvertMove(m Move) (engine.Move, error) {
 		return engine.Move{}, engine.ErrNotActiveColor
 	}
 
+	if m.IsDrop {
+		return g.validateAndConvertDrop(m)
+	}
+
 	piece, ok := g.b.Piece(m.Color, m.Symbol, m.mbFrom())
 	if !ok {
 		return engine.Move{}, engine.ErrPieceNotFound
@@ -242,6 +489,54 @@ func (g *Game) validateAndConvertMove(m Move) (engine.Move, error) {
 		return false
 	})
 	if moveIndex == -1 {
+		im, illegal := g.b.ExplainIllegalMove(engine.Move{
+			Color:     m.Color,
+			Symbol:    m.Symbol,
+			From:      m.mbFrom(),
+			To:        m.mbTo(),
+			Promotion: m.Promotion,
+		})
+		if illegal {
+			return engine.Move{}, newIllegalMoveError(m, im)
+		}
+		return engine.Move{}, ErrIllegalMove
+	}
+
+	return moves[moveIndex], nil
+}
+
+func (g *Game) applyDropWithFileRank(notation string, square string) (RoundResult, error) {
+	s, ok := dropNotationSymbol[notation]
+	if !ok {
+		return RoundResult{}, fmt.Errorf("%w: invalid drop piece", ErrInvalidMove)
+	}
+	if len(square) != 2 || !g.isValidFile(square[0]) || !g.isValidRank(square[1]) {
+		return RoundResult{}, fmt.Errorf("%w: file or rank is out of range", ErrInvalidMove)
+	}
+
+	return g.applyMove(Move{
+		Color:  g.b.ActiveColor(),
+		Symbol: s,
+		To:     g.fileRankToIndex(square[0], square[1]),
+		IsDrop: true,
+	})
+}
+
+func (g *Game) validateAndConvertDrop(m Move) (engine.Move, error) {
+	moves := g.b.GenerateLegalDropMoves(m.Color)
+	moveIndex := slices.IndexFunc(moves, func(move engine.Move) bool {
+		return move.Symbol == m.Symbol && move.To == m.mbTo()
+	})
+	if moveIndex == -1 {
+		im, illegal := g.b.ExplainIllegalMove(engine.Move{
+			Color:  m.Color,
+			Symbol: m.Symbol,
+			To:     m.mbTo(),
+			IsDrop: true,
+		})
+		if illegal {
+			return engine.Move{}, newIllegalMoveError(m, im)
+		}
 		return engine.Move{}, ErrIllegalMove
 	}
 
@@ -252,6 +547,43 @@ func (g *Game) canForceDraw() bool {
 	return g.b.Is100MoveDraw() || g.b.Is3FoldDraw()
 }
 
+func (g *Game) stopClock() {
+	if g.clock != nil {
+		g.clock.stop(g.clock.ts.Now())
+	}
+}
+
+func (g *Game) checkTimeout() bool {
+	if g.clock == nil || g.state.IsGameOver() {
+		return false
+	}
+	now := g.clock.ts.Now()
+	if !g.clock.isFlagged(now) {
+		return false
+	}
+
+	flagged := g.clock.running
+	g.clock.stop(now)
+	g.state = g.timeoutState(flagged)
+	return true
+}
+
+// timeoutState the opponent of flagged wins unless they cannot checkmate,
+// insufficient material is only considered in VariantStandard.
+func (g *Game) timeoutState(flagged engine.Color) State {
+	if g.variant == engine.VariantStandard && g.b.IsInsufficientMaterial(flagged.Opposite()) {
+		return g.draw(StateDraw)
+	}
+	g.winner = flagged.Opposite()
+	return StateTimeout
+}
+
+// draw resolves the winner of a drawn state according to rules
+func (g *Game) draw(state State) State {
+	g.winner = g.rules.drawWinner()
+	return state
+}
+
 func (g *Game) isValidFile(r byte) bool {
 	return r >= 'a' && r <= 'h'
 }
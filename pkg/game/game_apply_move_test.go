@@ -17,6 +17,7 @@ func TestApplyMoveWithMock(t *testing.T) {
 		defer ctrl.Finish()
 
 		b := NewMockBoard(ctrl)
+		b.EXPECT().Variant().Return(engine.VariantStandard)
 		g := NewGame(b)
 
 		m := Move{
@@ -47,6 +48,9 @@ func TestApplyMoveWithMock(t *testing.T) {
 		b.EXPECT().MoveCount().Return(rand.IntN(10))
 		b.EXPECT().GridRaw().Return([64]int{})
 		b.EXPECT().ActiveColor().Return(engine.Black)
+		b.EXPECT().Pieces(gomock.Any()).Return(nil).Times(2)
+		b.EXPECT().Captured(gomock.Any()).Return(nil).Times(2)
+		b.EXPECT().Checkers(gomock.Any()).Return(nil)
 
 		_, err := g.ApplyMove(m)
 		assert.NoError(t, err)
@@ -59,6 +63,7 @@ func TestApplyMoveWithMock(t *testing.T) {
 		defer ctrl.Finish()
 
 		b := NewMockBoard(ctrl)
+		b.EXPECT().Variant().Return(engine.VariantStandard)
 		g := NewGame(b)
 
 		m := Move{
@@ -81,6 +86,7 @@ func TestApplyMoveWithMock(t *testing.T) {
 		defer ctrl.Finish()
 
 		b := NewMockBoard(ctrl)
+		b.EXPECT().Variant().Return(engine.VariantStandard)
 		g := NewGame(b)
 
 		m := Move{
@@ -101,9 +107,18 @@ func TestApplyMoveWithMock(t *testing.T) {
 		b.EXPECT().ActiveColor().Return(engine.White)
 		b.EXPECT().Piece(m.Color, m.Symbol, m.mbFrom()).Return(piece, true)
 		b.EXPECT().GeneratePieceLegalMoves(piece).Return(legalMoves, nil)
+		b.EXPECT().ExplainIllegalMove(engine.Move{
+			Color: m.Color, Symbol: m.Symbol, From: m.mbFrom(), To: m.mbTo(),
+		}).Return(engine.IllegalMove{Reason: engine.IllegalPieceMovement}, true)
 
 		_, err := g.ApplyMove(m)
 		assert.ErrorIs(t, err, ErrIllegalMove)
+
+		var illegalErr *IllegalMoveError
+		require.ErrorAs(t, err, &illegalErr)
+		assert.Equal(t, engine.IllegalPieceMovement, illegalErr.Reason)
+		assert.Equal(t, -1, illegalErr.Square)
+		assert.Equal(t, "illegal move: pawn cannot move from a2 to a5", err.Error())
 	})
 
 	t.Run("failed to apply move", func(t *testing.T) {
@@ -111,6 +126,7 @@ func TestApplyMoveWithMock(t *testing.T) {
 		defer ctrl.Finish()
 
 		b := NewMockBoard(ctrl)
+		b.EXPECT().Variant().Return(engine.VariantStandard)
 		g := NewGame(b)
 
 		m := Move{
@@ -146,6 +162,7 @@ func TestApplyMoveWithMock(t *testing.T) {
 		defer ctrl.Finish()
 
 		b := NewMockBoard(ctrl)
+		b.EXPECT().Variant().Return(engine.VariantStandard)
 		g := NewGame(b)
 
 		m := Move{
@@ -179,6 +196,9 @@ func TestApplyMoveWithMock(t *testing.T) {
 		b.EXPECT().MoveCount().Return(rand.IntN(10))
 		b.EXPECT().GridRaw().Return([64]int{})
 		b.EXPECT().ActiveColor().Return(engine.Black)
+		b.EXPECT().Pieces(gomock.Any()).Return(nil).Times(2)
+		b.EXPECT().Captured(gomock.Any()).Return(nil).Times(2)
+		b.EXPECT().Checkers(gomock.Any()).Return(nil)
 
 		_, err := g.ApplyMove(m)
 		assert.NoError(t, err)
@@ -190,6 +210,7 @@ func TestApplyMoveWithMock(t *testing.T) {
 		defer ctrl.Finish()
 
 		b := NewMockBoard(ctrl)
+		b.EXPECT().Variant().Return(engine.VariantStandard)
 		g := NewGame(b)
 
 		m := Move{
@@ -223,6 +244,9 @@ func TestApplyMoveWithMock(t *testing.T) {
 		b.EXPECT().MoveCount().Return(rand.IntN(10))
 		b.EXPECT().GridRaw().Return([64]int{})
 		b.EXPECT().ActiveColor().Return(engine.Black)
+		b.EXPECT().Pieces(gomock.Any()).Return(nil).Times(2)
+		b.EXPECT().Captured(gomock.Any()).Return(nil).Times(2)
+		b.EXPECT().Checkers(gomock.Any()).Return(nil)
 
 		_, err := g.ApplyMove(m)
 		assert.NoError(t, err)
@@ -236,6 +260,7 @@ func TestApplyMoveWithFileRank(t *testing.T) {
 		defer ctrl.Finish()
 
 		b := NewMockBoard(ctrl)
+		b.EXPECT().Variant().Return(engine.VariantStandard)
 		g := NewGame(b)
 
 		tt := []struct {
@@ -281,6 +306,7 @@ func TestApplyMoveWithFileRank(t *testing.T) {
 		defer ctrl.Finish()
 
 		b := NewMockBoard(ctrl)
+		b.EXPECT().Variant().Return(engine.VariantStandard)
 		g := NewGame(b)
 
 		b.EXPECT().ActiveColor().Return(engine.White).Times(1)
@@ -314,6 +340,9 @@ func TestApplyMoveWithFileRank(t *testing.T) {
 		b.EXPECT().MoveCount().Return(rand.IntN(10))
 		b.EXPECT().GridRaw().Return([64]int{})
 		b.EXPECT().ActiveColor().Return(engine.Black)
+		b.EXPECT().Pieces(gomock.Any()).Return(nil).Times(2)
+		b.EXPECT().Captured(gomock.Any()).Return(nil).Times(2)
+		b.EXPECT().Checkers(gomock.Any()).Return(nil)
 
 		_, err := g.ApplyMoveWithFileRank("a2a3")
 		assert.NoError(t, err)
@@ -358,6 +387,10 @@ func TestApplyMove(t *testing.T) {
 
 		_, err = g.ApplyMove(m)
 		require.ErrorIs(t, err, ErrIllegalMove)
+
+		var illegalErr *IllegalMoveError
+		require.ErrorAs(t, err, &illegalErr)
+		assert.Equal(t, engine.IllegalPromotionMissing, illegalErr.Reason)
 	})
 
 	t.Run("promotion error(black), promotion not defined white", func(t *testing.T) {
@@ -529,3 +562,84 @@ func TestApplyMoveCheckPawn(t *testing.T) {
 	require.ErrorIs(t, err, ErrIllegalMove)
 	fmt.Println(board.GridFull())
 }
+
+func TestApplyMove_IllegalMoveExplanation(t *testing.T) {
+	tt := []struct {
+		name    string
+		fen     string
+		variant engine.Variant
+		move    Move
+		reason  engine.IllegalMoveReason
+		square  int
+		message string
+	}{
+		{
+			name:    "pinned",
+			fen:     "4k3/8/8/8/1b6/2N5/8/4K3 w - - 0 1",
+			move:    Move{Color: engine.White, Symbol: engine.Knight, From: 18, To: 35},
+			reason:  engine.IllegalPinned,
+			square:  25,
+			message: "illegal move: knight on c3 is pinned to the king by black bishop on b4",
+		},
+		{
+			name:    "in check",
+			fen:     "4k3/8/8/8/7q/8/P7/4K3 w - - 0 1",
+			move:    Move{Color: engine.White, Symbol: engine.Pawn, From: 8, To: 16},
+			reason:  engine.IllegalKingInCheck,
+			square:  31,
+			message: "illegal move: king would be in check from black queen on h4",
+		},
+		{
+			name:    "path blocked",
+			fen:     engine.StartFEN,
+			move:    Move{Color: engine.White, Symbol: engine.Rook, From: 0, To: 16},
+			reason:  engine.IllegalPathBlocked,
+			square:  8,
+			message: "illegal move: path from a1 to a3 is blocked by white pawn on a2",
+		},
+		{
+			name:    "castling through check",
+			fen:     "5rk1/8/8/8/8/8/8/4K2R w K - 0 1",
+			move:    Move{Color: engine.White, Symbol: engine.King, From: 4, To: 6},
+			reason:  engine.IllegalCastlingThroughCheck,
+			square:  5,
+			message: "illegal move: cannot castle through check, f1 is attacked by black rook",
+		},
+		{
+			name:    "promotion invalid",
+			fen:     "4k3/P7/8/8/8/8/8/4K3 w - - 0 1",
+			move:    Move{Color: engine.White, Symbol: engine.Pawn, From: 48, To: 56, Promotion: engine.King},
+			reason:  engine.IllegalPromotionInvalid,
+			square:  -1,
+			message: "illegal move: promotion piece must be queen, rook, bishop or knight",
+		},
+		{
+			name:    "promotion invalid antichess",
+			fen:     "8/P7/8/8/8/8/8/k7 w - - 0 1",
+			variant: engine.VariantAntichess,
+			move:    Move{Color: engine.White, Symbol: engine.Pawn, From: 48, To: 56, Promotion: engine.Pawn},
+			reason:  engine.IllegalPromotionInvalid,
+			square:  -1,
+			message: "illegal move: promotion piece must be queen, rook, bishop, knight or king",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := engine.NewBoardFromFEN(tc.fen)
+			require.NoError(t, err)
+			b.SetVariant(tc.variant)
+			g := NewGame(b)
+
+			_, err = g.ApplyMove(tc.move)
+			require.ErrorIs(t, err, ErrIllegalMove)
+
+			var illegalErr *IllegalMoveError
+			require.ErrorAs(t, err, &illegalErr)
+			assert.Equal(t, tc.reason, illegalErr.Reason)
+			assert.Equal(t, string(tc.reason), illegalErr.Code())
+			assert.Equal(t, tc.square, illegalErr.Square)
+			assert.Equal(t, tc.message, err.Error())
+		})
+	}
+}
@@ -3,7 +3,9 @@ package game
 import (
 	"testing"
 
+	"github.com/dyxj/chess/pkg/engine"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGame_fileRankToIndex(t *testing.T) {
@@ -94,3 +96,61 @@ func TestGame_fileRankToIndex(t *testing.T) {
 		})
 	}
 }
+
+func TestGame_Resign(t *testing.T) {
+	tt := []struct {
+		color  engine.Color
+		state  State
+		winner engine.Color
+	}{
+		{color: engine.White, state: StateWhiteResign, winner: engine.Black},
+		{color: engine.Black, state: StateBlackResign, winner: engine.White},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.color.String(), func(t *testing.T) {
+			g := NewGame(engine.NewBoard())
+			require.NoError(t, g.Resign(tc.color))
+			assert.Equal(t, tc.state, g.State())
+			assert.Equal(t, tc.winner, g.Winner())
+
+			assert.ErrorIs(t, g.Resign(tc.color.Opposite()), ErrInvalidMove)
+		})
+	}
+}
+
+func TestGame_Abort(t *testing.T) {
+	tt := []struct {
+		name    string
+		moves   []string
+		wantErr error
+	}{
+		{name: "no moves"},
+		{name: "white moved", moves: []string{"e2e4"}},
+		{name: "both moved", moves: []string{"e2e4", "e7e5"}, wantErr: ErrAbortNotAllowed},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGame(engine.NewBoard())
+			for _, m := range tc.moves {
+				_, err := g.ApplyMoveWithFileRank(m)
+				require.NoError(t, err)
+			}
+
+			err := g.Abort()
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Equal(t, StateInProgress, g.State())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, StateAborted, g.State())
+			assert.True(t, g.State().IsGameOver())
+			assert.Zero(t, g.Winner())
+			assert.False(t, g.IsDraw())
+
+			assert.ErrorIs(t, g.Abort(), ErrInvalidMove)
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGame_VariantWins(t *testing.T) {
+	tt := []struct {
+		name    string
+		variant engine.Variant
+		fen     string
+		moves   []string
+		state   State
+		winner  engine.Color
+	}{
+		{
+			name:    "king of the hill",
+			variant: engine.VariantKingOfTheHill,
+			fen:     "4k3/8/8/8/8/3K4/8/8 w - - 0 1",
+			moves:   []string{"d3d4"},
+			state:   StateKingOfTheHill,
+			winner:  engine.White,
+		},
+		{
+			name:    "three check",
+			variant: engine.VariantThreeCheck,
+			fen:     "7k/8/8/8/8/8/8/R3K3 w - - 0 1",
+			moves:   []string{"a1a8", "h8h7", "a8a7", "h7g8", "a7a8"},
+			state:   StateThreeCheck,
+			winner:  engine.White,
+		},
+		{
+			name:    "antichess no pieces left",
+			variant: engine.VariantAntichess,
+			fen:     "8/8/8/3p4/4P3/8/8/8 w - - 0 1",
+			moves:   []string{"e4d5"},
+			state:   StateNoMovesLeft,
+			winner:  engine.Black,
+		},
+		{
+			name:    "atomic king exploded",
+			variant: engine.VariantAtomic,
+			fen:     "4k3/3p4/8/8/8/8/8/3QK3 w - - 0 1",
+			moves:   []string{"d1d7"},
+			state:   StateKingExploded,
+			winner:  engine.White,
+		},
+		{
+			name:    "dark chess king captured",
+			variant: engine.VariantDarkChess,
+			fen:     "4k3/8/8/8/8/8/8/4RK2 w - - 0 1",
+			moves:   []string{"e1e8"},
+			state:   StateKingCaptured,
+			winner:  engine.White,
+		},
+		{
+			name:    "standard king in center",
+			variant: engine.VariantStandard,
+			fen:     "4k3/8/8/8/8/3K4/8/8 w - - 0 1",
+			moves:   []string{"d3d4"},
+			state:   StateInProgress,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := engine.NewBoardFromFEN(tc.fen)
+			require.NoError(t, err)
+			b.SetVariant(tc.variant)
+
+			g := NewGame(b)
+			assert.Equal(t, tc.variant, g.Variant())
+
+			var rr RoundResult
+			for _, m := range tc.moves {
+				rr, err = g.ApplyMoveWithFileRank(m)
+				require.NoError(t, err, m)
+			}
+
+			assert.Equal(t, tc.state, rr.State)
+			assert.Equal(t, tc.state, g.State())
+			assert.Equal(t, tc.winner, g.Winner())
+			if tc.state != StateInProgress {
+				assert.True(t, g.State().IsGameOver())
+			}
+		})
+	}
+}
+
+func TestGame_AntichessKingPromotion(t *testing.T) {
+	b, err := engine.NewBoardFromFEN("8/4P3/8/8/8/8/8/k7 w - - 0 1")
+	require.NoError(t, err)
+	b.SetVariant(engine.VariantAntichess)
+	g := NewGame(b)
+
+	rr, err := g.ApplyMoveWithFileRank("e7e8=K")
+	require.NoError(t, err)
+	assert.Equal(t, engine.King, rr.MoveResult.Promotion)
+}
+
+func TestState_VariantText(t *testing.T) {
+	for _, s := range []State{StateKingOfTheHill, StateThreeCheck, StateNoMovesLeft, StateKingExploded, StateKingCaptured} {
+		text, err := s.MarshalText()
+		require.NoError(t, err)
+
+		var got State
+		require.NoError(t, got.UnmarshalText(text))
+		assert.Equal(t, s, got)
+		assert.True(t, got.IsVariantWin())
+	}
+}
+
+func TestState_UnmarshalTextUnknown(t *testing.T) {
+	var s State
+	err := s.UnmarshalText([]byte("resigned"))
+	require.Error(t, err)
+	assert.EqualError(t, err, "unknown state: resigned valid state(in_progress,checkmate,stalemate,draw,"+
+		"white_resign,black_resign,king_of_the_hill,three_check,no_moves_left,king_exploded,king_captured,"+
+		"timeout,aborted)")
+
+	for _, state := range states {
+		text, err := state.MarshalText()
+		require.NoError(t, err)
+		require.NoError(t, s.UnmarshalText(text))
+		assert.Equal(t, state, s)
+	}
+}
+
+func TestGame_CrazyhouseDrop(t *testing.T) {
+	g := NewGame(engine.NewVariantBoard(engine.VariantCrazyhouse))
+
+	var rr RoundResult
+	var err error
+	for _, m := range []string{"e2e4", "d7d5", "e4d5", "g8f6"} {
+		rr, err = g.ApplyMoveWithFileRank(m)
+		require.NoError(t, err, m)
+	}
+	assert.Equal(t, map[engine.Color][]engine.Symbol{
+		engine.White: {engine.Pawn},
+		engine.Black: {},
+	}, rr.Pockets)
+
+	_, err = g.ApplyMove(Move{Color: engine.White, Symbol: engine.Knight, To: 44, IsDrop: true})
+	var illegalErr *IllegalMoveError
+	require.ErrorAs(t, err, &illegalErr)
+	assert.Equal(t, engine.IllegalDropNotInPocket, illegalErr.Reason)
+
+	_, err = g.ApplyMoveWithFileRank("P@e8")
+	require.ErrorAs(t, err, &illegalErr)
+	assert.Equal(t, engine.IllegalDropOccupied, illegalErr.Reason)
+
+	rr, err = g.ApplyMoveWithFileRank("P@e6")
+	require.NoError(t, err)
+	require.NotNil(t, rr.MoveResult)
+	assert.True(t, rr.MoveResult.IsDrop)
+	assert.Equal(t, -1, rr.MoveResult.From)
+	assert.Equal(t, 44, rr.MoveResult.To)
+	assert.Equal(t, 1, rr.Grid[44])
+	assert.Empty(t, rr.Pockets[engine.White])
+}
+
+func TestGame_StandardHasNoPockets(t *testing.T) {
+	g := NewGame(engine.NewBoard())
+	rr, err := g.ApplyMoveWithFileRank("e2e4")
+	require.NoError(t, err)
+	assert.Nil(t, rr.Pockets)
+
+	_, err = g.ApplyMoveWithFileRank("P@e5")
+	assert.ErrorIs(t, err, ErrIllegalMove)
+}
+
+func TestGame_DarkChessViews(t *testing.T) {
+	g := NewGame(engine.NewVariantBoard(engine.VariantDarkChess))
+
+	rr := g.Round()
+	require.Len(t, rr.Views, 2)
+	assert.Equal(t, HiddenCell, rr.For(engine.White).Grid[48], "a7 hidden from white")
+	assert.Equal(t, -1, rr.For(engine.Black).Grid[48])
+
+	rr, err := g.ApplyMoveWithFileRank("e2e4")
+	require.NoError(t, err)
+
+	white := rr.For(engine.White)
+	assert.Equal(t, 1, white.Grid[28])
+	assert.Equal(t, 12, white.MoveResult.From)
+	assert.Nil(t, white.Views)
+
+	black := rr.For(engine.Black)
+	assert.Equal(t, HiddenCell, black.Grid[28], "e4 hidden from black")
+	assert.Nil(t, black.MoveResult, "white move not seen by black")
+	assert.Equal(t, 0, rr.Grid[12], "full round is unmasked")
+
+	rr, err = g.ApplyMoveWithFileRank("d7d5")
+	require.NoError(t, err)
+
+	white = rr.For(engine.White)
+	require.NotNil(t, white.MoveResult)
+	assert.Equal(t, -1, white.MoveResult.From, "d7 hidden from white")
+	assert.Equal(t, 35, white.MoveResult.To, "d5 visible to e4 pawn")
+	assert.Equal(t, -1, white.Grid[35])
+}
+
+func TestGame_DarkChessRevealAtGameOver(t *testing.T) {
+	b, err := engine.NewBoardFromFEN("4k3/8/8/8/8/8/8/4RK2 w - - 0 1")
+	require.NoError(t, err)
+	b.SetVariant(engine.VariantDarkChess)
+	g := NewGame(b)
+
+	rr, err := g.ApplyMoveWithFileRank("e1e8")
+	require.NoError(t, err)
+	assert.Nil(t, rr.Views)
+	assert.Equal(t, rr, rr.For(engine.Black))
+	assert.Equal(t, 4, rr.For(engine.Black).MoveResult.From)
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"slices"
+
+	"github.com/dyxj/chess/pkg/engine"
+)
+
+// Hint assistance for a player, see Game.Hint.
+type Hint struct {
+	// Count move count of the position the hint is for
+	Count int          `json:"count"`
+	Color engine.Color `json:"color"`
+	// Hanging board indices of pieces of Color attacked by the opponent and not defended
+	Hanging []int `json:"hanging"`
+	// Captures legal captures of Color, omitted unless Color is the side to move
+	Captures []MoveResult `json:"captures,omitempty"`
+	// MaterialLoss material the last move loses by a simple exchange on its destination,
+	// omitted if the last move was not played by Color or does not lose material
+	MaterialLoss int `json:"materialLoss,omitempty"`
+}
+
+// Hint tells c which of its pieces hang, which captures it can play and whether its
+// last move lost material. Exchanges are evaluated statically without pins, so hints
+// are meant for beginners. Not available in dark chess as it reveals hidden pieces.
+func (g *Game) Hint(c engine.Color) (Hint, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.variant == engine.VariantDarkChess {
+		return Hint{}, ErrHintUnavailable
+	}
+
+	h := Hint{
+		Count:   g.b.MoveCount(),
+		Color:   c,
+		Hanging: make([]int, 0, 2),
+	}
+	for _, p := range g.b.Hanging(c) {
+		h.Hanging = append(h.Hanging, engine.MailboxToIndex(p.Position()))
+	}
+	slices.Sort(h.Hanging)
+
+	if !g.state.IsGameOver() && g.b.ActiveColor() == c {
+		for _, m := range g.b.GenerateLegalMoves(c) {
+			if m.Captured != 0 {
+				h.Captures = append(h.Captures, fromEngine(m))
+			}
+		}
+	}
+
+	if m, ok := g.b.LastMove(); ok && m.Color == c {
+		h.MaterialLoss = g.materialLoss(m)
+	}
+	return h, nil
+}
+
+// materialLoss material lost by m when the opponent starts a simple exchange on its
+// destination, material won by capturing or promoting is deducted.
+func (g *Game) materialLoss(m engine.Move) int {
+	won := m.Captured.Value()
+	if m.Promotion != 0 {
+		won += m.Promotion.Value() - engine.Pawn.Value()
+	}
+	return max(0, g.b.ExchangeGain(m.To, m.Color.Opposite())-won)
+}
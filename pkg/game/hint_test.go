@@ -0,0 +1,69 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGame_Hint(t *testing.T) {
+	g := NewGame(engine.NewBoard())
+	for _, m := range []string{"e2e4", "e7e5", "d1h5", "g8f6"} {
+		_, err := g.ApplyMoveWithFileRank(m)
+		require.NoError(t, err, m)
+	}
+
+	white, err := g.Hint(engine.White)
+	require.NoError(t, err)
+	assert.Equal(t, 4, white.Count)
+	assert.Equal(t, engine.White, white.Color)
+	assert.Equal(t, []int{28, 39}, white.Hanging, "pawn e4 and queen h5")
+	var captures [][2]int
+	for _, c := range white.Captures {
+		captures = append(captures, [2]int{c.From, c.To})
+	}
+	assert.ElementsMatch(t, [][2]int{{39, 36}, {39, 53}, {39, 55}}, captures)
+	assert.Zero(t, white.MaterialLoss)
+
+	black, err := g.Hint(engine.Black)
+	require.NoError(t, err)
+	assert.Equal(t, []int{36}, black.Hanging, "pawn e5")
+	assert.Empty(t, black.Captures, "not the side to move")
+	assert.Zero(t, black.MaterialLoss)
+}
+
+func TestGame_HintMaterialLoss(t *testing.T) {
+	tests := []struct {
+		name string
+		fen  string
+		move string
+		loss int
+	}{
+		{name: "queen takes defended pawn", fen: "4k3/2p5/3p4/8/8/8/8/3QK3 w - - 0 1", move: "d1d6", loss: 8},
+		{name: "rook takes defended rook", fen: "3rk3/3r4/8/8/8/8/8/3RK3 w - - 0 1", move: "d1d7", loss: 0},
+		{name: "queen moves into attack", fen: "4k3/8/2n5/8/8/8/8/3QK3 w - - 0 1", move: "d1d4", loss: 9},
+		{name: "promotion on defended square", fen: "1r5k/P7/8/8/8/8/8/K7 w - - 0 1", move: "a7a8=Q", loss: 1},
+		{name: "safe move", fen: engine.StartFEN, move: "e2e4", loss: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := engine.NewBoardFromFEN(tt.fen)
+			require.NoError(t, err)
+			g := NewGame(b)
+			_, err = g.ApplyMoveWithFileRank(tt.move)
+			require.NoError(t, err)
+
+			h, err := g.Hint(engine.White)
+			require.NoError(t, err)
+			assert.Equal(t, tt.loss, h.MaterialLoss)
+		})
+	}
+}
+
+func TestGame_HintDarkChess(t *testing.T) {
+	g := NewGame(engine.NewVariantBoard(engine.VariantDarkChess))
+	_, err := g.Hint(engine.White)
+	assert.ErrorIs(t, err, ErrHintUnavailable)
+}
@@ -1,6 +1,8 @@
 package game
 
 import (
+	"io"
+
 	"github.com/dyxj/chess/pkg/engine"
 )
 
@@ -9,15 +11,33 @@ type Board interface {
 	ApplyMove(m engine.Move) error
 	UndoLastMove() bool
 	LastMove() (engine.Move, bool)
+	History() []engine.Move
+	StartFEN() string
 	Piece(c engine.Color, s engine.Symbol, position int) (engine.Piece, bool)
 	Pieces(c engine.Color) []engine.Piece
 	Symbol(pos int) engine.Symbol
 	ActiveColor() engine.Color
 	GeneratePieceLegalMoves(p engine.Piece) ([]engine.Move, error)
+	GenerateLegalDropMoves(c engine.Color) []engine.Move
+	GenerateLegalMoves(c engine.Color) []engine.Move
+	Pocket(c engine.Color) []engine.Symbol
+	AddToPocket(c engine.Color, s engine.Symbol) error
+	LastCaptured() (engine.Piece, bool)
+	Captured(c engine.Color) []engine.Piece
+	ExplainIllegalMove(m engine.Move) (engine.IllegalMove, bool)
 	HasLegalMoves(c engine.Color) bool
 	IsCheck(c engine.Color) bool
+	Checkers(c engine.Color) []int
+	Hanging(c engine.Color) []engine.Piece
+	ExchangeGain(pos int, attacker engine.Color) int
 	GridRaw() [64]int
+	Visibility(c engine.Color) [64]bool
 	Is100MoveDraw() bool
 	Is3FoldDraw() bool
+	IsInsufficientMaterial(c engine.Color) bool
 	MoveCount() int
+	Variant() engine.Variant
+	VariantWinner() (engine.Color, engine.VariantWin)
+	Save(w io.Writer) error
+	Load(r io.Reader) error
 }
@@ -0,0 +1,71 @@
+package game
+
+import (
+	"slices"
+
+	"github.com/dyxj/chess/pkg/engine"
+)
+
+// IncludeLegalMoves includes the legal moves of the side to move in rounds,
+// so clients can restrict input to legal squares without implementing the rules.
+func (g *Game) IncludeLegalMoves(include bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.legalMoves = include
+}
+
+// LegalMoves legal moves of the side to move by origin index, drops are excluded.
+// Empty once the game is over.
+func (g *Game) LegalMoves() map[int][]LegalMove {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.state.IsGameOver() {
+		return map[int][]LegalMove{}
+	}
+	return legalMoveMap(g.b.GenerateLegalMoves(g.b.ActiveColor()))
+}
+
+// withChecks adds the check status and, if enabled, the legal moves of the side to move.
+func (g *Game) withChecks(r RoundResult) RoundResult {
+	for _, pos := range g.b.Checkers(r.ActiveColor) {
+		r.Checkers = append(r.Checkers, engine.MailboxToIndex(pos))
+	}
+	r.InCheck = len(r.Checkers) > 0
+
+	if g.legalMoves && !r.State.IsGameOver() {
+		r.LegalMoves = legalMoveMap(g.b.GenerateLegalMoves(r.ActiveColor))
+	}
+	return r
+}
+
+// legalMoveMap groups moves by origin index, destinations are sorted
+// and promotions to the same square are merged.
+func legalMoveMap(moves []engine.Move) map[int][]LegalMove {
+	m := make(map[int][]LegalMove, 16)
+	for _, mv := range moves {
+		if mv.IsDrop {
+			continue
+		}
+		from := engine.MailboxToIndex(mv.From)
+		to := engine.MailboxToIndex(mv.To)
+
+		i := slices.IndexFunc(m[from], func(lm LegalMove) bool { return lm.To == to })
+		if i < 0 {
+			m[from] = append(m[from], LegalMove{To: to})
+			i = len(m[from]) - 1
+		}
+		if mv.Promotion != 0 {
+			m[from][i].Promotions = append(m[from][i].Promotions, mv.Promotion)
+		}
+	}
+
+	for _, lms := range m {
+		slices.SortFunc(lms, func(a, b LegalMove) int { return a.To - b.To })
+		for _, lm := range lms {
+			slices.Sort(lm.Promotions)
+		}
+	}
+	return m
+}
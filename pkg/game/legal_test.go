@@ -0,0 +1,96 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGame_Checks(t *testing.T) {
+	tests := []struct {
+		name     string
+		fen      string
+		inCheck  bool
+		checkers []int
+	}{
+		{name: "not in check", fen: engine.StartFEN},
+		{name: "check", fen: "4k3/8/8/8/8/8/8/4R1K1 b - - 0 1", inCheck: true, checkers: []int{4}},
+		{name: "double check", fen: "4k3/8/3N4/8/8/8/8/4R1K1 b - - 0 1", inCheck: true, checkers: []int{4, 43}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := engine.NewBoardFromFEN(tt.fen)
+			require.NoError(t, err)
+			rr := NewGame(b).Round()
+
+			assert.Equal(t, tt.inCheck, rr.InCheck)
+			assert.Equal(t, tt.checkers, rr.Checkers)
+		})
+	}
+}
+
+func TestGame_ChecksAfterMove(t *testing.T) {
+	g := NewGame(engine.NewBoard())
+	g.IncludeLegalMoves(true)
+	var rr RoundResult
+	var err error
+	for _, m := range []string{"e2e4", "e7e5", "d1h5", "b8c6", "f1c4", "g8f6", "h5f7"} {
+		rr, err = g.ApplyMoveWithFileRank(m)
+		require.NoError(t, err, m)
+	}
+
+	assert.Equal(t, StateCheckmate, rr.State)
+	assert.True(t, rr.InCheck)
+	assert.Equal(t, []int{53}, rr.Checkers)
+	assert.Nil(t, rr.LegalMoves, "game is over")
+	assert.Empty(t, g.LegalMoves())
+}
+
+func TestGame_LegalMoves(t *testing.T) {
+	g := NewGame(engine.NewBoard())
+	assert.Nil(t, g.Round().LegalMoves, "not included by default")
+
+	g.IncludeLegalMoves(true)
+	rr := g.Round()
+	assert.Len(t, rr.LegalMoves, 10)
+	assert.Equal(t, []LegalMove{{To: 16}, {To: 18}}, rr.LegalMoves[1])
+	assert.Equal(t, []LegalMove{{To: 20}, {To: 28}}, rr.LegalMoves[12])
+	assert.NotContains(t, rr.LegalMoves, 4, "king has no moves")
+	assert.Equal(t, rr.LegalMoves, g.LegalMoves())
+
+	rr, err := g.ApplyMoveWithFileRank("e2e4")
+	require.NoError(t, err)
+	assert.Equal(t, []LegalMove{{To: 40}, {To: 42}}, rr.LegalMoves[57], "moves of black")
+}
+
+func TestGame_LegalMovesPromotion(t *testing.T) {
+	b, err := engine.NewBoardFromFEN("1r5k/P7/8/8/8/8/8/K7 w - - 0 1")
+	require.NoError(t, err)
+	g := NewGame(b)
+
+	promotions := []engine.Symbol{engine.Knight, engine.Bishop, engine.Rook, engine.Queen}
+	assert.Equal(t, []LegalMove{
+		{To: 56, Promotions: promotions},
+		{To: 57, Promotions: promotions},
+	}, g.LegalMoves()[48])
+}
+
+func TestGame_LegalMovesDarkChess(t *testing.T) {
+	b := engine.NewVariantBoard(engine.VariantDarkChess)
+	g := NewGame(b)
+	g.IncludeLegalMoves(true)
+
+	rr := g.Round()
+	assert.Len(t, rr.For(engine.White).LegalMoves, 10)
+	assert.Nil(t, rr.For(engine.Black).LegalMoves, "opponent moves are hidden")
+}
+
+func TestLegalMoveMap_SkipsDrops(t *testing.T) {
+	moves := []engine.Move{
+		{Color: engine.White, Symbol: engine.Knight, To: engine.IndexToMailbox(20), IsDrop: true},
+		{Color: engine.White, Symbol: engine.King, From: engine.IndexToMailbox(4), To: engine.IndexToMailbox(5)},
+	}
+	assert.Equal(t, map[int][]LegalMove{4: {{To: 5}}}, legalMoveMap(moves))
+}
@@ -0,0 +1,136 @@
+package game
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/dyxj/chess/pkg/engine"
+)
+
+// Captured pieces captured by c in capture order.
+func (g *Game) Captured(c engine.Color) []engine.Symbol {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return capturedSymbols(g.b.Captured(c.Opposite()))
+}
+
+// Material value of the pieces c has on the board, see engine.Symbol.Value.
+func (g *Game) Material(c engine.Color) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return materialValue(g.b.Pieces(c))
+}
+
+// MaterialDiff material of white minus material of black.
+func (g *Game) MaterialDiff() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return materialValue(g.b.Pieces(engine.White)) - materialValue(g.b.Pieces(engine.Black))
+}
+
+// Promoted board indices (0-63) of the pieces of c promoted from pawns.
+func (g *Game) Promoted(c engine.Color) []int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return promotedIndices(g.b.Pieces(c))
+}
+
+// withMaterial adds captured pieces, material and promoted pieces to r.
+// Computed from the board, so it stays correct after UndoLastMove.
+func (g *Game) withMaterial(r RoundResult) RoundResult {
+	r.Material = make(map[engine.Color]int, len(engine.Colors))
+	for _, color := range engine.Colors {
+		pieces := g.b.Pieces(color)
+		r.Material[color] = materialValue(pieces)
+
+		if captured := capturedSymbols(g.b.Captured(color.Opposite())); len(captured) > 0 {
+			if r.Captured == nil {
+				r.Captured = make(map[engine.Color][]engine.Symbol, len(engine.Colors))
+			}
+			r.Captured[color] = captured
+		}
+		if promoted := promotedIndices(pieces); len(promoted) > 0 {
+			if r.Promoted == nil {
+				r.Promoted = make(map[engine.Color][]int, len(engine.Colors))
+			}
+			r.Promoted[color] = promoted
+		}
+	}
+	r.MaterialDiff = r.Material[engine.White] - r.Material[engine.Black]
+	return r
+}
+
+// maskMaterial hides promoted pieces of the opponent outside of visible,
+// their material is counted as the pawn they were promoted from.
+func maskMaterial(r RoundResult, color engine.Color, visible [64]bool) RoundResult {
+	opponent := color.Opposite()
+	promoted := r.Promoted[opponent]
+	if len(promoted) == 0 {
+		return r
+	}
+
+	material := maps.Clone(r.Material)
+	seen := make([]int, 0, len(promoted))
+	for _, i := range promoted {
+		if visible[i] {
+			seen = append(seen, i)
+			continue
+		}
+		material[opponent] -= absSymbol(r.Grid[i]).Value() - engine.Pawn.Value()
+	}
+
+	r.Promoted = maps.Clone(r.Promoted)
+	if len(seen) > 0 {
+		r.Promoted[opponent] = seen
+	} else {
+		delete(r.Promoted, opponent)
+	}
+	if len(r.Promoted) == 0 {
+		r.Promoted = nil
+	}
+	r.Material = material
+	r.MaterialDiff = material[engine.White] - material[engine.Black]
+	return r
+}
+
+func materialValue(pieces []engine.Piece) int {
+	total := 0
+	for _, p := range pieces {
+		total += p.Symbol().Value()
+	}
+	return total
+}
+
+func capturedSymbols(pieces []engine.Piece) []engine.Symbol {
+	if len(pieces) == 0 {
+		return nil
+	}
+	symbols := make([]engine.Symbol, len(pieces))
+	for i, p := range pieces {
+		symbols[i] = p.Symbol()
+	}
+	return symbols
+}
+
+// promotedIndices sorted 0-63 indices of promoted pieces
+func promotedIndices(pieces []engine.Piece) []int {
+	var indices []int
+	for _, p := range pieces {
+		if p.Promoted() {
+			indices = append(indices, engine.MailboxToIndex(p.Position()))
+		}
+	}
+	slices.Sort(indices)
+	return indices
+}
+
+func absSymbol(v int) engine.Symbol {
+	if v < 0 {
+		v = -v
+	}
+	return engine.Symbol(v)
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGame_Material(t *testing.T) {
+	b, err := engine.NewBoardFromFEN("r1k5/1P6/8/8/8/8/8/4K3 w - - 0 1")
+	require.NoError(t, err)
+	g := NewGame(b)
+
+	rr := g.Round()
+	assert.Nil(t, rr.Captured)
+	assert.Equal(t, map[engine.Color]int{engine.White: 1, engine.Black: 5}, rr.Material)
+	assert.Equal(t, -4, rr.MaterialDiff)
+
+	rr, err = g.ApplyMoveWithFileRank("b7a8=Q")
+	require.NoError(t, err)
+	assert.Equal(t, map[engine.Color][]engine.Symbol{engine.White: {engine.Rook}}, rr.Captured)
+	assert.Equal(t, map[engine.Color]int{engine.White: 9, engine.Black: 0}, rr.Material)
+	assert.Equal(t, 9, rr.MaterialDiff)
+	assert.Equal(t, map[engine.Color][]int{engine.White: {56}}, rr.Promoted)
+
+	var promotedRound RoundResult
+	for _, m := range []string{"c8c7", "a8b7", "c7b7"} {
+		promotedRound = rr
+		rr, err = g.ApplyMoveWithFileRank(m)
+		require.NoError(t, err, m)
+	}
+	assert.Equal(t, map[engine.Color][]engine.Symbol{
+		engine.White: {engine.Rook},
+		engine.Black: {engine.Queen},
+	}, rr.Captured)
+	assert.Equal(t, []engine.Symbol{engine.Queen}, g.Captured(engine.Black))
+	assert.Equal(t, 0, g.Material(engine.White))
+	assert.Equal(t, 0, g.MaterialDiff())
+	assert.Nil(t, rr.Promoted)
+	assert.Empty(t, g.Promoted(engine.White))
+
+	require.NoError(t, g.UndoLastMove())
+	rr = g.Round()
+	assert.Equal(t, promotedRound.Captured, rr.Captured)
+	assert.Equal(t, promotedRound.Material, rr.Material)
+	assert.Equal(t, promotedRound.MaterialDiff, rr.MaterialDiff)
+	assert.Equal(t, map[engine.Color][]int{engine.White: {49}}, rr.Promoted)
+	assert.Equal(t, []int{49}, g.Promoted(engine.White))
+}
+
+func TestGame_MaterialDarkChess(t *testing.T) {
+	b, err := engine.NewBoardFromFEN("k6K/8/8/8/8/8/1p6/8 b - - 0 1")
+	require.NoError(t, err)
+	b.SetVariant(engine.VariantDarkChess)
+	g := NewGame(b)
+
+	rr, err := g.ApplyMoveWithFileRank("b2b1=Q")
+	require.NoError(t, err)
+	require.False(t, rr.State.IsGameOver())
+
+	black := rr.For(engine.Black)
+	assert.Equal(t, map[engine.Color][]int{engine.Black: {1}}, black.Promoted)
+	assert.Equal(t, -9, black.MaterialDiff)
+
+	white := rr.For(engine.White)
+	assert.Nil(t, white.Promoted, "promotion out of sight")
+	assert.Equal(t, map[engine.Color]int{engine.White: 0, engine.Black: 1}, white.Material)
+	assert.Equal(t, -1, white.MaterialDiff)
+	assert.Equal(t, 9, rr.Material[engine.Black], "full round is not masked")
+}
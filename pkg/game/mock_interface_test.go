@@ -10,6 +10,7 @@
 package game
 
 import (
+	io "io"
 	reflect "reflect"
 
 	engine "github.com/dyxj/chess/pkg/engine"
@@ -54,6 +55,20 @@ func (mr *MockBoardMockRecorder) ActiveColor() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveColor", reflect.TypeOf((*MockBoard)(nil).ActiveColor))
 }
 
+// AddToPocket mocks base method.
+func (m *MockBoard) AddToPocket(c engine.Color, s engine.Symbol) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToPocket", c, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToPocket indicates an expected call of AddToPocket.
+func (mr *MockBoardMockRecorder) AddToPocket(c, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToPocket", reflect.TypeOf((*MockBoard)(nil).AddToPocket), c, s)
+}
+
 // ApplyMove mocks base method.
 func (m_2 *MockBoard) ApplyMove(m engine.Move) error {
 	m_2.ctrl.T.Helper()
@@ -68,6 +83,91 @@ func (mr *MockBoardMockRecorder) ApplyMove(m any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyMove", reflect.TypeOf((*MockBoard)(nil).ApplyMove), m)
 }
 
+// Captured mocks base method.
+func (m *MockBoard) Captured(c engine.Color) []engine.Piece {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Captured", c)
+	ret0, _ := ret[0].([]engine.Piece)
+	return ret0
+}
+
+// Captured indicates an expected call of Captured.
+func (mr *MockBoardMockRecorder) Captured(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Captured", reflect.TypeOf((*MockBoard)(nil).Captured), c)
+}
+
+// Checkers mocks base method.
+func (m *MockBoard) Checkers(c engine.Color) []int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkers", c)
+	ret0, _ := ret[0].([]int)
+	return ret0
+}
+
+// Checkers indicates an expected call of Checkers.
+func (mr *MockBoardMockRecorder) Checkers(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkers", reflect.TypeOf((*MockBoard)(nil).Checkers), c)
+}
+
+// ExchangeGain mocks base method.
+func (m *MockBoard) ExchangeGain(pos int, attacker engine.Color) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExchangeGain", pos, attacker)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// ExchangeGain indicates an expected call of ExchangeGain.
+func (mr *MockBoardMockRecorder) ExchangeGain(pos, attacker any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeGain", reflect.TypeOf((*MockBoard)(nil).ExchangeGain), pos, attacker)
+}
+
+// ExplainIllegalMove mocks base method.
+func (m_2 *MockBoard) ExplainIllegalMove(m engine.Move) (engine.IllegalMove, bool) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "ExplainIllegalMove", m)
+	ret0, _ := ret[0].(engine.IllegalMove)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// ExplainIllegalMove indicates an expected call of ExplainIllegalMove.
+func (mr *MockBoardMockRecorder) ExplainIllegalMove(m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainIllegalMove", reflect.TypeOf((*MockBoard)(nil).ExplainIllegalMove), m)
+}
+
+// GenerateLegalDropMoves mocks base method.
+func (m *MockBoard) GenerateLegalDropMoves(c engine.Color) []engine.Move {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateLegalDropMoves", c)
+	ret0, _ := ret[0].([]engine.Move)
+	return ret0
+}
+
+// GenerateLegalDropMoves indicates an expected call of GenerateLegalDropMoves.
+func (mr *MockBoardMockRecorder) GenerateLegalDropMoves(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateLegalDropMoves", reflect.TypeOf((*MockBoard)(nil).GenerateLegalDropMoves), c)
+}
+
+// GenerateLegalMoves mocks base method.
+func (m *MockBoard) GenerateLegalMoves(c engine.Color) []engine.Move {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateLegalMoves", c)
+	ret0, _ := ret[0].([]engine.Move)
+	return ret0
+}
+
+// GenerateLegalMoves indicates an expected call of GenerateLegalMoves.
+func (mr *MockBoardMockRecorder) GenerateLegalMoves(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateLegalMoves", reflect.TypeOf((*MockBoard)(nil).GenerateLegalMoves), c)
+}
+
 // GeneratePieceLegalMoves mocks base method.
 func (m *MockBoard) GeneratePieceLegalMoves(p engine.Piece) ([]engine.Move, error) {
 	m.ctrl.T.Helper()
@@ -97,6 +197,20 @@ func (mr *MockBoardMockRecorder) GridRaw() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GridRaw", reflect.TypeOf((*MockBoard)(nil).GridRaw))
 }
 
+// Hanging mocks base method.
+func (m *MockBoard) Hanging(c engine.Color) []engine.Piece {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hanging", c)
+	ret0, _ := ret[0].([]engine.Piece)
+	return ret0
+}
+
+// Hanging indicates an expected call of Hanging.
+func (mr *MockBoardMockRecorder) Hanging(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hanging", reflect.TypeOf((*MockBoard)(nil).Hanging), c)
+}
+
 // HasLegalMoves mocks base method.
 func (m *MockBoard) HasLegalMoves(c engine.Color) bool {
 	m.ctrl.T.Helper()
@@ -111,6 +225,20 @@ func (mr *MockBoardMockRecorder) HasLegalMoves(c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasLegalMoves", reflect.TypeOf((*MockBoard)(nil).HasLegalMoves), c)
 }
 
+// History mocks base method.
+func (m *MockBoard) History() []engine.Move {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History")
+	ret0, _ := ret[0].([]engine.Move)
+	return ret0
+}
+
+// History indicates an expected call of History.
+func (mr *MockBoardMockRecorder) History() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockBoard)(nil).History))
+}
+
 // Is100MoveDraw mocks base method.
 func (m *MockBoard) Is100MoveDraw() bool {
 	m.ctrl.T.Helper()
@@ -153,6 +281,35 @@ func (mr *MockBoardMockRecorder) IsCheck(c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCheck", reflect.TypeOf((*MockBoard)(nil).IsCheck), c)
 }
 
+// IsInsufficientMaterial mocks base method.
+func (m *MockBoard) IsInsufficientMaterial(c engine.Color) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsInsufficientMaterial", c)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsInsufficientMaterial indicates an expected call of IsInsufficientMaterial.
+func (mr *MockBoardMockRecorder) IsInsufficientMaterial(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsInsufficientMaterial", reflect.TypeOf((*MockBoard)(nil).IsInsufficientMaterial), c)
+}
+
+// LastCaptured mocks base method.
+func (m *MockBoard) LastCaptured() (engine.Piece, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastCaptured")
+	ret0, _ := ret[0].(engine.Piece)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// LastCaptured indicates an expected call of LastCaptured.
+func (mr *MockBoardMockRecorder) LastCaptured() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastCaptured", reflect.TypeOf((*MockBoard)(nil).LastCaptured))
+}
+
 // LastMove mocks base method.
 func (m *MockBoard) LastMove() (engine.Move, bool) {
 	m.ctrl.T.Helper()
@@ -168,6 +325,20 @@ func (mr *MockBoardMockRecorder) LastMove() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastMove", reflect.TypeOf((*MockBoard)(nil).LastMove))
 }
 
+// Load mocks base method.
+func (m *MockBoard) Load(r io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load", r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Load indicates an expected call of Load.
+func (mr *MockBoardMockRecorder) Load(r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockBoard)(nil).Load), r)
+}
+
 // MoveCount mocks base method.
 func (m *MockBoard) MoveCount() int {
 	m.ctrl.T.Helper()
@@ -211,6 +382,48 @@ func (mr *MockBoardMockRecorder) Pieces(c any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pieces", reflect.TypeOf((*MockBoard)(nil).Pieces), c)
 }
 
+// Pocket mocks base method.
+func (m *MockBoard) Pocket(c engine.Color) []engine.Symbol {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pocket", c)
+	ret0, _ := ret[0].([]engine.Symbol)
+	return ret0
+}
+
+// Pocket indicates an expected call of Pocket.
+func (mr *MockBoardMockRecorder) Pocket(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pocket", reflect.TypeOf((*MockBoard)(nil).Pocket), c)
+}
+
+// Save mocks base method.
+func (m *MockBoard) Save(w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockBoardMockRecorder) Save(w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockBoard)(nil).Save), w)
+}
+
+// StartFEN mocks base method.
+func (m *MockBoard) StartFEN() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartFEN")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// StartFEN indicates an expected call of StartFEN.
+func (mr *MockBoardMockRecorder) StartFEN() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartFEN", reflect.TypeOf((*MockBoard)(nil).StartFEN))
+}
+
 // Symbol mocks base method.
 func (m *MockBoard) Symbol(pos int) engine.Symbol {
 	m.ctrl.T.Helper()
@@ -238,3 +451,46 @@ func (mr *MockBoardMockRecorder) UndoLastMove() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndoLastMove", reflect.TypeOf((*MockBoard)(nil).UndoLastMove))
 }
+
+// Variant mocks base method.
+func (m *MockBoard) Variant() engine.Variant {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Variant")
+	ret0, _ := ret[0].(engine.Variant)
+	return ret0
+}
+
+// Variant indicates an expected call of Variant.
+func (mr *MockBoardMockRecorder) Variant() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Variant", reflect.TypeOf((*MockBoard)(nil).Variant))
+}
+
+// VariantWinner mocks base method.
+func (m *MockBoard) VariantWinner() (engine.Color, engine.VariantWin) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VariantWinner")
+	ret0, _ := ret[0].(engine.Color)
+	ret1, _ := ret[1].(engine.VariantWin)
+	return ret0, ret1
+}
+
+// VariantWinner indicates an expected call of VariantWinner.
+func (mr *MockBoardMockRecorder) VariantWinner() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VariantWinner", reflect.TypeOf((*MockBoard)(nil).VariantWinner))
+}
+
+// Visibility mocks base method.
+func (m *MockBoard) Visibility(c engine.Color) [64]bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Visibility", c)
+	ret0, _ := ret[0].([64]bool)
+	return ret0
+}
+
+// Visibility indicates an expected call of Visibility.
+func (mr *MockBoardMockRecorder) Visibility(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Visibility", reflect.TypeOf((*MockBoard)(nil).Visibility), c)
+}
@@ -16,11 +16,13 @@ import (
 // (  8) (  9) ( 10) ( 11) ( 12) ( 13) ( 14) ( 15)
 // (  0) (  1) (  2) (  3) (  4) (  5) (  6) (  7)
 type Move struct {
-	Color     engine.Color
-	Symbol    engine.Symbol
-	From      int
-	To        int
-	Promotion engine.Symbol
+	Color     engine.Color  `json:"color"`
+	Symbol    engine.Symbol `json:"symbol"`
+	From      int           `json:"from"`
+	To        int           `json:"to"`
+	Promotion engine.Symbol `json:"promotion,omitempty"`
+	// IsDrop pocket piece Symbol is dropped on To, From is ignored
+	IsDrop bool `json:"isDrop,omitempty"`
 }
 
 func (m Move) mbTo() int {
@@ -44,11 +46,23 @@ type MoveResult struct {
 	Captured    engine.Symbol `json:"captured"`
 	Promotion   engine.Symbol `json:"promotion"`
 	IsEnPassant bool          `json:"isEnPassant"`
+	// IsDrop piece dropped from the pocket, From is -1
+	IsDrop bool `json:"isDrop,omitempty"`
+}
+
+// LegalMove legal destination of a piece, Promotions lists the pieces
+// a pawn may promote to on To, empty if the move is not a promotion.
+type LegalMove struct {
+	To         int             `json:"to"`
+	Promotions []engine.Symbol `json:"promotions,omitempty"`
 }
 
 func fromEngine(m engine.Move) MoveResult {
-	from := engine.MailboxToIndex(m.From)
-	if from < 0 {
+	from := -1
+	if !m.IsDrop {
+		from = engine.MailboxToIndex(m.From)
+	}
+	if from < 0 && !m.IsDrop {
 		panic(fmt.Sprintf("invalid 'from' index: %d", from))
 	}
 	to := engine.MailboxToIndex(m.To)
@@ -66,5 +80,6 @@ func fromEngine(m engine.Move) MoveResult {
 		Captured:    m.Captured,
 		Promotion:   m.Promotion,
 		IsEnPassant: m.IsEnPassant,
+		IsDrop:      m.IsDrop,
 	}
 }
@@ -0,0 +1,134 @@
+package game
+
+import (
+	"slices"
+	"sync"
+)
+
+// Listener receives the round after a change of the game. Listeners are called in the
+// goroutine that changed the game after its mutex is released, so they may call back
+// into the game. Rounds of dark chess games include every view, use RoundResult.For.
+type Listener func(r RoundResult)
+
+type event int
+
+const (
+	eventMove event = iota
+	eventStateChange
+	eventUndo
+)
+
+type subscription struct {
+	id int
+	fn Listener
+}
+
+// listeners subscribed per event in subscription order
+type listeners struct {
+	mu     sync.Mutex
+	nextID int
+	subs   map[event][]subscription
+}
+
+func (l *listeners) add(e event, fn Listener) (unsubscribe func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.subs == nil {
+		l.subs = make(map[event][]subscription, 3)
+	}
+	l.nextID++
+	id := l.nextID
+	l.subs[e] = append(l.subs[e], subscription{id: id, fn: fn})
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.subs[e] = slices.DeleteFunc(l.subs[e], func(s subscription) bool {
+			return s.id == id
+		})
+	}
+}
+
+func (l *listeners) has(e event) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.subs[e]) > 0
+}
+
+// notify calls the listeners of e, listeners unsubscribed meanwhile may still be called once.
+func (l *listeners) notify(e event, r RoundResult) {
+	l.mu.Lock()
+	subs := slices.Clone(l.subs[e])
+	l.mu.Unlock()
+
+	for _, s := range subs {
+		s.fn(r)
+	}
+}
+
+// changes made while the game mutex is held, reported once it is released
+type changes struct {
+	move bool
+	undo bool
+	// round result of the move, avoids building the round again
+	round *RoundResult
+}
+
+// OnMove calls fn after every move, including drops.
+func (g *Game) OnMove(fn Listener) (unsubscribe func()) {
+	return g.listeners.add(eventMove, fn)
+}
+
+// OnStateChange calls fn after the state of the game changes, e.g. checkmate,
+// resignation, draw or timeout. Called after the OnMove listeners if a move ended the game.
+func (g *Game) OnStateChange(fn Listener) (unsubscribe func()) {
+	return g.listeners.add(eventStateChange, fn)
+}
+
+// OnUndo calls fn after a move is taken back.
+func (g *Game) OnUndo(fn Listener) (unsubscribe func()) {
+	return g.listeners.add(eventUndo, fn)
+}
+
+// unlockAndNotify releases g.mu locked by the caller and notifies the listeners of the
+// changes made since. prev is the state when the lock was acquired, use as:
+//
+//	g.mu.Lock()
+//	defer g.unlockAndNotify(g.state)
+func (g *Game) unlockAndNotify(prev State) {
+	c := g.changes
+	g.changes = changes{}
+
+	var events []event
+	if c.move {
+		events = append(events, eventMove)
+	}
+	if c.undo {
+		events = append(events, eventUndo)
+	}
+	if g.state != prev {
+		events = append(events, eventStateChange)
+	}
+	events = slices.DeleteFunc(events, func(e event) bool {
+		return !g.listeners.has(e)
+	})
+	if len(events) == 0 {
+		g.mu.Unlock()
+		return
+	}
+
+	var r RoundResult
+	if c.round != nil {
+		r = *c.round
+	} else {
+		r = g.round()
+	}
+	g.mu.Unlock()
+
+	for _, e := range events {
+		g.listeners.notify(e, r)
+	}
+}
@@ -0,0 +1,145 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder records the events received by the listeners of a game in order
+type recorder struct {
+	events []string
+	rounds []RoundResult
+}
+
+func (rec *recorder) listen(name string) Listener {
+	return func(r RoundResult) {
+		rec.events = append(rec.events, name)
+		rec.rounds = append(rec.rounds, r)
+	}
+}
+
+func subscribeAll(g *Game, rec *recorder) {
+	g.OnMove(rec.listen("move"))
+	g.OnStateChange(rec.listen("state"))
+	g.OnUndo(rec.listen("undo"))
+}
+
+func TestGame_Listeners(t *testing.T) {
+	g := NewGame(engine.NewBoard())
+	rec := &recorder{}
+	subscribeAll(g, rec)
+
+	rr, err := g.ApplyMoveWithFileRank("g2g4")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"move"}, rec.events)
+	assert.Equal(t, rr, rec.rounds[0])
+
+	_, err = g.ApplyMoveWithFileRank("g4g6")
+	require.Error(t, err)
+	assert.Len(t, rec.events, 1, "rejected moves are not notified")
+
+	require.NoError(t, g.UndoLastMove())
+	assert.Equal(t, []string{"move", "undo"}, rec.events)
+	assert.Equal(t, 0, rec.rounds[1].Count)
+	assert.Nil(t, rec.rounds[1].MoveResult)
+
+	for _, m := range []string{"g2g4", "e7e6", "f2f3", "d8h4"} {
+		_, err = g.ApplyMoveWithFileRank(m)
+		require.NoError(t, err, m)
+	}
+	assert.Equal(t, []string{"move", "undo", "move", "move", "move", "move", "state"}, rec.events)
+	assert.Equal(t, StateCheckmate, rec.rounds[6].State)
+	assert.Equal(t, rec.rounds[5], rec.rounds[6])
+}
+
+func TestGame_ListenersStateChange(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(g *Game) error
+		state  State
+	}{
+		{
+			name:   "resign",
+			change: func(g *Game) error { return g.Resign(engine.White) },
+			state:  StateWhiteResign,
+		},
+		{
+			name:   "agree draw",
+			change: func(g *Game) error { return g.AgreeDraw() },
+			state:  StateDraw,
+		},
+		{
+			name:   "forfeit",
+			change: func(g *Game) error { return g.Forfeit(engine.Black) },
+			state:  StateTimeout,
+		},
+		{
+			name:   "abort",
+			change: func(g *Game) error { return g.Abort() },
+			state:  StateAborted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(engine.NewBoard())
+			rec := &recorder{}
+			subscribeAll(g, rec)
+
+			require.NoError(t, tt.change(g))
+			assert.Equal(t, []string{"state"}, rec.events)
+			assert.Equal(t, tt.state, rec.rounds[0].State)
+
+			assert.Error(t, tt.change(g))
+			assert.Len(t, rec.events, 1, "game already over")
+		})
+	}
+}
+
+func TestGame_ListenersTimeout(t *testing.T) {
+	g, ft := newTimedGame(t, SuddenDeath(time.Minute), engine.StartFEN)
+	rec := &recorder{}
+	subscribeAll(g, rec)
+
+	ft.advance(time.Minute)
+	_, err := g.ApplyMoveWithFileRank("e2e4")
+	require.ErrorIs(t, err, ErrTimeout)
+	assert.Equal(t, []string{"state"}, rec.events)
+	assert.Equal(t, StateTimeout, rec.rounds[0].State)
+	assert.Equal(t, 0, rec.rounds[0].Count)
+}
+
+func TestGame_ListenersUnsubscribe(t *testing.T) {
+	g := NewGame(engine.NewBoard())
+
+	var calls []int
+	unsubscribe1 := g.OnMove(func(RoundResult) { calls = append(calls, 1) })
+	g.OnMove(func(RoundResult) { calls = append(calls, 2) })
+
+	_, err := g.ApplyMoveWithFileRank("e2e4")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, calls)
+
+	unsubscribe1()
+	unsubscribe1()
+	_, err = g.ApplyMoveWithFileRank("e7e5")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 2}, calls)
+}
+
+func TestGame_ListenersCallBack(t *testing.T) {
+	g := NewGame(engine.NewBoard())
+
+	var counts []int
+	g.OnMove(func(RoundResult) {
+		// mutex is released before listeners are called
+		counts = append(counts, g.Round().Count)
+	})
+
+	_, err := g.ApplyMoveWithFileRank("e2e4")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, counts)
+}
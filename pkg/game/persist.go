@@ -0,0 +1,171 @@
+package game
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/versionx"
+)
+
+// GameFormatVersion version of the game document written by Save.
+const GameFormatVersion = 1
+
+// gameMigrations upgrade older game documents, see versionx.Upgrade.
+// Bump GameFormatVersion and append a migration whenever gameDocument changes.
+var gameMigrations = []versionx.Migration{}
+
+// gameDocument version 1 of the game format, durations are in nanoseconds.
+type gameDocument struct {
+	Version int `json:"version"`
+	// Board document written by Board.Save
+	Board       json.RawMessage `json:"board"`
+	State       State           `json:"state"`
+	Winner      engine.Color    `json:"winner,omitempty"`
+	Rules       Rules           `json:"rules"`
+	CreatedTime time.Time       `json:"createdTime"`
+	// Clock omitted for untimed games
+	Clock *clockDocument `json:"clock,omitempty"`
+}
+
+type clockDocument struct {
+	Remaining  map[engine.Color]time.Duration `json:"remaining"`
+	Stage      map[engine.Color]int           `json:"stage"`
+	StageMoves map[engine.Color]int           `json:"stageMoves"`
+	MoveTimes  []time.Duration                `json:"moveTimes"`
+}
+
+// Save serializes the game as a versioned JSON document, see GameFormatVersion.
+// The board is saved with Board.Save which must write JSON.
+// A running clock is saved as stopped with the time remaining when Save was called.
+func (g *Game) Save(w io.Writer) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var board bytes.Buffer
+	if err := g.b.Save(&board); err != nil {
+		return fmt.Errorf("failed to save board: %w", err)
+	}
+	if !json.Valid(board.Bytes()) {
+		return fmt.Errorf("failed to save board: %w", ErrInvalidBoard)
+	}
+
+	d := gameDocument{
+		Version:     GameFormatVersion,
+		Board:       board.Bytes(),
+		State:       g.state,
+		Winner:      g.winner,
+		Rules:       g.rules,
+		CreatedTime: g.CreatedTime,
+	}
+	if g.clock != nil {
+		now := g.clock.ts.Now()
+		d.Clock = &clockDocument{
+			Remaining: map[engine.Color]time.Duration{
+				engine.White: g.clock.remainingAt(engine.White, now),
+				engine.Black: g.clock.remainingAt(engine.Black, now),
+			},
+			Stage:      maps.Clone(g.clock.stage),
+			StageMoves: maps.Clone(g.clock.stageMoves),
+			MoveTimes:  slices.Clone(g.clock.history),
+		}
+	}
+	return json.NewEncoder(w).Encode(d)
+}
+
+// Load deserializes a game saved with Save, older document versions are migrated
+// and legacy gob snapshots are still read. The board is loaded with Board.Load into
+// board if provided, otherwise into a new engine board.
+// The clock is stopped, restart it with StartClock.
+func Load(r io.Reader, board ...Board) (*Game, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	d, err := decodeGameDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode game: %w", err)
+	}
+
+	var b Board = engine.NewBoard()
+	if len(board) > 0 {
+		b = board[0]
+	}
+	if err := b.Load(bytes.NewReader(d.Board)); err != nil {
+		return nil, fmt.Errorf("failed to load board: %w", err)
+	}
+
+	g := NewGame(b, d.Rules)
+	g.state = d.State
+	g.winner = d.Winner
+	g.CreatedTime = d.CreatedTime
+	if g.clock != nil && d.Clock != nil {
+		maps.Copy(g.clock.remaining, d.Clock.Remaining)
+		maps.Copy(g.clock.stage, d.Clock.Stage)
+		maps.Copy(g.clock.stageMoves, d.Clock.StageMoves)
+		g.clock.history = d.Clock.MoveTimes
+	}
+	return g, nil
+}
+
+func decodeGameDocument(data []byte) (gameDocument, error) {
+	if !versionx.IsDocument(data) {
+		return decodeLegacyGame(data)
+	}
+
+	data, err := versionx.Upgrade(data, GameFormatVersion, gameMigrations)
+	if err != nil {
+		return gameDocument{}, err
+	}
+	var d gameDocument
+	err = json.Unmarshal(data, &d)
+	return d, err
+}
+
+// decodeLegacyGame gob snapshot of earlier releases, the board is a gob snapshot as well
+func decodeLegacyGame(data []byte) (gameDocument, error) {
+	var d legacyGameData
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&d); err != nil {
+		return gameDocument{}, err
+	}
+	doc := gameDocument{
+		Version:     GameFormatVersion,
+		Board:       d.Board,
+		State:       d.State,
+		Winner:      d.Winner,
+		Rules:       d.Rules,
+		CreatedTime: d.CreatedTime,
+	}
+	if d.Clock != nil {
+		doc.Clock = &clockDocument{
+			Remaining:  d.Clock.Remaining,
+			Stage:      d.Clock.Stage,
+			StageMoves: d.Clock.StageMoves,
+			MoveTimes:  d.Clock.History,
+		}
+	}
+	return doc, nil
+}
+
+// legacyGameData gob layout of earlier releases
+type legacyGameData struct {
+	Board       []byte
+	State       State
+	Winner      engine.Color
+	Rules       Rules
+	CreatedTime time.Time
+	Clock       *legacyClockData
+}
+
+type legacyClockData struct {
+	Remaining  map[engine.Color]time.Duration
+	Stage      map[engine.Color]int
+	StageMoves map[engine.Color]int
+	History    []time.Duration
+}
@@ -0,0 +1,203 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/versionx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
+// newPersistTestGame timed crazyhouse game resigned by white, saved in the golden files
+func newPersistTestGame(t *testing.T) *Game {
+	t.Helper()
+	ts := newFakeTime()
+	g := NewGame(engine.NewVariantBoard(engine.VariantCrazyhouse), Rules{
+		Draw:             DrawAutomatic,
+		DrawOfferMinMove: 20,
+		TimeControl:      Fischer(3*time.Minute, 2*time.Second),
+	})
+	g.SetTimeSource(ts)
+	g.CreatedTime = time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	g.StartClock()
+	for _, m := range []string{"e2e4", "d7d5", "e4d5"} {
+		ts.advance(3 * time.Second)
+		_, err := g.ApplyMoveWithFileRank(m)
+		require.NoError(t, err, m)
+	}
+	ts.advance(time.Second)
+	require.NoError(t, g.Resign(engine.White))
+	return g
+}
+
+func TestGame_SaveLoad(t *testing.T) {
+	tt := []struct {
+		name  string
+		build func(t *testing.T) *Game
+	}{
+		{
+			name: "resigned",
+			build: func(t *testing.T) *Game {
+				g := NewGame(engine.NewBoard(), Rules{Draw: DrawAutomatic, NoTakebacks: true, DrawOfferMinMove: 30})
+				_, err := g.ApplyMoveWithFileRank("e2e4")
+				require.NoError(t, err)
+				require.NoError(t, g.Resign(engine.White))
+				return g
+			},
+		},
+		{
+			name: "in progress crazyhouse",
+			build: func(t *testing.T) *Game {
+				g := NewGame(engine.NewVariantBoard(engine.VariantCrazyhouse))
+				for _, m := range []string{"e2e4", "d7d5", "e4d5"} {
+					_, err := g.ApplyMoveWithFileRank(m)
+					require.NoError(t, err)
+				}
+				return g
+			},
+		},
+		{
+			name: "drawn without winner",
+			build: func(t *testing.T) *Game {
+				g := NewGame(engine.NewBoard())
+				require.NoError(t, g.AgreeDraw())
+				return g
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			g := tc.build(t)
+			g.CreatedTime = time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+
+			var buf bytes.Buffer
+			require.NoError(t, g.Save(&buf))
+			loaded, err := Load(&buf)
+			require.NoError(t, err)
+
+			assert.Equal(t, g.State(), loaded.State())
+			assert.Equal(t, g.Winner(), loaded.Winner())
+			assert.Equal(t, g.Rules(), loaded.Rules())
+			assert.Equal(t, g.Variant(), loaded.Variant())
+			assert.True(t, g.CreatedTime.Equal(loaded.CreatedTime))
+			assert.Equal(t, g.Round(), loaded.Round())
+		})
+	}
+}
+
+func TestGame_SaveLoadClock(t *testing.T) {
+	ts := newFakeTime()
+	g := NewGame(engine.NewBoard(), Rules{TimeControl: TimeControl{
+		Bonus:  BonusFischer,
+		Stages: []Stage{{Time: time.Minute, Increment: time.Second, Moves: 1}, {Time: time.Minute}},
+	}})
+	g.SetTimeSource(ts)
+	g.StartClock()
+
+	ts.advance(10 * time.Second)
+	_, err := g.ApplyMoveWithFileRank("e2e4")
+	require.NoError(t, err)
+	ts.advance(5 * time.Second)
+
+	var buf bytes.Buffer
+	require.NoError(t, g.Save(&buf))
+	loaded, err := Load(&buf)
+	require.NoError(t, err)
+	loaded.SetTimeSource(ts)
+
+	assert.Equal(t, 111*time.Second, loaded.Remaining(engine.White), "second stage added")
+	assert.Equal(t, 55*time.Second, loaded.Remaining(engine.Black))
+	assert.Equal(t, []time.Duration{10 * time.Second}, loaded.MoveTimes())
+
+	ts.advance(time.Hour)
+	assert.Equal(t, 55*time.Second, loaded.Remaining(engine.Black), "clock stopped until started")
+	loaded.StartClock()
+	ts.advance(5 * time.Second)
+	assert.Equal(t, 50*time.Second, loaded.Remaining(engine.Black))
+}
+
+func TestGame_LoadThroughBoard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	b := NewMockBoard(ctrl)
+	b.EXPECT().Variant().Return(engine.VariantStandard).AnyTimes()
+	b.EXPECT().Save(gomock.Any()).DoAndReturn(func(w *bytes.Buffer) error {
+		_, err := w.WriteString(`{"version":1}`)
+		return err
+	})
+	g := NewGame(b)
+
+	var buf bytes.Buffer
+	require.NoError(t, g.Save(&buf))
+
+	loadedBoard := NewMockBoard(ctrl)
+	loadedBoard.EXPECT().Variant().Return(engine.VariantStandard)
+	loadedBoard.EXPECT().Load(gomock.Any()).DoAndReturn(func(r *bytes.Reader) error {
+		assert.Equal(t, 13, r.Len())
+		return nil
+	})
+	_, err := Load(&buf, loadedBoard)
+	require.NoError(t, err)
+
+	_, err = Load(bytes.NewReader([]byte("invalid")))
+	assert.Error(t, err)
+
+	b.EXPECT().Save(gomock.Any()).DoAndReturn(func(w *bytes.Buffer) error {
+		_, err := w.WriteString("board")
+		return err
+	})
+	assert.ErrorIs(t, g.Save(&buf), ErrInvalidBoard, "board must be saved as JSON")
+}
+
+// TestGame_SaveGolden document of the current version, run with -update to rewrite it.
+func TestGame_SaveGolden(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, newPersistTestGame(t).Save(&buf))
+
+	var indented bytes.Buffer
+	require.NoError(t, json.Indent(&indented, buf.Bytes(), "", "  "))
+
+	path := filepath.Join("testdata", "game", "timed_resigned.json")
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(path, indented.Bytes(), 0o644))
+	}
+	golden, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), indented.String())
+}
+
+// TestGame_LoadGolden documents of released versions and legacy gob snapshots keep loading.
+func TestGame_LoadGolden(t *testing.T) {
+	expected := newPersistTestGame(t)
+
+	for _, name := range []string{"timed_resigned.json", "timed_resigned.gob"} {
+		t.Run(name, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", "game", name))
+			require.NoError(t, err)
+			defer f.Close()
+
+			g, err := Load(f)
+			require.NoError(t, err)
+			assert.Equal(t, StateWhiteResign, g.State())
+			assert.Equal(t, engine.Black, g.Winner())
+			assert.Equal(t, expected.Rules(), g.Rules())
+			assert.True(t, expected.CreatedTime.Equal(g.CreatedTime))
+			assert.Equal(t, expected.MoveTimes(), g.MoveTimes())
+			assert.Equal(t, expected.Round(), g.Round())
+		})
+	}
+
+	_, err := Load(bytes.NewReader([]byte(`{"version":99}`)))
+	assert.ErrorIs(t, err, versionx.ErrUnsupportedVersion)
+	assert.Len(t, gameMigrations, GameFormatVersion-1, "one migration per version")
+}
@@ -0,0 +1,300 @@
+package game
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+)
+
+// Record format version 1, integers are little endian:
+//
+//	magic     "CGR" followed by the version byte
+//	variant   1 byte
+//	state     1 byte
+//	winner    1 byte signed, 1 white, -1 black or 0 if there is none
+//	created   varint unix milliseconds, 0 for the zero time
+//	start FEN uvarint length and bytes, empty for the standard starting position
+//	plies     uvarint number of moves
+//	moves     index of each move into the sorted legal moves of its position, see sortRecordMoves.
+//	          1 byte, or 2 bytes if the position has more than 256 legal moves
+//	crc       4 bytes CRC-32 (IEEE) of all preceding bytes
+//
+// Rules are not recorded, decoded games use the default Rules.
+const recordVersion = 1
+
+const recordMagic = "CGR"
+
+// maxRecordSize upper bound of a record read by DecodeRecords
+const maxRecordSize = 1 << 20
+
+// MarshalRecord encodes the game as a compact record of its starting position and moves.
+// Moves are replayed on a new engine.Board, bughouse games can not be recorded
+// as their drops depend on the partner board.
+func (g *Game) MarshalRecord() ([]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.variant == engine.VariantBughouse {
+		return nil, fmt.Errorf("%w: bughouse drops depend on the partner board", ErrUnsupportedRecord)
+	}
+
+	startFEN := g.b.StartFEN()
+	b, err := newRecordBoard(startFEN, g.variant)
+	if err != nil {
+		return nil, err
+	}
+
+	history := g.b.History()
+	buf := make([]byte, 0, 32+len(history))
+	buf = append(buf, recordMagic...)
+	buf = append(buf, recordVersion, byte(g.variant), byte(g.state), byte(int8(g.winner)))
+	var created int64
+	if !g.CreatedTime.IsZero() {
+		created = g.CreatedTime.UnixMilli()
+	}
+	buf = binary.AppendVarint(buf, created)
+	if startFEN == engine.StartFEN {
+		startFEN = ""
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(startFEN)))
+	buf = append(buf, startFEN...)
+
+	buf = binary.AppendUvarint(buf, uint64(len(history)))
+	for ply, m := range history {
+		legal := sortRecordMoves(b.GenerateLegalMoves(b.ActiveColor()))
+		i := slices.IndexFunc(legal, func(lm engine.Move) bool { return sameMove(lm, m) })
+		if i < 0 {
+			return nil, fmt.Errorf("%w: ply %d does not replay", ErrIllegalMove, ply+1)
+		}
+		if len(legal) > 256 {
+			buf = binary.LittleEndian.AppendUint16(buf, uint16(i))
+		} else {
+			buf = append(buf, byte(i))
+		}
+		if err := b.ApplyMove(legal[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return binary.LittleEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf)), nil
+}
+
+// UnmarshalRecord decodes a record written by MarshalRecord, every move is replayed
+// on a new engine.Board. The clock of timed games is not recorded.
+func UnmarshalRecord(data []byte) (*Game, error) {
+	if len(data) < len(recordMagic)+1+4 {
+		return nil, fmt.Errorf("%w: too short", ErrInvalidRecord)
+	}
+	body, sum := data[:len(data)-4], data[len(data)-4:]
+	if crc32.ChecksumIEEE(body) != binary.LittleEndian.Uint32(sum) {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidRecord)
+	}
+	if string(body[:len(recordMagic)]) != recordMagic {
+		return nil, fmt.Errorf("%w: not a game record", ErrInvalidRecord)
+	}
+	if v := body[len(recordMagic)]; v != recordVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidRecord, v)
+	}
+
+	rr := &recordReader{data: body[len(recordMagic)+1:]}
+	variant := engine.Variant(rr.byte())
+	state := State(rr.byte())
+	winner := engine.Color(int8(rr.byte()))
+	created := rr.varint()
+	startFEN := string(rr.bytes(rr.uvarint()))
+	plies := rr.uvarint()
+	if rr.err != nil {
+		return nil, rr.err
+	}
+	if !slices.Contains(engine.Variants, variant) || variant == engine.VariantBughouse {
+		return nil, fmt.Errorf("%w: unsupported variant %d", ErrInvalidRecord, variant)
+	}
+	if state.String() == stateUnknownStr || (winner != 0 && !slices.Contains(engine.Colors, winner)) {
+		return nil, fmt.Errorf("%w: invalid result", ErrInvalidRecord)
+	}
+	if startFEN == "" {
+		startFEN = engine.StartFEN
+	}
+
+	b, err := newRecordBoard(startFEN, variant)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+	}
+	for ply := range plies {
+		legal := sortRecordMoves(b.GenerateLegalMoves(b.ActiveColor()))
+		var i int
+		if len(legal) > 256 {
+			i = int(rr.uint16())
+		} else {
+			i = int(rr.byte())
+		}
+		if rr.err != nil {
+			return nil, rr.err
+		}
+		if i >= len(legal) {
+			return nil, fmt.Errorf("%w: ply %d has no legal move %d", ErrInvalidRecord, ply+1, i)
+		}
+		if err := b.ApplyMove(legal[i]); err != nil {
+			return nil, err
+		}
+	}
+	if len(rr.data) > 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrInvalidRecord, len(rr.data))
+	}
+
+	g := NewGame(b)
+	g.state = state
+	g.winner = winner
+	g.CreatedTime = time.Time{}
+	if created != 0 {
+		g.CreatedTime = time.UnixMilli(created).UTC()
+	}
+	return g, nil
+}
+
+// EncodeRecords writes games as uvarint length prefixed records, see MarshalRecord.
+func EncodeRecords(w io.Writer, games ...*Game) error {
+	bw := bufio.NewWriter(w)
+	for i, g := range games {
+		record, err := g.MarshalRecord()
+		if err != nil {
+			return fmt.Errorf("failed to encode game %d: %w", i, err)
+		}
+		if _, err := bw.Write(binary.AppendUvarint(nil, uint64(len(record)))); err != nil {
+			return err
+		}
+		if _, err := bw.Write(record); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// DecodeRecords reads records written by EncodeRecords until r is exhausted.
+func DecodeRecords(r io.Reader) ([]*Game, error) {
+	br := bufio.NewReader(r)
+	var games []*Game
+	for {
+		size, err := binary.ReadUvarint(br)
+		if errors.Is(err, io.EOF) {
+			return games, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+		}
+		if size > maxRecordSize {
+			return nil, fmt.Errorf("%w: record of %d bytes", ErrInvalidRecord, size)
+		}
+
+		record := make([]byte, size)
+		if _, err := io.ReadFull(br, record); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+		}
+		g, err := UnmarshalRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode game %d: %w", len(games), err)
+		}
+		games = append(games, g)
+	}
+}
+
+func newRecordBoard(fen string, variant engine.Variant) (*engine.Board, error) {
+	b, err := engine.NewBoardFromFEN(fen)
+	if err != nil {
+		return nil, err
+	}
+	b.SetVariant(variant)
+	return b, nil
+}
+
+// sortRecordMoves sorts moves independently of the board layout:
+// board moves by from, to (0-63) and promotion, followed by drops by symbol and to.
+func sortRecordMoves(moves []engine.Move) []engine.Move {
+	slices.SortFunc(moves, func(a, b engine.Move) int {
+		if a.IsDrop != b.IsDrop {
+			if a.IsDrop {
+				return 1
+			}
+			return -1
+		}
+		if a.IsDrop {
+			return cmp.Or(
+				cmp.Compare(a.Symbol, b.Symbol),
+				cmp.Compare(engine.MailboxToIndex(a.To), engine.MailboxToIndex(b.To)),
+			)
+		}
+		return cmp.Or(
+			cmp.Compare(engine.MailboxToIndex(a.From), engine.MailboxToIndex(b.From)),
+			cmp.Compare(engine.MailboxToIndex(a.To), engine.MailboxToIndex(b.To)),
+			cmp.Compare(a.Promotion, b.Promotion),
+		)
+	})
+	return moves
+}
+
+// recordReader reads fields of a record, the first error is kept and later reads return zero values
+type recordReader struct {
+	data []byte
+	err  error
+}
+
+func (r *recordReader) bytes(n uint64) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > uint64(len(r.data)) {
+		r.err = fmt.Errorf("%w: unexpected end of record", ErrInvalidRecord)
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *recordReader) byte() byte {
+	if b := r.bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *recordReader) uint16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *recordReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = fmt.Errorf("%w: invalid uvarint", ErrInvalidRecord)
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *recordReader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.err = fmt.Errorf("%w: invalid varint", ErrInvalidRecord)
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
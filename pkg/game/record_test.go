@@ -0,0 +1,141 @@
+package game
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecordTestGame(t *testing.T, b *engine.Board, moves ...string) *Game {
+	t.Helper()
+	g := NewGame(b)
+	g.CreatedTime = time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	for _, m := range moves {
+		_, err := g.ApplyMoveWithFileRank(m)
+		require.NoError(t, err, m)
+	}
+	return g
+}
+
+func TestGame_Record(t *testing.T) {
+	fenBoard, err := engine.NewBoardFromFEN("r1k5/1P6/8/8/8/8/8/4K3 w - - 0 40")
+	require.NoError(t, err)
+
+	tt := []struct {
+		name string
+		game func(t *testing.T) *Game
+	}{
+		{
+			name: "checkmate",
+			game: func(t *testing.T) *Game {
+				return newRecordTestGame(t, engine.NewBoard(), "e2e4", "e7e5", "f1c4", "b8c6", "d1h5", "g8f6", "h5f7")
+			},
+		},
+		{
+			name: "resigned from fen with promotion",
+			game: func(t *testing.T) *Game {
+				g := newRecordTestGame(t, fenBoard, "b7a8=Q", "c8c7")
+				require.NoError(t, g.Resign(engine.Black))
+				return g
+			},
+		},
+		{
+			name: "crazyhouse drop",
+			game: func(t *testing.T) *Game {
+				return newRecordTestGame(t, engine.NewVariantBoard(engine.VariantCrazyhouse), "e2e4", "d7d5", "e4d5", "g8f6", "P@e6")
+			},
+		},
+		{
+			name: "no moves",
+			game: func(t *testing.T) *Game {
+				g := NewGame(engine.NewBoard())
+				g.CreatedTime = time.Time{}
+				return g
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			g := tc.game(t)
+			record, err := g.MarshalRecord()
+			require.NoError(t, err)
+
+			decoded, err := UnmarshalRecord(record)
+			require.NoError(t, err)
+			assert.Equal(t, g.Round(), decoded.Round())
+			assert.Equal(t, g.State(), decoded.State())
+			assert.Equal(t, g.Winner(), decoded.Winner())
+			assert.True(t, g.CreatedTime.Equal(decoded.CreatedTime))
+			assert.Equal(t, g.Variant(), decoded.Variant())
+		})
+	}
+}
+
+func TestGame_RecordSize(t *testing.T) {
+	g := newRecordTestGame(t, engine.NewBoard(), "e2e4", "e7e5", "f1c4", "b8c6", "d1h5", "g8f6", "h5f7")
+	record, err := g.MarshalRecord()
+	require.NoError(t, err)
+
+	// magic and version 4, result 3, created 6, fen 1, plies 1, moves 7, crc 4
+	assert.Len(t, record, 26)
+}
+
+func TestUnmarshalRecord_Invalid(t *testing.T) {
+	g := newRecordTestGame(t, engine.NewBoard(), "e2e4", "e7e5")
+	record, err := g.MarshalRecord()
+	require.NoError(t, err)
+
+	corrupted := bytes.Clone(record)
+	corrupted[len(corrupted)-6] ^= 0xff
+	_, err = UnmarshalRecord(corrupted)
+	assert.ErrorIs(t, err, ErrInvalidRecord, "checksum")
+
+	_, err = UnmarshalRecord(record[:5])
+	assert.ErrorIs(t, err, ErrInvalidRecord, "truncated")
+
+	_, err = UnmarshalRecord([]byte("not a record"))
+	assert.ErrorIs(t, err, ErrInvalidRecord)
+
+	_, err = NewGame(engine.NewVariantBoard(engine.VariantBughouse)).MarshalRecord()
+	assert.ErrorIs(t, err, ErrUnsupportedRecord)
+}
+
+func TestRecords(t *testing.T) {
+	games := []*Game{
+		newRecordTestGame(t, engine.NewBoard(), "d2d4", "d7d5", "c2c4"),
+		newRecordTestGame(t, engine.NewVariantBoard(engine.VariantAtomic), "e2e4", "d7d5", "e4d5"),
+		newRecordTestGame(t, engine.NewBoard()),
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, EncodeRecords(&buf, games...))
+	decoded, err := DecodeRecords(&buf)
+	require.NoError(t, err)
+	require.Len(t, decoded, len(games))
+	for i := range games {
+		assert.Equal(t, games[i].Round(), decoded[i].Round())
+	}
+
+	buf.Reset()
+	require.NoError(t, EncodeRecords(&buf, games...))
+	_, err = DecodeRecords(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+	assert.ErrorIs(t, err, ErrInvalidRecord, "truncated stream")
+}
+
+func TestSortRecordMoves(t *testing.T) {
+	b := engine.NewVariantBoard(engine.VariantCrazyhouse)
+	require.NoError(t, b.AddToPocket(engine.White, engine.Knight))
+
+	moves := sortRecordMoves(b.GenerateLegalMoves(engine.White))
+	require.Len(t, moves, 20+32)
+	assert.Equal(t, "b1", engine.SquareName(moves[0].From), "knight b1 first")
+	assert.Equal(t, "a3", engine.SquareName(moves[0].To))
+	assert.False(t, moves[19].IsDrop)
+	assert.True(t, moves[20].IsDrop, "drops last")
+	assert.Equal(t, "a3", engine.SquareName(moves[20].To))
+}
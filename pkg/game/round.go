@@ -10,4 +10,27 @@ type RoundResult struct {
 	State       State        `json:"state"`
 	Grid        [64]int      `json:"grid"`
 	ActiveColor engine.Color `json:"activeColor"`
+	// Pockets pieces available to drop per color, crazyhouse only
+	Pockets map[engine.Color][]engine.Symbol `json:"pockets,omitempty"`
+	// Captured pieces captured by each color in capture order, omitted if none
+	Captured map[engine.Color][]engine.Symbol `json:"captured,omitempty"`
+	// Material value of the pieces on the board per color, pawn 1, knight and bishop 3,
+	// rook 5 and queen 9
+	Material map[engine.Color]int `json:"material"`
+	// MaterialDiff material of white minus material of black
+	MaterialDiff int `json:"materialDiff"`
+	// Promoted board indices of pieces promoted from pawns per color, omitted if none
+	Promoted map[engine.Color][]int `json:"promoted,omitempty"`
+	// InCheck side to move is in check
+	InCheck bool `json:"inCheck"`
+	// Checkers board indices of the pieces giving check to the side to move, omitted if none
+	Checkers []int `json:"checkers,omitempty"`
+	// LegalMoves legal moves of the side to move by origin index, drops are excluded.
+	// Only included by games with IncludeLegalMoves while in progress
+	LegalMoves map[int][]LegalMove `json:"legalMoves,omitempty"`
+	// Remaining clock time in milliseconds per color, timed games only
+	Remaining map[engine.Color]int64 `json:"remaining,omitempty"`
+	// Views masked round per recipient while a dark chess game is in progress,
+	// use For to pick the view to publish
+	Views map[engine.Color]RoundResult `json:"-"`
 }
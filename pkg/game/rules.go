@@ -0,0 +1,79 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/dyxj/chess/pkg/engine"
+)
+
+// DrawMode how draws by the 100 half move rule and threefold repetition are reached.
+type DrawMode int
+
+const (
+	// DrawClaimable draw has to be claimed with ForceDraw
+	DrawClaimable DrawMode = iota
+	// DrawAutomatic game is drawn as soon as the position allows it
+	DrawAutomatic
+)
+
+const (
+	drawClaimableStr = "claimable"
+	drawAutomaticStr = "automatic"
+	drawUnknownStr   = "unknown"
+)
+
+func (d DrawMode) String() string {
+	switch d {
+	case DrawClaimable:
+		return drawClaimableStr
+	case DrawAutomatic:
+		return drawAutomaticStr
+	default:
+		return drawUnknownStr
+	}
+}
+
+func (d DrawMode) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (d *DrawMode) UnmarshalText(text []byte) error {
+	str := string(text)
+	switch str {
+	case drawClaimableStr:
+		*d = DrawClaimable
+	case drawAutomaticStr:
+		*d = DrawAutomatic
+	default:
+		return fmt.Errorf("unknown draw mode: %s valid draw mode(claimable,automatic)", str)
+	}
+	return nil
+}
+
+// Rules of a game that are not part of the board.
+// The zero value is the default rule set: claimable draws,
+// takebacks allowed, draw offers at any move and untimed.
+type Rules struct {
+	Draw DrawMode `json:"draw"`
+	// NoTakebacks disallows UndoLastMove
+	NoTakebacks bool `json:"noTakebacks"`
+	// DrawOfferMinMove Sofia rule, number of full moves to be played before a draw can be agreed
+	DrawOfferMinMove int `json:"drawOfferMinMove"`
+	// Armageddon a draw counts as a win for Black
+	Armageddon  bool        `json:"armageddon"`
+	TimeControl TimeControl `json:"timeControl"`
+}
+
+// canOfferDraw moveCount is the number of half moves played
+func (r Rules) canOfferDraw(moveCount int) bool {
+	return moveCount >= r.DrawOfferMinMove*2
+}
+
+// drawWinner winner of a drawn game, 0 if the draw stands
+func (r Rules) drawWinner() engine.Color {
+	if r.Armageddon {
+		return engine.Black
+	}
+	return 0
+}
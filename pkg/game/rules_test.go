@@ -0,0 +1,161 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGame_RulesDraw(t *testing.T) {
+	knightShuffle := []string{
+		"g1f3", "g8f6", "f3g1", "f6g8",
+		"g1f3", "g8f6", "f3g1", "f6g8",
+		"g1f3",
+	}
+
+	tt := []struct {
+		name   string
+		rules  Rules
+		fen    string
+		moves  []string
+		state  State
+		winner engine.Color
+		isDraw bool
+	}{
+		{
+			name:  "100 half moves claimable",
+			fen:   "4k3/8/8/8/8/8/8/R3K3 w - - 99 60",
+			moves: []string{"a1a2"},
+			state: StateInProgress,
+		},
+		{
+			name:   "100 half moves automatic",
+			rules:  Rules{Draw: DrawAutomatic},
+			fen:    "4k3/8/8/8/8/8/8/R3K3 w - - 99 60",
+			moves:  []string{"a1a2"},
+			state:  StateDraw,
+			isDraw: true,
+		},
+		{
+			name:   "threefold repetition automatic",
+			rules:  Rules{Draw: DrawAutomatic},
+			fen:    engine.StartFEN,
+			moves:  knightShuffle,
+			state:  StateDraw,
+			isDraw: true,
+		},
+		{
+			name:   "100 half moves automatic armageddon",
+			rules:  Rules{Draw: DrawAutomatic, Armageddon: true},
+			fen:    "4k3/8/8/8/8/8/8/R3K3 w - - 99 60",
+			moves:  []string{"a1a2"},
+			state:  StateDraw,
+			winner: engine.Black,
+		},
+		{
+			name:   "stalemate",
+			fen:    "k7/8/2Q5/8/8/8/8/7K w - - 0 1",
+			moves:  []string{"c6b6"},
+			state:  StateStalemate,
+			isDraw: true,
+		},
+		{
+			name:   "stalemate armageddon",
+			rules:  Rules{Armageddon: true},
+			fen:    "k7/8/2Q5/8/8/8/8/7K w - - 0 1",
+			moves:  []string{"c6b6"},
+			state:  StateStalemate,
+			winner: engine.Black,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := engine.NewBoardFromFEN(tc.fen)
+			require.NoError(t, err)
+			g := NewGame(b, tc.rules)
+			assert.Equal(t, tc.rules, g.Rules())
+
+			var rr RoundResult
+			for _, m := range tc.moves {
+				require.False(t, g.State().IsGameOver(), m)
+				rr, err = g.ApplyMoveWithFileRank(m)
+				require.NoError(t, err, m)
+			}
+
+			assert.Equal(t, tc.state, rr.State)
+			assert.Equal(t, tc.winner, g.Winner())
+			assert.Equal(t, tc.isDraw, g.IsDraw())
+		})
+	}
+}
+
+func TestGame_ForceDrawArmageddon(t *testing.T) {
+	b, err := engine.NewBoardFromFEN("4k3/8/8/8/8/8/8/R3K3 w - - 99 60")
+	require.NoError(t, err)
+	g := NewGame(b, Rules{Armageddon: true})
+
+	assert.ErrorIs(t, g.ForceDraw(), ErrNotEligibleToForceDraw)
+
+	_, err = g.ApplyMoveWithFileRank("a1a2")
+	require.NoError(t, err)
+	require.NoError(t, g.ForceDraw())
+
+	assert.Equal(t, StateDraw, g.State())
+	assert.Equal(t, engine.Black, g.Winner())
+	assert.False(t, g.IsDraw())
+}
+
+func TestGame_Takebacks(t *testing.T) {
+	g := NewGame(engine.NewBoard())
+	assert.ErrorIs(t, g.UndoLastMove(), ErrNoMoveToUndo)
+
+	_, err := g.ApplyMoveWithFileRank("e2e4")
+	require.NoError(t, err)
+	require.NoError(t, g.UndoLastMove())
+	assert.Equal(t, engine.White, g.ActiveColor())
+
+	g = NewGame(engine.NewBoard(), Rules{NoTakebacks: true})
+	_, err = g.ApplyMoveWithFileRank("e2e4")
+	require.NoError(t, err)
+	assert.ErrorIs(t, g.UndoLastMove(), ErrTakebackNotAllowed)
+	assert.Equal(t, engine.Black, g.ActiveColor())
+}
+
+func TestGame_AgreeDrawSofiaRule(t *testing.T) {
+	g := NewGame(engine.NewBoard(), Rules{DrawOfferMinMove: 2})
+	assert.False(t, g.CanOfferDraw())
+	assert.ErrorIs(t, g.AgreeDraw(), ErrDrawOfferTooEarly)
+
+	for _, m := range []string{"e2e4", "e7e5", "g1f3"} {
+		_, err := g.ApplyMoveWithFileRank(m)
+		require.NoError(t, err, m)
+	}
+	assert.ErrorIs(t, g.AgreeDraw(), ErrDrawOfferTooEarly)
+
+	_, err := g.ApplyMoveWithFileRank("b8c6")
+	require.NoError(t, err)
+	assert.True(t, g.CanOfferDraw())
+	require.NoError(t, g.AgreeDraw())
+	assert.Equal(t, StateDraw, g.State())
+	assert.True(t, g.IsDraw())
+
+	assert.False(t, g.CanOfferDraw())
+	assert.ErrorIs(t, g.AgreeDraw(), ErrInvalidMove)
+}
+
+func TestDrawMode_Text(t *testing.T) {
+	for _, d := range []DrawMode{DrawClaimable, DrawAutomatic} {
+		text, err := d.MarshalText()
+		require.NoError(t, err)
+
+		var got DrawMode
+		require.NoError(t, got.UnmarshalText(text))
+		assert.Equal(t, d, got)
+	}
+
+	var d DrawMode
+	assert.Error(t, d.UnmarshalText([]byte("never")))
+}
@@ -1,6 +1,11 @@
 package game
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dyxj/chess/pkg/engine"
+)
 
 type State int
 
@@ -11,18 +16,56 @@ const (
 	StateDraw
 	StateWhiteResign
 	StateBlackResign
+	// StateKingOfTheHill king reached the center, King of the Hill
+	StateKingOfTheHill
+	// StateThreeCheck third check given, Three-check
+	StateThreeCheck
+	// StateNoMovesLeft side to move has no pieces or legal moves and wins, Antichess
+	StateNoMovesLeft
+	// StateKingExploded king exploded by a capture, Atomic
+	StateKingExploded
+	// StateKingCaptured king captured, Dark Chess
+	StateKingCaptured
+	// StateTimeout player ran out of time
+	StateTimeout
+	// StateAborted game was called off before both sides moved, there is no result
+	StateAborted
 )
 
 const (
-	stateInProgressStr  = "in_progress"
-	stateCheckmateStr   = "checkmate"
-	stateStalemateStr   = "stalemate"
-	stateDrawStr        = "draw"
-	stateUnknownStr     = "unknown"
-	stateWhiteResignStr = "white_resign"
-	stateBlackResignStr = "black_resign"
+	stateInProgressStr    = "in_progress"
+	stateCheckmateStr     = "checkmate"
+	stateStalemateStr     = "stalemate"
+	stateDrawStr          = "draw"
+	stateUnknownStr       = "unknown"
+	stateWhiteResignStr   = "white_resign"
+	stateBlackResignStr   = "black_resign"
+	stateKingOfTheHillStr = "king_of_the_hill"
+	stateThreeCheckStr    = "three_check"
+	stateNoMovesLeftStr   = "no_moves_left"
+	stateKingExplodedStr  = "king_exploded"
+	stateKingCapturedStr  = "king_captured"
+	stateTimeoutStr       = "timeout"
+	stateAbortedStr       = "aborted"
 )
 
+// states every known state in declaration order
+var states = []State{
+	StateInProgress,
+	StateCheckmate,
+	StateStalemate,
+	StateDraw,
+	StateWhiteResign,
+	StateBlackResign,
+	StateKingOfTheHill,
+	StateThreeCheck,
+	StateNoMovesLeft,
+	StateKingExploded,
+	StateKingCaptured,
+	StateTimeout,
+	StateAborted,
+}
+
 func (s State) String() string {
 	switch s {
 	case StateInProgress:
@@ -37,6 +80,20 @@ func (s State) String() string {
 		return stateWhiteResignStr
 	case StateBlackResign:
 		return stateBlackResignStr
+	case StateKingOfTheHill:
+		return stateKingOfTheHillStr
+	case StateThreeCheck:
+		return stateThreeCheckStr
+	case StateNoMovesLeft:
+		return stateNoMovesLeftStr
+	case StateKingExploded:
+		return stateKingExplodedStr
+	case StateKingCaptured:
+		return stateKingCapturedStr
+	case StateTimeout:
+		return stateTimeoutStr
+	case StateAborted:
+		return stateAbortedStr
 	default:
 		return stateUnknownStr
 	}
@@ -44,7 +101,14 @@ func (s State) String() string {
 
 func (s State) IsGameOver() bool {
 	return s == StateCheckmate || s == StateStalemate ||
-		s == StateDraw || s == StateWhiteResign || s == StateBlackResign
+		s == StateDraw || s == StateWhiteResign || s == StateBlackResign ||
+		s == StateTimeout || s == StateAborted || s.IsVariantWin()
+}
+
+// IsVariantWin game won by a variant specific win condition
+func (s State) IsVariantWin() bool {
+	return s == StateKingOfTheHill || s == StateThreeCheck ||
+		s == StateNoMovesLeft || s == StateKingExploded || s == StateKingCaptured
 }
 
 func (s State) MarshalText() ([]byte, error) {
@@ -54,29 +118,40 @@ func (s State) MarshalText() ([]byte, error) {
 //goland:noinspection GoMixedReceiverTypes
 func (s *State) UnmarshalText(text []byte) error {
 	str := string(text)
-	switch str {
-	case stateInProgressStr:
-		*s = StateInProgress
-	case stateCheckmateStr:
-		*s = StateCheckmate
-	case stateStalemateStr:
-		*s = StateStalemate
-	case stateDrawStr:
-		*s = StateDraw
-	case stateWhiteResignStr:
-		*s = StateWhiteResign
-	case stateBlackResignStr:
-		*s = StateBlackResign
-	default:
-		return fmt.Errorf("unknown state: %s valid state(in_progress,checkmate,stalemate,draw)", str)
+	names := make([]string, 0, len(states))
+	for _, state := range states {
+		if state.String() == str {
+			*s = state
+			return nil
+		}
+		names = append(names, state.String())
 	}
-	return nil
+	return fmt.Errorf("unknown state: %s valid state(%s)", str, strings.Join(names, ","))
+}
+
+var variantWinStates = map[engine.VariantWin]State{
+	engine.VariantWinKingOfTheHill: StateKingOfTheHill,
+	engine.VariantWinThreeCheck:    StateThreeCheck,
+	engine.VariantWinNoMoves:       StateNoMovesLeft,
+	engine.VariantWinExplosion:     StateKingExploded,
+	engine.VariantWinKingCaptured:  StateKingCaptured,
 }
 
 func (g *Game) calculateGameState() State {
+	if g.variant != engine.VariantStandard {
+		winner, win := g.b.VariantWinner()
+		if state, ok := variantWinStates[win]; ok {
+			g.winner = winner
+			return state
+		}
+	}
+
 	activeColor := g.b.ActiveColor()
 
 	if g.b.HasLegalMoves(activeColor) {
+		if g.rules.Draw == DrawAutomatic && g.canForceDraw() {
+			return g.draw(StateDraw)
+		}
 		return StateInProgress
 	}
 
@@ -85,5 +160,5 @@ func (g *Game) calculateGameState() State {
 		return StateCheckmate
 	}
 
-	return StateStalemate
+	return g.draw(StateStalemate)
 }
@@ -0,0 +1,379 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dyxj/chess/pkg/engine"
+)
+
+// Node of a Tree, the root holds the starting position and has no move.
+// The first child of a node continues its line, other children are variations.
+type Node struct {
+	move     engine.Move
+	san      string
+	ply      int
+	comment  string
+	nags     []int
+	parent   *Node
+	children []*Node
+	// redo child Tree.Redo returns to, the last child stepped back from
+	redo *Node
+}
+
+// Move applied by the node, zero for the root.
+func (n *Node) Move() MoveResult {
+	if n.parent == nil {
+		return MoveResult{}
+	}
+	return fromEngine(n.move)
+}
+
+// SAN of the move, empty for the root.
+func (n *Node) SAN() string {
+	return n.san
+}
+
+// Ply number of moves from the starting position, 0 for the root.
+func (n *Node) Ply() int {
+	return n.ply
+}
+
+func (n *Node) Parent() *Node {
+	return n.parent
+}
+
+// Children first child continues the line, the rest are variations.
+func (n *Node) Children() []*Node {
+	return slices.Clone(n.children)
+}
+
+// Comment after the move, or before the first move for the root.
+func (n *Node) Comment() string {
+	return n.comment
+}
+
+func (n *Node) SetComment(comment string) {
+	n.comment = strings.TrimSpace(comment)
+}
+
+// NAGs numeric annotation glyphs of the move, e.g. 1 for "!" or 4 for "??".
+func (n *Node) NAGs() []int {
+	return slices.Clone(n.nags)
+}
+
+// AddNAG nag must be between 0 and 255, duplicates are ignored.
+func (n *Node) AddNAG(nag int) error {
+	if nag < 0 || nag > 255 {
+		return fmt.Errorf("%w: %d", ErrInvalidNAG, nag)
+	}
+	if !slices.Contains(n.nags, nag) {
+		n.nags = append(n.nags, nag)
+	}
+	return nil
+}
+
+func (n *Node) RemoveNAG(nag int) {
+	n.nags = slices.DeleteFunc(n.nags, func(v int) bool { return v == nag })
+}
+
+// Tree of moves with nested variations for analysis, the board follows the current node.
+// A Tree is not safe for concurrent use.
+type Tree struct {
+	startFEN string
+	variant  engine.Variant
+	root     *Node
+	current  *Node
+	b        *engine.Board
+}
+
+// NewTree creates a tree starting from fen, variant is optional and defaults to VariantStandard.
+func NewTree(fen string, variant ...engine.Variant) (*Tree, error) {
+	var v engine.Variant
+	if len(variant) > 0 {
+		v = variant[0]
+	}
+	t := &Tree{
+		startFEN: fen,
+		variant:  v,
+		root:     &Node{},
+	}
+	b, err := t.newBoard()
+	if err != nil {
+		return nil, err
+	}
+	t.b = b
+	t.current = t.root
+	return t, nil
+}
+
+// Tree of the moves played from the starting position, the current node is the last move.
+// Bughouse games can not be converted as their drops depend on the partner board.
+func (g *Game) Tree() (*Tree, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.variant == engine.VariantBughouse {
+		return nil, fmt.Errorf("%w: bughouse drops depend on the partner board", ErrUnsupportedTree)
+	}
+
+	t, err := NewTree(g.b.StartFEN(), g.variant)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range g.b.History() {
+		t.play(m)
+	}
+	return t, nil
+}
+
+func (t *Tree) Root() *Node {
+	return t.root
+}
+
+func (t *Tree) Current() *Node {
+	return t.current
+}
+
+// StartFEN starting position of the tree
+func (t *Tree) StartFEN() string {
+	return t.startFEN
+}
+
+// FEN position of the current node
+func (t *Tree) FEN() string {
+	return t.b.FEN()
+}
+
+func (t *Tree) GridRaw() [64]int {
+	return t.b.GridRaw()
+}
+
+func (t *Tree) ActiveColor() engine.Color {
+	return t.b.ActiveColor()
+}
+
+// Mainline nodes following the first child from the root, excluding the root.
+func (t *Tree) Mainline() []*Node {
+	var line []*Node
+	for n := t.root; len(n.children) > 0; n = n.children[0] {
+		line = append(line, n.children[0])
+	}
+	return line
+}
+
+// Play plays m from the current node and moves to it.
+// If the move was already played from the current node the existing node is reused,
+// otherwise it is added as the last child, a variation if the node already has children.
+func (t *Tree) Play(m Move) (*Node, error) {
+	if m.Color != t.b.ActiveColor() {
+		return nil, engine.ErrNotActiveColor
+	}
+	for _, lm := range t.b.GenerateLegalMoves(m.Color) {
+		if matchesMove(m, lm) {
+			return t.play(lm), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no legal move matches %+v", ErrIllegalMove, m)
+}
+
+// PlaySAN plays a move in Standard Algebraic Notation, see Play.
+func (t *Tree) PlaySAN(san string) (*Node, error) {
+	m, err := t.b.ParseSAN(san)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIllegalMove, err)
+	}
+	return t.play(m), nil
+}
+
+// Back steps back to the parent of the current node, returns false at the root.
+// The move is kept and can be played again with Redo.
+func (t *Tree) Back() bool {
+	if t.current.parent == nil {
+		return false
+	}
+	t.b.UndoLastMove()
+	t.current.parent.redo = t.current
+	t.current = t.current.parent
+	return true
+}
+
+// Redo steps forward to the child last stepped back from, or the first child.
+// Returns false if the current node has no children.
+func (t *Tree) Redo() bool {
+	next := t.current.redo
+	if next == nil {
+		if len(t.current.children) == 0 {
+			return false
+		}
+		next = t.current.children[0]
+	}
+	if err := t.b.ApplyMove(next.move); err != nil {
+		return false
+	}
+	t.current = next
+	return true
+}
+
+// GoTo moves to n, the board is rebuilt from the starting position.
+func (t *Tree) GoTo(n *Node) error {
+	if !t.contains(n) {
+		return ErrNodeNotInTree
+	}
+	b, err := t.boardAt(n)
+	if err != nil {
+		return err
+	}
+	t.b = b
+	t.current = n
+	return nil
+}
+
+// Promote makes variation n the first child of its parent, the line it continues.
+func (t *Tree) Promote(n *Node) error {
+	if !t.contains(n) {
+		return ErrNodeNotInTree
+	}
+	if n.parent == nil {
+		return ErrRootNode
+	}
+	siblings := n.parent.children
+	i := slices.Index(siblings, n)
+	copy(siblings[1:i+1], siblings[:i])
+	siblings[0] = n
+	return nil
+}
+
+// Delete removes n and its descendants, the tree moves to the parent of n
+// if the current node is removed.
+func (t *Tree) Delete(n *Node) error {
+	if !t.contains(n) {
+		return ErrNodeNotInTree
+	}
+	if n.parent == nil {
+		return ErrRootNode
+	}
+
+	parent := n.parent
+	removesCurrent := isAncestor(n, t.current)
+	if removesCurrent {
+		if err := t.GoTo(parent); err != nil {
+			return err
+		}
+	}
+
+	parent.children = slices.DeleteFunc(parent.children, func(c *Node) bool { return c == n })
+	if parent.redo == n {
+		parent.redo = nil
+	}
+	n.parent = nil
+	return nil
+}
+
+func (t *Tree) play(m engine.Move) *Node {
+	parent := t.current
+	for _, c := range parent.children {
+		if sameMove(c.move, m) {
+			_ = t.b.ApplyMove(c.move)
+			t.current = c
+			return c
+		}
+	}
+
+	n := &Node{
+		move:   m,
+		san:    t.b.SAN(m),
+		ply:    parent.ply + 1,
+		parent: parent,
+	}
+	_ = t.b.ApplyMove(m)
+	parent.children = append(parent.children, n)
+	t.current = n
+	return n
+}
+
+func (t *Tree) newBoard() (*engine.Board, error) {
+	b, err := engine.NewBoardFromFEN(t.startFEN)
+	if err != nil {
+		return nil, err
+	}
+	b.SetVariant(t.variant)
+	return b, nil
+}
+
+// boardAt position of n replayed from the starting position
+func (t *Tree) boardAt(n *Node) (*engine.Board, error) {
+	b, err := t.newBoard()
+	if err != nil {
+		return nil, err
+	}
+	var path []*Node
+	for c := n; c.parent != nil; c = c.parent {
+		path = append(path, c)
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		if err := b.ApplyMove(path[i].move); err != nil {
+			return nil, fmt.Errorf("failed to replay ply %d: %w", path[i].ply, err)
+		}
+	}
+	return b, nil
+}
+
+// contains n is attached to the root of t, deleted nodes are detached
+func (t *Tree) contains(n *Node) bool {
+	return n != nil && isAncestor(t.root, n)
+}
+
+// moveNumber full move number and color of the move of ply
+func (t *Tree) moveNumber(ply int) (int, engine.Color) {
+	fields := strings.Fields(t.startFEN)
+	fullMove, startColor := 1, engine.White
+	if len(fields) > 5 {
+		if v, err := strconv.Atoi(fields[5]); err == nil && v > 0 {
+			fullMove = v
+		}
+	}
+	if len(fields) > 1 && fields[1] == "b" {
+		startColor = engine.Black
+	}
+
+	halfMoves := ply - 1
+	if startColor == engine.Black {
+		halfMoves++
+	}
+	if halfMoves%2 == 0 {
+		return fullMove + halfMoves/2, engine.White
+	}
+	return fullMove + halfMoves/2, engine.Black
+}
+
+func isAncestor(ancestor *Node, n *Node) bool {
+	for c := n; c != nil; c = c.parent {
+		if c == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMove m in 0-63 indices is the legal move lm
+func matchesMove(m Move, lm engine.Move) bool {
+	if m.IsDrop {
+		return lm.IsDrop && lm.Symbol == m.Symbol && lm.To == m.mbTo()
+	}
+	return !lm.IsDrop &&
+		lm.Symbol == m.Symbol &&
+		lm.From == m.mbFrom() &&
+		lm.To == m.mbTo() &&
+		lm.Promotion == m.Promotion
+}
+
+func sameMove(a, b engine.Move) bool {
+	return a.IsDrop == b.IsDrop &&
+		a.Symbol == b.Symbol &&
+		a.From == b.From &&
+		a.To == b.To &&
+		a.Promotion == b.Promotion
+}
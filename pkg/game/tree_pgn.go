@@ -0,0 +1,157 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dyxj/chess/pkg/engine"
+)
+
+const (
+	pgnResultWhite   = "1-0"
+	pgnResultBlack   = "0-1"
+	pgnResultDraw    = "1/2-1/2"
+	pgnResultOngoing = "*"
+)
+
+// pgnVariantNames values of the Variant tag, standard games omit the tag
+var pgnVariantNames = map[engine.Variant]string{
+	engine.VariantKingOfTheHill: "King of the Hill",
+	engine.VariantThreeCheck:    "Three-check",
+	engine.VariantAntichess:     "Antichess",
+	engine.VariantAtomic:        "Atomic",
+	engine.VariantCrazyhouse:    "Crazyhouse",
+	engine.VariantDarkChess:     "Dark Chess",
+	engine.VariantBughouse:      "Bughouse",
+}
+
+// PGN exports the tree with the seven tag roster, variations as recursive annotation
+// variations (RAV), comments and NAGs. The result is taken from the end of the mainline.
+// SetUp and FEN tags are added if the tree does not start from the standard position.
+func (t *Tree) PGN() string {
+	result := t.mainlineResult()
+
+	sb := strings.Builder{}
+	writeTag := func(name, value string) {
+		fmt.Fprintf(&sb, "[%s %q]\n", name, value)
+	}
+	writeTag("Event", "?")
+	writeTag("Site", "?")
+	writeTag("Date", "????.??.??")
+	writeTag("Round", "?")
+	writeTag("White", "?")
+	writeTag("Black", "?")
+	writeTag("Result", result)
+	if name, ok := pgnVariantNames[t.variant]; ok {
+		writeTag("Variant", name)
+	}
+	if t.startFEN != engine.StartFEN {
+		writeTag("SetUp", "1")
+		writeTag("FEN", t.startFEN)
+	}
+	sb.WriteByte('\n')
+
+	w := &pgnWriter{t: t}
+	if t.root.comment != "" {
+		w.token(pgnComment(t.root.comment))
+	}
+	w.line(t.root, true)
+	w.token(result)
+
+	sb.WriteString(w.sb.String())
+	sb.WriteByte('\n')
+	return sb.String()
+}
+
+// mainlineResult result of the final position of the mainline, "*" if it is not over.
+func (t *Tree) mainlineResult() string {
+	end := t.root
+	if line := t.Mainline(); len(line) > 0 {
+		end = line[len(line)-1]
+	}
+	b, err := t.boardAt(end)
+	if err != nil {
+		return pgnResultOngoing
+	}
+
+	if winner, win := b.VariantWinner(); win != engine.VariantWinNone {
+		return pgnWinner(winner)
+	}
+	color := b.ActiveColor()
+	if b.HasLegalMoves(color) {
+		return pgnResultOngoing
+	}
+	if b.IsCheck(color) {
+		return pgnWinner(color.Opposite())
+	}
+	return pgnResultDraw
+}
+
+func pgnWinner(c engine.Color) string {
+	if c == engine.White {
+		return pgnResultWhite
+	}
+	return pgnResultBlack
+}
+
+// pgnComment braces can not be nested in PGN comments and are removed
+func pgnComment(comment string) string {
+	comment = strings.NewReplacer("{", "", "}", "").Replace(comment)
+	return "{" + comment + "}"
+}
+
+type pgnWriter struct {
+	t  *Tree
+	sb strings.Builder
+}
+
+// token writes s separated by a space, except after an opening parenthesis
+func (w *pgnWriter) token(s string) {
+	str := w.sb.String()
+	if len(str) > 0 && !strings.HasSuffix(str, "(") {
+		w.sb.WriteByte(' ')
+	}
+	w.sb.WriteString(s)
+}
+
+// line writes the line continuing from n with the variations of each move,
+// numbered tells whether the next black move needs its move number.
+func (w *pgnWriter) line(n *Node, numbered bool) {
+	for len(n.children) > 0 {
+		main := n.children[0]
+		numbered = w.move(main, numbered)
+
+		for _, variation := range n.children[1:] {
+			w.token("(")
+			w.line(variation, w.move(variation, true))
+			w.sb.WriteByte(')')
+			numbered = true
+		}
+
+		n = main
+	}
+}
+
+// move writes the move number if required, SAN, NAGs and comment of n.
+// Returns true if the next move needs its move number.
+func (w *pgnWriter) move(n *Node, numbered bool) bool {
+	number, color := w.t.moveNumber(n.ply)
+	switch {
+	case color == engine.White:
+		w.token(strconv.Itoa(number) + ".")
+	case numbered:
+		w.token(strconv.Itoa(number) + "...")
+	default:
+	}
+
+	w.token(n.san)
+	for _, nag := range n.nags {
+		w.token("$" + strconv.Itoa(nag))
+	}
+	if n.comment != "" {
+		w.token(pgnComment(n.comment))
+		return true
+	}
+	return false
+}
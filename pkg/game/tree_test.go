@@ -0,0 +1,225 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTree(t *testing.T, fen string, sans ...string) *Tree {
+	t.Helper()
+	tree, err := NewTree(fen)
+	require.NoError(t, err)
+	for _, san := range sans {
+		_, err := tree.PlaySAN(san)
+		require.NoError(t, err)
+	}
+	return tree
+}
+
+func mainlineSANs(tree *Tree) []string {
+	var sans []string
+	for _, n := range tree.Mainline() {
+		sans = append(sans, n.SAN())
+	}
+	return sans
+}
+
+func TestTree_Variations(t *testing.T) {
+	tree := newTestTree(t, engine.StartFEN, "e4", "e5", "Nf3")
+	nf3 := tree.Current()
+
+	require.True(t, tree.Back())
+	require.True(t, tree.Back())
+	e4 := tree.Current()
+	assert.Equal(t, 1, e4.Ply())
+
+	c5, err := tree.PlaySAN("c5")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"e4", "e5", "Nf3"}, mainlineSANs(tree))
+	require.Len(t, e4.Children(), 2)
+	assert.Equal(t, c5, e4.Children()[1])
+
+	// playing an existing move reuses the node
+	require.True(t, tree.Back())
+	e5, err := tree.Play(Move{Color: engine.Black, Symbol: engine.Pawn, From: 52, To: 36})
+	require.NoError(t, err)
+	assert.Equal(t, e4.Children()[0], e5)
+	assert.Len(t, e4.Children(), 2)
+
+	require.NoError(t, tree.GoTo(nf3))
+	assert.Equal(t, "rnbqkbnr/pppp1ppp/8/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R b KQkq - 1 2", tree.FEN())
+	assert.Equal(t, engine.Black, tree.ActiveColor())
+
+	require.NoError(t, tree.Promote(c5))
+	assert.Equal(t, []string{"e4", "c5"}, mainlineSANs(tree))
+	assert.Equal(t, nf3, tree.Current(), "promote does not move")
+}
+
+func TestTree_BackAndRedo(t *testing.T) {
+	tree := newTestTree(t, engine.StartFEN, "d4", "d5")
+
+	require.True(t, tree.Back())
+	_, err := tree.PlaySAN("Nf6")
+	require.NoError(t, err)
+	require.True(t, tree.Back())
+	require.True(t, tree.Back())
+	assert.False(t, tree.Back(), "root")
+	assert.Equal(t, engine.StartFEN, tree.FEN())
+
+	require.True(t, tree.Redo())
+	require.True(t, tree.Redo())
+	assert.Equal(t, "Nf6", tree.Current().SAN(), "redo returns to the line stepped back from")
+	assert.False(t, tree.Redo(), "end of line")
+
+	require.NoError(t, tree.GoTo(tree.Root()))
+	require.True(t, tree.Redo())
+	require.True(t, tree.Redo())
+	assert.Equal(t, "Nf6", tree.Current().SAN(), "redo history is kept")
+
+	fresh := newTestTree(t, engine.StartFEN, "d4", "d5")
+	require.NoError(t, fresh.GoTo(fresh.Root()))
+	require.True(t, fresh.Redo())
+	require.True(t, fresh.Redo())
+	assert.Equal(t, "d5", fresh.Current().SAN(), "first child without redo history")
+}
+
+func TestTree_Delete(t *testing.T) {
+	tree := newTestTree(t, engine.StartFEN, "e4", "e5")
+	require.True(t, tree.Back())
+	c5, err := tree.PlaySAN("c5")
+	require.NoError(t, err)
+	nf3, err := tree.PlaySAN("Nf3")
+	require.NoError(t, err)
+
+	require.NoError(t, tree.Delete(c5))
+	assert.Equal(t, "e4", tree.Current().SAN(), "moved out of the deleted line")
+	assert.Equal(t, "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1", tree.FEN())
+	assert.Len(t, tree.Current().Children(), 1)
+
+	assert.ErrorIs(t, tree.GoTo(nf3), ErrNodeNotInTree)
+	assert.ErrorIs(t, tree.Delete(c5), ErrNodeNotInTree)
+	assert.ErrorIs(t, tree.Delete(tree.Root()), ErrRootNode)
+	assert.ErrorIs(t, tree.Promote(tree.Root()), ErrRootNode)
+}
+
+func TestTree_PlayErrors(t *testing.T) {
+	tree := newTestTree(t, engine.StartFEN)
+
+	_, err := tree.Play(Move{Color: engine.Black, Symbol: engine.Pawn, From: 52, To: 36})
+	assert.ErrorIs(t, err, engine.ErrNotActiveColor)
+
+	_, err = tree.Play(Move{Color: engine.White, Symbol: engine.Pawn, From: 12, To: 36})
+	assert.ErrorIs(t, err, ErrIllegalMove)
+
+	_, err = tree.PlaySAN("Ke2")
+	assert.ErrorIs(t, err, ErrIllegalMove)
+
+	assert.Equal(t, tree.Root(), tree.Current())
+	assert.ErrorIs(t, tree.Root().AddNAG(256), ErrInvalidNAG)
+}
+
+func TestTree_PGN(t *testing.T) {
+	tt := []struct {
+		name  string
+		build func(t *testing.T) *Tree
+		pgn   string
+	}{
+		{
+			name: "variations comments and nags",
+			build: func(t *testing.T) *Tree {
+				tree := newTestTree(t, engine.StartFEN, "e4", "e5", "Nf3", "Nc6")
+				tree.Root().SetComment("King's pawn")
+				require.NoError(t, tree.GoTo(tree.Mainline()[0]))
+				_, err := tree.PlaySAN("c5")
+				require.NoError(t, err)
+				n, err := tree.PlaySAN("Nf3")
+				require.NoError(t, err)
+				require.NoError(t, n.AddNAG(1))
+				n.SetComment("Open {Sicilian}")
+				require.True(t, tree.Back())
+				_, err = tree.PlaySAN("c3")
+				require.NoError(t, err)
+				return tree
+			},
+			pgn: `[Event "?"]
+[Site "?"]
+[Date "????.??.??"]
+[Round "?"]
+[White "?"]
+[Black "?"]
+[Result "*"]
+
+{King's pawn} 1. e4 e5 (1... c5 2. Nf3 $1 {Open Sicilian} (2. c3)) 2. Nf3 Nc6 *
+`,
+		},
+		{
+			name: "starts with black and ends in checkmate",
+			build: func(t *testing.T) *Tree {
+				tree := newTestTree(t, "rnbqkbnr/pppppppp/8/8/6P1/8/PPPPPP1P/RNBQKBNR b KQkq g3 0 1",
+					"e5", "f3", "Qh4#")
+				require.NoError(t, tree.GoTo(tree.Root()))
+				_, err := tree.PlaySAN("d5")
+				require.NoError(t, err)
+				return tree
+			},
+			pgn: `[Event "?"]
+[Site "?"]
+[Date "????.??.??"]
+[Round "?"]
+[White "?"]
+[Black "?"]
+[Result "0-1"]
+[SetUp "1"]
+[FEN "rnbqkbnr/pppppppp/8/8/6P1/8/PPPPPP1P/RNBQKBNR b KQkq g3 0 1"]
+
+1... e5 (1... d5) 2. f3 Qh4# 0-1
+`,
+		},
+		{
+			name: "comment on the first move of a variation",
+			build: func(t *testing.T) *Tree {
+				tree := newTestTree(t, engine.StartFEN, "e4", "e5")
+				require.NoError(t, tree.GoTo(tree.Root()))
+				n, err := tree.PlaySAN("d4")
+				require.NoError(t, err)
+				n.SetComment("queen pawn")
+				_, err = tree.PlaySAN("d5")
+				require.NoError(t, err)
+				return tree
+			},
+			pgn: `[Event "?"]
+[Site "?"]
+[Date "????.??.??"]
+[Round "?"]
+[White "?"]
+[Black "?"]
+[Result "*"]
+
+1. e4 (1. d4 {queen pawn} 1... d5) 1... e5 *
+`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.pgn, tc.build(t).PGN())
+		})
+	}
+}
+
+func TestTree_PGNVariant(t *testing.T) {
+	tree, err := NewTree(engine.StartFEN, engine.VariantKingOfTheHill)
+	require.NoError(t, err)
+	for _, san := range []string{"e3", "e6", "Ke2", "Ke7", "Kd3", "Kd6", "Ke4"} {
+		_, err := tree.PlaySAN(san)
+		require.NoError(t, err)
+	}
+
+	pgn := tree.PGN()
+	assert.Contains(t, pgn, `[Result "1-0"]`)
+	assert.Contains(t, pgn, `[Variant "King of the Hill"]`)
+	assert.Contains(t, pgn, "4. Ke4 1-0")
+}
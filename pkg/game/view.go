@@ -0,0 +1,69 @@
+package game
+
+import "github.com/dyxj/chess/pkg/engine"
+
+// HiddenCell grid value of a square the recipient can not see, dark chess only.
+// Same value as engine.SentinelCell which never appears in a grid otherwise.
+const HiddenCell = engine.SentinelCell
+
+// For round as seen by color, the full round if there is no view for color.
+func (r RoundResult) For(color engine.Color) RoundResult {
+	if v, ok := r.Views[color]; ok {
+		return v
+	}
+	return r
+}
+
+// withViews adds per color views for dark chess,
+// the full board is revealed once the game is over.
+func (g *Game) withViews(r RoundResult) RoundResult {
+	if g.variant != engine.VariantDarkChess || r.State.IsGameOver() {
+		return r
+	}
+
+	r.Views = make(map[engine.Color]RoundResult, len(engine.Colors))
+	for _, color := range engine.Colors {
+		r.Views[color] = maskRound(r, color, g.b.Visibility(color))
+	}
+	return r
+}
+
+func maskRound(r RoundResult, color engine.Color, visible [64]bool) RoundResult {
+	r.Views = nil
+	r = maskMaterial(r, color, visible)
+	for i := range r.Grid {
+		if !visible[i] {
+			r.Grid[i] = HiddenCell
+		}
+	}
+	if r.MoveResult != nil && r.MoveResult.Color != color {
+		r.MoveResult = maskMove(*r.MoveResult, visible)
+	}
+	// legal moves of the opponent reveal its pieces
+	if r.ActiveColor != color {
+		r.LegalMoves = nil
+	}
+	return r
+}
+
+// maskMove opponent move with squares outside of visible hidden as -1.
+// Capture squares are always revealed, returns nil if nothing was seen.
+func maskMove(m MoveResult, visible [64]bool) *MoveResult {
+	fromVisible := m.From >= 0 && visible[m.From]
+	toVisible := visible[m.To] || m.Captured != 0
+	if !fromVisible && !toVisible {
+		return nil
+	}
+
+	if !fromVisible {
+		m.From = -1
+	}
+	if !toVisible {
+		m.To = -1
+		m.IsCastling = false
+		m.RookFrom = 0
+		m.RookTo = 0
+		m.Promotion = 0
+	}
+	return &m
+}
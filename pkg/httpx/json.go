@@ -15,6 +15,7 @@ const headerKeyContentType = "Content-Type"
 const internalServerErrorDefaultMessage = "internal server error"
 const validationFailedDefaultMessage = "validation failed"
 const notFoundDefaultMessage = "entity not found"
+const unauthorizedDefaultMessage = "unauthorized"
 
 func JsonResponse(statusCode int, resp any, w http.ResponseWriter) {
 	// Why not directly in the response writer?
@@ -68,6 +69,16 @@ func NotFoundResponse(w http.ResponseWriter) {
 		w)
 }
 
+func UnauthorizedResponse(w http.ResponseWriter) {
+	JsonResponse(
+		http.StatusUnauthorized,
+		ErrorResponse{
+			Code:    CodeUnauthorized,
+			Message: unauthorizedDefaultMessage,
+		},
+		w)
+}
+
 func ConflictResponse(message string, details map[string]string, w http.ResponseWriter) {
 	JsonResponse(
 		http.StatusConflict,
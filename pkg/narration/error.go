@@ -0,0 +1,5 @@
+package narration
+
+import "errors"
+
+var ErrUnsupportedLanguage = errors.New("unsupported language")
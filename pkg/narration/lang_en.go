@@ -0,0 +1,55 @@
+package narration
+
+import (
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+)
+
+var englishPack = Pack{
+	Name: func(s engine.Symbol) string {
+		return s.String()
+	},
+	Piece: func(c engine.Color, s engine.Symbol) string {
+		return c.String() + " " + s.String()
+	},
+	Side: func(c engine.Color) string {
+		if c == engine.White {
+			return "White"
+		}
+		return "Black"
+	},
+	States: map[game.State]string{
+		game.StateCheckmate:     "checkmate",
+		game.StateStalemate:     "stalemate",
+		game.StateDraw:          "draw",
+		game.StateWhiteResign:   "white resigns",
+		game.StateBlackResign:   "black resigns",
+		game.StateKingOfTheHill: "king reached the hill",
+		game.StateThreeCheck:    "third check",
+		game.StateNoMovesLeft:   "no moves left",
+		game.StateKingExploded:  "king exploded",
+		game.StateKingCaptured:  "king captured",
+		game.StateTimeout:       "out of time",
+		game.StateAborted:       "game aborted",
+	},
+
+	Move:            "%s from %s to %s",
+	MoveUnseenFrom:  "%s to %s",
+	MoveUnseenTo:    "%s leaves %s",
+	Drop:            "%s dropped on %s",
+	CastleKingside:  "%s castles kingside",
+	CastleQueenside: "%s castles queenside",
+	Capture:         "captures %s",
+	Promotion:       "promotes to %s",
+	EnPassant:       "en passant",
+	Check:           "check",
+
+	PieceOn: "%s on %s",
+	Rank:    "rank %d: %s",
+	Empty:   "%d empty",
+	Hidden:  "%d hidden",
+	None:    "no pieces",
+
+	Separator:         ", ",
+	SentenceSeparator: ". ",
+}
@@ -0,0 +1,77 @@
+package narration
+
+import (
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+)
+
+var spanishNames = map[engine.Symbol]string{
+	engine.Pawn:   "peón",
+	engine.Knight: "caballo",
+	engine.Bishop: "alfil",
+	engine.Rook:   "torre",
+	engine.Queen:  "dama",
+	engine.King:   "rey",
+}
+
+// spanishFeminine pieces whose color adjective is feminine
+var spanishFeminine = map[engine.Symbol]bool{
+	engine.Rook:  true,
+	engine.Queen: true,
+}
+
+var spanishPack = Pack{
+	Name: func(s engine.Symbol) string {
+		return spanishNames[s]
+	},
+	Piece: func(c engine.Color, s engine.Symbol) string {
+		color := "blanco"
+		if c == engine.Black {
+			color = "negro"
+		}
+		if spanishFeminine[s] {
+			color = color[:len(color)-1] + "a"
+		}
+		return spanishNames[s] + " " + color
+	},
+	Side: func(c engine.Color) string {
+		if c == engine.White {
+			return "las blancas"
+		}
+		return "las negras"
+	},
+	States: map[game.State]string{
+		game.StateCheckmate:     "jaque mate",
+		game.StateStalemate:     "rey ahogado",
+		game.StateDraw:          "tablas",
+		game.StateWhiteResign:   "las blancas abandonan",
+		game.StateBlackResign:   "las negras abandonan",
+		game.StateKingOfTheHill: "el rey llegó al centro",
+		game.StateThreeCheck:    "tercer jaque",
+		game.StateNoMovesLeft:   "sin jugadas",
+		game.StateKingExploded:  "rey explotado",
+		game.StateKingCaptured:  "rey capturado",
+		game.StateTimeout:       "tiempo agotado",
+		game.StateAborted:       "partida anulada",
+	},
+
+	Move:            "%s de %s a %s",
+	MoveUnseenFrom:  "%s a %s",
+	MoveUnseenTo:    "%s sale de %s",
+	Drop:            "%s se coloca en %s",
+	CastleKingside:  "%s enrocan en corto",
+	CastleQueenside: "%s enrocan en largo",
+	Capture:         "captura %s",
+	Promotion:       "corona %s",
+	EnPassant:       "al paso",
+	Check:           "jaque",
+
+	PieceOn: "%s en %s",
+	Rank:    "fila %d: %s",
+	Empty:   "%d vacías",
+	Hidden:  "%d ocultas",
+	None:    "sin piezas",
+
+	Separator:         ", ",
+	SentenceSeparator: ". ",
+}
@@ -0,0 +1,166 @@
+// Package narration describes moves and positions in natural language,
+// e.g. "White knight from g1 to f3, check", for screen readers and other
+// accessibility clients.
+package narration
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+)
+
+// Narrator describes moves and positions using the pack of a language.
+type Narrator struct {
+	lang Language
+	pack Pack
+}
+
+func New(lang Language) (*Narrator, error) {
+	p, ok := lookup(lang)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s valid language(%s)", ErrUnsupportedLanguage, lang, languageList())
+	}
+	return &Narrator{lang: lang, pack: p}, nil
+}
+
+func (n *Narrator) Language() Language {
+	return n.lang
+}
+
+// Move describes a move, squares of a dark chess move that were not seen are left out.
+func (n *Narrator) Move(m game.MoveResult) string {
+	return sentence(strings.Join(n.move(m), n.pack.Separator))
+}
+
+// Round describes the move of the round followed by check or the outcome
+// of the game. Empty if the round has no move or check and the game is in progress.
+func (n *Narrator) Round(r game.RoundResult) string {
+	var clauses []string
+	if r.MoveResult != nil {
+		clauses = n.move(*r.MoveResult)
+	}
+
+	if s, ok := n.pack.States[r.State]; ok && r.State.IsGameOver() {
+		clauses = append(clauses, s)
+	} else if r.InCheck {
+		clauses = append(clauses, n.pack.Check)
+	}
+	return sentence(strings.Join(clauses, n.pack.Separator))
+}
+
+func (n *Narrator) move(m game.MoveResult) []string {
+	p := n.pack
+	var clauses []string
+
+	piece := p.Piece(m.Color, m.Symbol)
+	switch {
+	case m.IsDrop:
+		clauses = append(clauses, fmt.Sprintf(p.Drop, piece, square(m.To)))
+	case m.IsCastling && m.RookTo > m.RookFrom:
+		clauses = append(clauses, fmt.Sprintf(p.CastleQueenside, p.Side(m.Color)))
+	case m.IsCastling:
+		clauses = append(clauses, fmt.Sprintf(p.CastleKingside, p.Side(m.Color)))
+	case m.From < 0:
+		clauses = append(clauses, fmt.Sprintf(p.MoveUnseenFrom, piece, square(m.To)))
+	case m.To < 0:
+		clauses = append(clauses, fmt.Sprintf(p.MoveUnseenTo, piece, square(m.From)))
+	default:
+		clauses = append(clauses, fmt.Sprintf(p.Move, piece, square(m.From), square(m.To)))
+	}
+
+	if m.Captured != 0 {
+		clauses = append(clauses, fmt.Sprintf(p.Capture, p.Piece(m.Color.Opposite(), m.Captured)))
+	}
+	if m.IsEnPassant {
+		clauses = append(clauses, p.EnPassant)
+	}
+	if m.Promotion != 0 {
+		clauses = append(clauses, fmt.Sprintf(p.Promotion, p.Name(m.Promotion)))
+	}
+	return clauses
+}
+
+// PositionByPiece describes the position one side at a time, kings first
+// followed by the other pieces from the most to the least valuable.
+func (n *Narrator) PositionByPiece(grid [64]int) string {
+	p := n.pack
+	sentences := make([]string, 0, len(engine.Colors))
+	for _, c := range engine.Colors {
+		var pieces []string
+		for s := engine.King; s >= engine.Pawn; s-- {
+			for i, v := range grid {
+				if v == int(s)*int(c) && v != game.HiddenCell {
+					pieces = append(pieces, fmt.Sprintf(p.PieceOn, p.Name(s), square(i)))
+				}
+			}
+		}
+		if len(pieces) == 0 {
+			pieces = append(pieces, p.None)
+		}
+		sentences = append(sentences, sentence(p.Side(c)+": "+strings.Join(pieces, p.Separator)))
+	}
+	return strings.Join(sentences, p.SentenceSeparator) + "."
+}
+
+// PositionByRank describes the position rank by rank from the 8th to the 1st,
+// consecutive empty or hidden squares are grouped.
+func (n *Narrator) PositionByRank(grid [64]int) string {
+	p := n.pack
+	sentences := make([]string, 0, 8)
+	for rank := 7; rank >= 0; rank-- {
+		var squares []string
+		empty, hidden := 0, 0
+		flush := func() {
+			if empty > 0 {
+				squares = append(squares, fmt.Sprintf(p.Empty, empty))
+			}
+			if hidden > 0 {
+				squares = append(squares, fmt.Sprintf(p.Hidden, hidden))
+			}
+			empty, hidden = 0, 0
+		}
+		for file := range 8 {
+			v := grid[rank*8+file]
+			switch {
+			case v == 0:
+				if hidden > 0 {
+					flush()
+				}
+				empty++
+			case v == game.HiddenCell:
+				if empty > 0 {
+					flush()
+				}
+				hidden++
+			default:
+				flush()
+				c, s := engine.White, engine.Symbol(v)
+				if v < 0 {
+					c, s = engine.Black, engine.Symbol(-v)
+				}
+				squares = append(squares, p.Piece(c, s))
+			}
+		}
+		flush()
+		sentences = append(sentences, sentence(fmt.Sprintf(p.Rank, rank+1, strings.Join(squares, p.Separator))))
+	}
+	return strings.Join(sentences, p.SentenceSeparator) + "."
+}
+
+// square algebraic name of a board index.
+func square(i int) string {
+	return engine.SquareName(engine.IndexToMailbox(i))
+}
+
+// sentence s with its first letter in upper case.
+func sentence(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,220 @@
+package narration
+
+import (
+	"testing"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	n, err := New(Spanish)
+	require.NoError(t, err)
+	assert.Equal(t, Spanish, n.Language())
+
+	_, err = New("xx")
+	assert.ErrorIs(t, err, ErrUnsupportedLanguage)
+}
+
+func TestNarrator_Round(t *testing.T) {
+	tests := []struct {
+		name    string
+		fen     string
+		variant engine.Variant
+		moves   []string
+		en      string
+		es      string
+	}{
+		{
+			name:  "move",
+			fen:   "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+			moves: []string{"g1f3"},
+			en:    "White knight from g1 to f3",
+			es:    "Caballo blanco de g1 a f3",
+		},
+		{
+			name:  "castle kingside",
+			fen:   "r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1",
+			moves: []string{"e1g1"},
+			en:    "White castles kingside",
+			es:    "Las blancas enrocan en corto",
+		},
+		{
+			name:  "castle queenside",
+			fen:   "r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 0 1",
+			moves: []string{"e8c8"},
+			en:    "Black castles queenside",
+			es:    "Las negras enrocan en largo",
+		},
+		{
+			name:  "capture promotion check",
+			fen:   "r1k5/1P6/8/8/8/8/8/4K3 w - - 0 1",
+			moves: []string{"b7a8=Q"},
+			en:    "White pawn from b7 to a8, captures black rook, promotes to queen, check",
+			es:    "Peón blanco de b7 a a8, captura torre negra, corona dama, jaque",
+		},
+		{
+			name:  "en passant",
+			fen:   "4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 1",
+			moves: []string{"e5d6"},
+			en:    "White pawn from e5 to d6, captures black pawn, en passant",
+			es:    "Peón blanco de e5 a d6, captura peón negro, al paso",
+		},
+		{
+			name:  "checkmate",
+			fen:   "r1bqkbnr/pppp1ppp/2n5/4p2Q/2B1P3/8/PPPP1PPP/RNB1K1NR w KQkq - 0 1",
+			moves: []string{"h5f7"},
+			en:    "White queen from h5 to f7, captures black pawn, checkmate",
+			es:    "Dama blanca de h5 a f7, captura peón negro, jaque mate",
+		},
+		{
+			name:    "no check in antichess",
+			fen:     "4k3/8/8/8/8/8/8/R5K1 w - - 0 1",
+			variant: engine.VariantAntichess,
+			moves:   []string{"a1e1"},
+			en:      "White rook from a1 to e1",
+			es:      "Torre blanca de a1 a e1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := engine.NewBoardFromFEN(tt.fen)
+			require.NoError(t, err)
+			if tt.variant != 0 {
+				b.SetVariant(tt.variant)
+			}
+			g := game.NewGame(b)
+
+			var rr game.RoundResult
+			for _, m := range tt.moves {
+				rr, err = g.ApplyMoveWithFileRank(m)
+				require.NoError(t, err, m)
+			}
+
+			for lang, want := range map[Language]string{English: tt.en, Spanish: tt.es} {
+				n, err := New(lang)
+				require.NoError(t, err)
+				assert.Equal(t, want, n.Round(rr), lang)
+			}
+		})
+	}
+}
+
+func TestNarrator_Move(t *testing.T) {
+	n, err := New(English)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		m    game.MoveResult
+		want string
+	}{
+		{
+			name: "drop",
+			m:    game.MoveResult{Color: engine.Black, Symbol: engine.Knight, From: -1, To: 44, IsDrop: true},
+			want: "Black knight dropped on e6",
+		},
+		{
+			name: "origin unseen",
+			m:    game.MoveResult{Color: engine.Black, Symbol: engine.Bishop, From: -1, To: 28, Captured: engine.Pawn},
+			want: "Black bishop to e4, captures white pawn",
+		},
+		{
+			name: "destination unseen",
+			m:    game.MoveResult{Color: engine.White, Symbol: engine.Rook, From: 0, To: -1},
+			want: "White rook leaves a1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, n.Move(tt.m))
+		})
+	}
+}
+
+func TestNarrator_RoundWithoutMove(t *testing.T) {
+	n, err := New(English)
+	require.NoError(t, err)
+
+	g := game.NewGame(engine.NewBoard())
+	assert.Empty(t, n.Round(g.Round()))
+
+	require.NoError(t, g.Resign(engine.White))
+	assert.Equal(t, "White resigns", n.Round(g.Round()))
+}
+
+func TestNarrator_Position(t *testing.T) {
+	b, err := engine.NewBoardFromFEN("4k3/8/8/8/8/8/4P3/R3K3 w Q - 0 1")
+	require.NoError(t, err)
+	grid := game.NewGame(b).GridRaw()
+
+	en, err := New(English)
+	require.NoError(t, err)
+	es, err := New(Spanish)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"White: king on e1, rook on a1, pawn on e2. Black: king on e8.",
+		en.PositionByPiece(grid))
+	assert.Equal(t,
+		"Las blancas: rey en e1, torre en a1, peón en e2. Las negras: rey en e8.",
+		es.PositionByPiece(grid))
+
+	assert.Equal(t,
+		"Rank 8: 4 empty, black king, 3 empty. "+
+			"Rank 7: 8 empty. Rank 6: 8 empty. Rank 5: 8 empty. Rank 4: 8 empty. Rank 3: 8 empty. "+
+			"Rank 2: 4 empty, white pawn, 3 empty. "+
+			"Rank 1: white rook, 3 empty, white king, 3 empty.",
+		en.PositionByRank(grid))
+}
+
+func TestNarrator_PositionHidden(t *testing.T) {
+	n, err := New(English)
+	require.NoError(t, err)
+
+	var grid [64]int
+	for i := range grid {
+		grid[i] = game.HiddenCell
+	}
+	grid[4] = int(engine.King)
+	grid[5] = 0
+	grid[6] = 0
+
+	assert.Equal(t, "White: king on e1. Black: no pieces.", n.PositionByPiece(grid))
+	assert.Equal(t,
+		"Rank 8: 8 hidden. Rank 7: 8 hidden. Rank 6: 8 hidden. Rank 5: 8 hidden. "+
+			"Rank 4: 8 hidden. Rank 3: 8 hidden. Rank 2: 8 hidden. "+
+			"Rank 1: 4 hidden, white king, 2 empty, 1 hidden.",
+		n.PositionByRank(grid))
+}
+
+func TestLanguage_UnmarshalText(t *testing.T) {
+	var lang Language
+	require.NoError(t, lang.UnmarshalText([]byte("es")))
+	assert.Equal(t, Spanish, lang)
+	assert.ErrorIs(t, lang.UnmarshalText([]byte("xx")), ErrUnsupportedLanguage)
+}
+
+func TestRegister(t *testing.T) {
+	p := englishPack
+	p.Check = "check!"
+	Register("en-test", p)
+	t.Cleanup(func() {
+		packsMu.Lock()
+		delete(packs, "en-test")
+		packsMu.Unlock()
+	})
+
+	assert.Contains(t, Languages(), Language("en-test"))
+	n, err := New("en-test")
+	require.NoError(t, err)
+	rr := game.RoundResult{
+		State:       game.StateInProgress,
+		ActiveColor: engine.Black,
+		InCheck:     true,
+		MoveResult:  &game.MoveResult{Color: engine.White, Symbol: engine.Rook, From: 7, To: 63},
+	}
+	assert.Equal(t, "White rook from h1 to h8, check!", n.Round(rr))
+}
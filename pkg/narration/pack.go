@@ -0,0 +1,117 @@
+package narration
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+)
+
+// Language tag of a language pack, e.g. "en".
+type Language string
+
+const (
+	English Language = "en"
+	Spanish Language = "es"
+)
+
+func (l Language) MarshalText() ([]byte, error) {
+	return []byte(l), nil
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (l *Language) UnmarshalText(text []byte) error {
+	lang := Language(text)
+	if _, ok := lookup(lang); !ok {
+		return fmt.Errorf("%w: %s valid language(%s)", ErrUnsupportedLanguage, text, languageList())
+	}
+	*l = lang
+	return nil
+}
+
+// Pack wording of a language. Sentences are built from the format strings,
+// use explicit argument indexes such as %[2]s to change the word order.
+type Pack struct {
+	// Name of a piece, e.g. "knight"
+	Name func(s engine.Symbol) string
+	// Piece name of a piece of a color, e.g. "white knight"
+	Piece func(c engine.Color, s engine.Symbol) string
+	// Side color as the subject of a sentence, e.g. "White"
+	Side func(c engine.Color) string
+	// States outcome of a finished game, states without wording are not narrated
+	States map[game.State]string
+
+	Move string // piece, from, to
+	// MoveUnseenFrom move of a dark chess opponent whose origin was not seen: piece, to
+	MoveUnseenFrom string
+	// MoveUnseenTo move of a dark chess opponent whose destination was not seen: piece, from
+	MoveUnseenTo    string
+	Drop            string // piece, to
+	CastleKingside  string // side
+	CastleQueenside string // side
+	Capture         string // captured piece
+	Promotion       string // promoted piece name
+	EnPassant       string
+	Check           string
+
+	PieceOn string // piece name, square
+	Rank    string // rank number, squares of the rank
+	Empty   string // number of consecutive empty squares
+	Hidden  string // number of consecutive hidden squares, dark chess
+	None    string // side without pieces
+
+	// Separator between the clauses of a sentence
+	Separator string
+	// SentenceSeparator between the sentences of a position
+	SentenceSeparator string
+}
+
+var (
+	packsMu sync.RWMutex
+	packs   = map[Language]Pack{
+		English: englishPack,
+		Spanish: spanishPack,
+	}
+)
+
+// Register adds or replaces the pack of lang.
+func Register(lang Language, p Pack) {
+	packsMu.Lock()
+	defer packsMu.Unlock()
+
+	packs[lang] = p
+}
+
+// Languages registered languages in order.
+func Languages() []Language {
+	packsMu.RLock()
+	defer packsMu.RUnlock()
+
+	langs := make([]Language, 0, len(packs))
+	for lang := range packs {
+		langs = append(langs, lang)
+	}
+	slices.Sort(langs)
+	return langs
+}
+
+func lookup(lang Language) (Pack, bool) {
+	packsMu.RLock()
+	defer packsMu.RUnlock()
+
+	p, ok := packs[lang]
+	return p, ok
+}
+
+func languageList() string {
+	var list string
+	for i, lang := range Languages() {
+		if i > 0 {
+			list += ","
+		}
+		list += string(lang)
+	}
+	return list
+}
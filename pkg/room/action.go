@@ -3,7 +3,9 @@ package room
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/dyxj/chess/pkg/engine"
 	"github.com/dyxj/chess/pkg/game"
@@ -13,6 +15,17 @@ type ActionType string
 
 const (
 	ActionTypeMove ActionType = "move"
+	ActionTypeDrop ActionType = "drop"
+	ActionTypeChat ActionType = "chat"
+	// ActionTypeAdjourn offers to adjourn, the opponent accepts with ActionTypeAdjournAccept
+	ActionTypeAdjourn       ActionType = "adjourn"
+	ActionTypeAdjournAccept ActionType = "adjourn_accept"
+	// ActionTypeDescribe requests a description of the position, replied with EventTypeDescription
+	ActionTypeDescribe ActionType = "describe"
+	// ActionTypeResign resigns the game, both players receive EventTypeResign
+	ActionTypeResign ActionType = "resign"
+	// ActionTypeAbort calls off the game before both sides moved, both players receive EventTypeAbort
+	ActionTypeAbort ActionType = "abort"
 )
 
 type ActionPartial struct {
@@ -48,7 +61,8 @@ func (p *ActionMovePayload) Validate() error {
 		return errors.New("invalid symbol")
 	}
 
-	if p.Promotion != 0 && !slices.Contains(engine.PromotionSymbols, p.Promotion) {
+	// king promotion is rejected by the game unless the variant allows it
+	if p.Promotion != 0 && (p.Promotion == engine.Pawn || !slices.Contains(engine.Symbols, p.Promotion)) {
 		return errors.New("invalid promotion symbol")
 	}
 
@@ -90,3 +104,144 @@ func NewActionMove(
 		},
 	}
 }
+
+// ActionDropPayload drops a pocket piece, crazyhouse only
+// to is a pointer as 0 is a valid value
+type ActionDropPayload struct {
+	Symbol engine.Symbol `json:"symbol"`
+	To     *int          `json:"to"`
+}
+
+func (p *ActionDropPayload) Validate() error {
+	if p.To == nil {
+		return errors.New("to required")
+	}
+	if *p.To < 0 || *p.To > 63 {
+		return errors.New("to must be between 0 and 63")
+	}
+
+	if !slices.Contains(engine.DropSymbols, p.Symbol) {
+		return errors.New("invalid drop symbol")
+	}
+
+	return nil
+}
+
+func (p *ActionDropPayload) ToMove(color engine.Color) game.Move {
+	return game.Move{
+		Color:  color,
+		Symbol: p.Symbol,
+		To:     *p.To,
+		IsDrop: true,
+	}
+}
+
+type ActionDrop struct {
+	Type    ActionType        `json:"type"`
+	Payload ActionDropPayload `json:"payload"`
+}
+
+func NewActionDrop(symbol engine.Symbol, to *int) ActionDrop {
+	return ActionDrop{
+		Type: ActionTypeDrop,
+		Payload: ActionDropPayload{
+			Symbol: symbol,
+			To:     to,
+		},
+	}
+}
+
+// moveAction payload of an action converted to a game.Move
+type moveAction interface {
+	Validate() error
+	ToMove(color engine.Color) game.Move
+}
+
+// maxChatLength maximum number of bytes in a chat message
+const maxChatLength = 500
+
+// ActionChatPayload message to a teammate, bughouse only
+type ActionChatPayload struct {
+	Message string `json:"message"`
+}
+
+func (p *ActionChatPayload) Validate() error {
+	if strings.TrimSpace(p.Message) == "" {
+		return errors.New("message required")
+	}
+	if len(p.Message) > maxChatLength {
+		return fmt.Errorf("message must be at most %d bytes", maxChatLength)
+	}
+	return nil
+}
+
+type ActionChat struct {
+	Type    ActionType        `json:"type"`
+	Payload ActionChatPayload `json:"payload"`
+}
+
+func NewActionChat(message string) ActionChat {
+	return ActionChat{
+		Type: ActionTypeChat,
+		Payload: ActionChatPayload{
+			Message: message,
+		},
+	}
+}
+
+// NewActionAdjourn action without payload, actionType is ActionTypeAdjourn or ActionTypeAdjournAccept
+func NewActionAdjourn(actionType ActionType) ActionPartial {
+	return ActionPartial{
+		Type: actionType,
+	}
+}
+
+// NewActionResign action without payload
+func NewActionResign() ActionPartial {
+	return ActionPartial{
+		Type: ActionTypeResign,
+	}
+}
+
+// NewActionAbort action without payload
+func NewActionAbort() ActionPartial {
+	return ActionPartial{
+		Type: ActionTypeAbort,
+	}
+}
+
+// DescribeBy how a position is described
+type DescribeBy string
+
+const (
+	// DescribeByPiece each side piece by piece
+	DescribeByPiece DescribeBy = "piece"
+	// DescribeByRank rank by rank from the 8th rank
+	DescribeByRank DescribeBy = "rank"
+)
+
+// ActionDescribePayload By defaults to DescribeByPiece
+type ActionDescribePayload struct {
+	By DescribeBy `json:"by"`
+}
+
+func (p *ActionDescribePayload) Validate() error {
+	if p.By != "" && p.By != DescribeByPiece && p.By != DescribeByRank {
+		return fmt.Errorf("by must be %s or %s", DescribeByPiece, DescribeByRank)
+	}
+	return nil
+}
+
+type ActionDescribe struct {
+	Type    ActionType            `json:"type"`
+	Payload ActionDescribePayload `json:"payload"`
+}
+
+func NewActionDescribe(by DescribeBy) ActionDescribe {
+	return ActionDescribe{
+		Type: ActionTypeDescribe,
+		Payload: ActionDescribePayload{
+			By: by,
+		},
+	}
+}
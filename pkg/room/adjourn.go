@@ -0,0 +1,110 @@
+package room
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+)
+
+// AdjournedGame saved state of a room adjourned by mutual agreement.
+// The game is rebuilt from FEN by replaying Moves and restoring the clocks.
+type AdjournedGame struct {
+	Code    string                           `json:"code"`
+	Variant engine.Variant                   `json:"variant"`
+	FEN     string                           `json:"fen"`
+	Rules   game.Rules                       `json:"rules"`
+	Moves   []game.Move                      `json:"moves"`
+	Players map[engine.Color]AdjournedPlayer `json:"players"`
+	// Remaining and MoveTimes are only set for timed games
+	Remaining     map[engine.Color]time.Duration `json:"remaining,omitempty"`
+	MoveTimes     []time.Duration                `json:"moveTimes,omitempty"`
+	AdjournedTime time.Time                      `json:"adjournedTime"`
+}
+
+// AdjournedPlayer only the hash of the resume key is saved
+type AdjournedPlayer struct {
+	Name    string `json:"name"`
+	KeyHash string `json:"keyHash"`
+}
+
+// AdjournStore saves adjourned games until they are resumed.
+type AdjournStore interface {
+	Save(ag AdjournedGame) error
+	Find(code string) (AdjournedGame, bool)
+	Delete(code string) error
+}
+
+// adjournedGame snapshot of the room, resume keys are not set
+func (r *Room) adjournedGame() AdjournedGame {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ag := AdjournedGame{
+		Code:    r.Code,
+		Variant: r.Variant(),
+		FEN:     r.startFEN,
+		Rules:   r.Game.Rules(),
+		Moves:   slices.Clone(r.moves),
+		Players: map[engine.Color]AdjournedPlayer{
+			engine.White: {Name: r.whitePlayer.Name},
+			engine.Black: {Name: r.blackPlayer.Name},
+		},
+		MoveTimes:     r.Game.MoveTimes(),
+		AdjournedTime: time.Now(),
+	}
+	if r.IsTimed() {
+		ag.Remaining = map[engine.Color]time.Duration{
+			engine.White: r.Game.Remaining(engine.White),
+			engine.Black: r.Game.Remaining(engine.Black),
+		}
+	}
+	return ag
+}
+
+// newResumedRoom rebuilds the room of ag in the position it was adjourned,
+// seats are reserved for the players of ag.
+func newResumedRoom(ag AdjournedGame) (*Room, error) {
+	b, err := engine.NewBoardFromFEN(ag.FEN)
+	if err != nil {
+		return nil, fmt.Errorf("adjourned game %s has invalid fen: %w", ag.Code, err)
+	}
+	b.SetVariant(ag.Variant)
+
+	r := newRoom(b, ag.Rules)
+	r.Code = ag.Code
+	r.resumed = true
+	for i, m := range ag.Moves {
+		if _, err := r.Game.ApplyMove(m); err != nil {
+			return nil, fmt.Errorf("adjourned game %s failed to replay move %d: %w", ag.Code, i+1, err)
+		}
+		r.moves = append(r.moves, m)
+	}
+	r.Game.RestoreClock(ag.Remaining, ag.MoveTimes)
+
+	return r, nil
+}
+
+func (ag AdjournedGame) authenticate(key string) (engine.Color, bool) {
+	hash := hashToken(key)
+	for _, color := range engine.Colors {
+		p, ok := ag.Players[color]
+		if ok && subtle.ConstantTimeCompare([]byte(p.KeyHash), []byte(hash)) == 1 {
+			return color, true
+		}
+	}
+	return 0, false
+}
+
+// clone copies maps and slices so stored games are not shared with callers
+func (ag AdjournedGame) clone() AdjournedGame {
+	ag.Moves = slices.Clone(ag.Moves)
+	ag.Players = maps.Clone(ag.Players)
+	ag.Remaining = maps.Clone(ag.Remaining)
+	ag.MoveTimes = slices.Clone(ag.MoveTimes)
+	return ag
+}
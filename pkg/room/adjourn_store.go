@@ -0,0 +1,149 @@
+package room
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dyxj/chess/pkg/store"
+)
+
+// AdjournMemStore in memory AdjournStore, adjourned games are lost on restart.
+type AdjournMemStore struct {
+	cache *store.MemCache
+}
+
+func NewAdjournMemStore(cache *store.MemCache) *AdjournMemStore {
+	return &AdjournMemStore{
+		cache: cache,
+	}
+}
+
+func (s *AdjournMemStore) Save(ag AdjournedGame) error {
+	return s.cache.Set(adjournKey(ag.Code), ag.clone(), time.Time{})
+}
+
+func (s *AdjournMemStore) Find(code string) (AdjournedGame, bool) {
+	item, ok := s.cache.Find(adjournKey(code))
+	if !ok {
+		return AdjournedGame{}, false
+	}
+	ag, isGame := item.(AdjournedGame)
+	if !isGame {
+		return AdjournedGame{}, false
+	}
+	return ag.clone(), true
+}
+
+func (s *AdjournMemStore) Delete(code string) error {
+	s.cache.Delete(adjournKey(code))
+	return nil
+}
+
+// adjournKey keeps adjourned codes apart from room codes sharing the cache
+func adjournKey(code string) string {
+	return "adjourned:" + code
+}
+
+// AdjournFileStore AdjournStore keeping each game as a json file in dir,
+// adjourned games survive restarts.
+type AdjournFileStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewAdjournFileStore creates dir if it does not exist.
+func NewAdjournFileStore(dir string) (*AdjournFileStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create adjourn dir: %w", err)
+	}
+	return &AdjournFileStore{
+		dir: dir,
+	}, nil
+}
+
+// Save writes to a temporary file first so a crash does not leave a partial game.
+func (s *AdjournFileStore) Save(ag AdjournedGame) error {
+	path, ok := codePath(s.dir, ag.Code)
+	if !ok {
+		return fmt.Errorf("invalid code %q", ag.Code)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return writeJSONFile(path, ag)
+}
+
+func (s *AdjournFileStore) Find(code string) (AdjournedGame, bool) {
+	path, ok := codePath(s.dir, code)
+	if !ok {
+		return AdjournedGame{}, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ag AdjournedGame
+	if err := readJSONFile(path, &ag); err != nil {
+		return AdjournedGame{}, false
+	}
+	return ag, true
+}
+
+func (s *AdjournFileStore) Delete(code string) error {
+	path, ok := codePath(s.dir, code)
+	if !ok {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// codePath path of the json file of code in dir, codes with characters outside
+// validChars are rejected so they can not escape dir.
+func codePath(dir string, code string) (string, bool) {
+	if code == "" || strings.Trim(code, validChars) != "" {
+		return "", false
+	}
+	return filepath.Join(dir, code+".json"), true
+}
+
+// writeJSONFile writes to a temporary file first so a crash does not leave a partial file.
+func writeJSONFile(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func readJSONFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
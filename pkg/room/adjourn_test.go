@@ -0,0 +1,138 @@
+package room
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestRoomInProgress(t *testing.T, cfg Config) *Room {
+	t.Helper()
+	r, err := NewRoom(cfg)
+	require.NoError(t, err)
+	require.NoError(t, r.SetPlayer(engine.White, NewPlayer("white player")))
+	require.NoError(t, r.SetPlayer(engine.Black, NewPlayer("black player")))
+	r.SetStatus(StatusInProgress)
+	return r
+}
+
+func TestRoom_Adjourn(t *testing.T) {
+	r := newTestRoomInProgress(t, Config{})
+
+	assert.ErrorIs(t, r.acceptAdjourn(engine.Black), ErrNoAdjournOffer)
+
+	require.NoError(t, r.offerAdjourn(engine.White))
+	assert.ErrorIs(t, r.acceptAdjourn(engine.White), ErrNoAdjournOffer, "own offer")
+
+	_, err := r.applyMove(game.Move{Color: engine.White, Symbol: engine.Pawn, From: 12, To: 28})
+	require.NoError(t, err)
+	assert.ErrorIs(t, r.acceptAdjourn(engine.Black), ErrNoAdjournOffer, "offer lapsed")
+
+	require.NoError(t, r.offerAdjourn(engine.White))
+	require.NoError(t, r.acceptAdjourn(engine.Black))
+	assert.Equal(t, StatusAdjourned, r.Status())
+
+	_, err = r.applyMove(game.Move{Color: engine.Black, Symbol: engine.Pawn, From: 52, To: 36})
+	assert.ErrorIs(t, err, ErrRoomNotInProgress)
+}
+
+func TestNewResumedRoom(t *testing.T) {
+	r := newTestRoomInProgress(t, Config{
+		Odds:  engine.OddsKnight,
+		Rules: game.Rules{TimeControl: game.Fischer(time.Minute, time.Second)},
+	})
+	moves := []game.Move{
+		{Color: engine.White, Symbol: engine.Pawn, From: 12, To: 28},
+		{Color: engine.Black, Symbol: engine.Pawn, From: 52, To: 36},
+		{Color: engine.White, Symbol: engine.Knight, From: 6, To: 21},
+	}
+	for _, m := range moves {
+		_, err := r.applyMove(m)
+		require.NoError(t, err)
+	}
+
+	ag := r.adjournedGame()
+	assert.Equal(t, moves, ag.Moves)
+	assert.Equal(t, "black player", ag.Players[engine.Black].Name)
+	require.Contains(t, ag.Remaining, engine.White)
+	ag.Remaining = map[engine.Color]time.Duration{engine.White: 20 * time.Second, engine.Black: 30 * time.Second}
+
+	resumed, err := newResumedRoom(ag)
+	require.NoError(t, err)
+	assert.Equal(t, r.Code, resumed.Code)
+	assert.True(t, resumed.resumed)
+	assert.Equal(t, StatusWaiting, resumed.Status())
+	assert.Equal(t, r.StartFEN(), resumed.StartFEN())
+	assert.Equal(t, r.Game.GridRaw(), resumed.Game.GridRaw())
+	assert.Equal(t, 3, resumed.Game.Round().Count)
+	assert.Equal(t, engine.Black, resumed.Game.ActiveColor())
+	assert.Equal(t, 20*time.Second, resumed.Game.Remaining(engine.White))
+	assert.Equal(t, 30*time.Second, resumed.Game.Remaining(engine.Black))
+	assert.Equal(t, moves, resumed.moves)
+}
+
+func TestAdjournFileStore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewAdjournFileStore(dir)
+	require.NoError(t, err)
+
+	ag := AdjournedGame{
+		Code:    "ABC123",
+		Variant: engine.VariantCrazyhouse,
+		FEN:     engine.StartFEN,
+		Rules:   game.Rules{Draw: game.DrawAutomatic, TimeControl: game.Bronstein(time.Minute, 2*time.Second)},
+		Moves:   []game.Move{{Color: engine.White, Symbol: engine.Pawn, From: 12, To: 28}},
+		Players: map[engine.Color]AdjournedPlayer{
+			engine.White: {Name: "white player", KeyHash: hashToken("white")},
+			engine.Black: {Name: "black player", KeyHash: hashToken("black")},
+		},
+		Remaining:     map[engine.Color]time.Duration{engine.White: time.Minute, engine.Black: 50 * time.Second},
+		MoveTimes:     []time.Duration{10 * time.Second},
+		AdjournedTime: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, s.Save(ag))
+
+	// a new store on the same dir, as after a restart
+	restarted, err := NewAdjournFileStore(dir)
+	require.NoError(t, err)
+	found, ok := restarted.Find(ag.Code)
+	require.True(t, ok)
+	assert.Equal(t, ag, found)
+
+	color, ok := found.authenticate("black")
+	assert.True(t, ok)
+	assert.Equal(t, engine.Black, color)
+	_, ok = found.authenticate("other")
+	assert.False(t, ok)
+
+	require.NoError(t, restarted.Delete(ag.Code))
+	_, ok = restarted.Find(ag.Code)
+	assert.False(t, ok)
+	assert.NoError(t, restarted.Delete(ag.Code), "already deleted")
+
+	_, ok = restarted.Find("../" + ag.Code)
+	assert.False(t, ok)
+	assert.Error(t, restarted.Save(AdjournedGame{Code: "../ABC123"}))
+}
+
+func TestCoordinator_DisconnectAfterAdjourn(t *testing.T) {
+	cache := store.NewMemCache()
+	c := NewCoordinator(zap.NewNop(), time.Minute, NewMemCache(cache), NewAdjournMemStore(cache))
+
+	r := newTestRoomInProgress(t, Config{})
+	require.NoError(t, r.offerAdjourn(engine.White))
+	require.NoError(t, r.acceptAdjourn(engine.Black))
+
+	c.resignAndNotifyOpponent(r, engine.White)
+	assert.Equal(t, game.StateInProgress, r.Game.State(), "adjourned game is not resigned")
+
+	r = newTestRoomInProgress(t, Config{})
+	c.resignAndNotifyOpponent(r, engine.White)
+	assert.Equal(t, game.StateWhiteResign, r.Game.State())
+}
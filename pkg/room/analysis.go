@@ -0,0 +1,22 @@
+package room
+
+import (
+	"github.com/dyxj/chess/pkg/game"
+)
+
+// AnalysisStatus progress of the post-game analysis of a room
+type AnalysisStatus string
+
+const (
+	AnalysisPending AnalysisStatus = "pending"
+	AnalysisReady   AnalysisStatus = "ready"
+	AnalysisFailed  AnalysisStatus = "failed"
+)
+
+// GameAnalysis post-game analysis of the game of room Code,
+// Analysis is set once Status is AnalysisReady.
+type GameAnalysis struct {
+	Code     string         `json:"code"`
+	Status   AnalysisStatus `json:"status"`
+	Analysis *game.Analysis `json:"analysis,omitempty"`
+}
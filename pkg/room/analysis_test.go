@@ -0,0 +1,26 @@
+package room
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyxj/chess/pkg/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCoordinator_AnalyzedCodeIsReserved(t *testing.T) {
+	cache := store.NewMemCache()
+	c := NewCoordinator(zap.NewNop(), time.Minute, NewMemCache(cache), NewAdjournMemStore(cache))
+
+	r, err := NewRoom(Config{})
+	require.NoError(t, err)
+	require.NoError(t, c.cache.AddAnalysis(GameAnalysis{Code: r.Code, Status: AnalysisPending}))
+	assert.True(t, c.isReservedCode(r.Code))
+	assert.ErrorIs(t, c.addRoom(r), ErrCodeAlreadyExists)
+
+	r.Code = generateCode()
+	assert.False(t, c.isReservedCode(r.Code))
+	require.NoError(t, c.addRoom(r))
+}
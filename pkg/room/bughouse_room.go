@@ -0,0 +1,172 @@
+package room
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/google/uuid"
+)
+
+// BughouseSeats seats of a bughouse room, two boards with two teams
+var BughouseSeats = []Seat{
+	{Board: 0, Color: engine.White},
+	{Board: 0, Color: engine.Black},
+	{Board: 1, Color: engine.White},
+	{Board: 1, Color: engine.Black},
+}
+
+// BughouseRoom four players on two linked boards.
+// Team 0 plays white on board 0 and black on board 1, see Seat.Team.
+type BughouseRoom struct {
+	mu           sync.RWMutex
+	ID           uuid.UUID
+	Code         string
+	status       Status
+	Match        *game.Bughouse
+	players      map[Seat]*Player
+	pubs         map[Seat]websocketPublisher
+	CreatedTime  time.Time
+	readyChan    chan struct{}
+	readyOnce    sync.Once
+	gameOverChan chan struct{}
+	gameOverOnce sync.Once
+	// publishMu serializes applying moves and publishing events,
+	// so all seats receive rounds of both boards in the same order
+	publishMu sync.Mutex
+}
+
+func NewEmptyBughouseRoom() *BughouseRoom {
+	r := &BughouseRoom{
+		ID:           uuid.New(),
+		Code:         generateCode(),
+		Match:        game.NewBughouse(),
+		status:       StatusWaiting,
+		players:      make(map[Seat]*Player, len(BughouseSeats)),
+		pubs:         make(map[Seat]websocketPublisher, len(BughouseSeats)),
+		CreatedTime:  time.Now(),
+		readyChan:    make(chan struct{}),
+		gameOverChan: make(chan struct{}),
+	}
+	for board := range game.BughouseBoards {
+		g, _ := r.Match.Game(board)
+		g.IncludeLegalMoves(true)
+	}
+	return r
+}
+
+func (r *BughouseRoom) Variant() engine.Variant {
+	return engine.VariantBughouse
+}
+
+func (r *BughouseRoom) Player(seat Seat) *Player {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.players[seat]
+}
+
+func (r *BughouseRoom) SetPlayer(seat Seat, p *Player) error {
+	if !isBughouseSeat(seat) {
+		return ErrInvalidSeat
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.players[seat] != nil {
+		return ErrColorOccupied
+	}
+	r.players[seat] = p
+	return nil
+}
+
+func (r *BughouseRoom) RemovePlayer(seat Seat) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.players, seat)
+}
+
+func (r *BughouseRoom) Status() Status {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.status
+}
+
+func (r *BughouseRoom) SetStatus(s Status) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = s
+}
+
+func (r *BughouseRoom) setPublisher(seat Seat, pub websocketPublisher) (hasAll bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pubs[seat] = pub
+
+	return len(r.pubs) == len(BughouseSeats)
+}
+
+func (r *BughouseRoom) publisher(seat Seat) (websocketPublisher, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pub, ok := r.pubs[seat]
+	return pub, ok
+}
+
+func (r *BughouseRoom) publishers() map[Seat]websocketPublisher {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	m := make(map[Seat]websocketPublisher, len(r.pubs))
+	for seat, pub := range r.pubs {
+		m[seat] = pub
+	}
+	return m
+}
+
+func (r *BughouseRoom) removePublisher(seat Seat) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.pubs, seat)
+}
+
+// seatPlayers players ordered as BughouseSeats, empty seats are skipped
+func (r *BughouseRoom) seatPlayers() []SeatPlayer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	players := make([]SeatPlayer, 0, len(BughouseSeats))
+	for _, seat := range BughouseSeats {
+		if p, ok := r.players[seat]; ok {
+			players = append(players, SeatPlayer{Seat: seat, Name: p.Name})
+		}
+	}
+	return players
+}
+
+// publish runs fn holding publishMu
+func (r *BughouseRoom) publish(fn func() error) error {
+	r.publishMu.Lock()
+	defer r.publishMu.Unlock()
+	return fn()
+}
+
+func (r *BughouseRoom) signalReady() {
+	r.readyOnce.Do(func() {
+		close(r.readyChan)
+	})
+}
+
+func (r *BughouseRoom) signalGameOver() {
+	r.gameOverOnce.Do(func() {
+		close(r.gameOverChan)
+	})
+}
+
+func isBughouseSeat(seat Seat) bool {
+	return seat.Board >= 0 && seat.Board < game.BughouseBoards &&
+		(seat.Color == engine.White || seat.Color == engine.Black)
+}
@@ -8,6 +8,9 @@ import (
 
 const maximumRoomDuration = 6 * time.Hour
 
+// maximumAnalysisDuration analyses are kept after the room expires
+const maximumAnalysisDuration = 24 * time.Hour
+
 type MemCache struct {
 	cache *store.MemCache
 }
@@ -28,6 +31,18 @@ func (c *MemCache) Add(room *Room) error {
 	return nil
 }
 
+func (c *MemCache) AddBughouse(room *BughouseRoom) error {
+	err := c.cache.Add(room.Code, room, time.Now().Add(maximumRoomDuration))
+	if err != nil {
+		return ErrCodeAlreadyExists
+	}
+	return nil
+}
+
+func (c *MemCache) Delete(code string) {
+	c.cache.Delete(code)
+}
+
 func (c *MemCache) Find(code string) (*Room, bool) {
 	item, ok := c.cache.Find(code)
 	if !ok {
@@ -39,3 +54,45 @@ func (c *MemCache) Find(code string) (*Room, bool) {
 	}
 	return room, true
 }
+
+func (c *MemCache) FindBughouse(code string) (*BughouseRoom, bool) {
+	item, ok := c.cache.Find(code)
+	if !ok {
+		return nil, false
+	}
+	room, isRoom := item.(*BughouseRoom)
+	if !isRoom {
+		return nil, false
+	}
+	return room, true
+}
+
+// AddAnalysis returns ErrCodeAlreadyExists if the game of the room is already analyzed.
+func (c *MemCache) AddAnalysis(a GameAnalysis) error {
+	err := c.cache.Add(analysisKey(a.Code), a, time.Now().Add(maximumAnalysisDuration))
+	if err != nil {
+		return ErrCodeAlreadyExists
+	}
+	return nil
+}
+
+func (c *MemCache) SetAnalysis(a GameAnalysis) error {
+	return c.cache.Set(analysisKey(a.Code), a, time.Now().Add(maximumAnalysisDuration))
+}
+
+func (c *MemCache) FindAnalysis(code string) (GameAnalysis, bool) {
+	item, ok := c.cache.Find(analysisKey(code))
+	if !ok {
+		return GameAnalysis{}, false
+	}
+	a, isAnalysis := item.(GameAnalysis)
+	if !isAnalysis {
+		return GameAnalysis{}, false
+	}
+	return a, true
+}
+
+// analysisKey keeps analyses apart from the rooms they were played in
+func analysisKey(code string) string {
+	return "analysis:" + code
+}
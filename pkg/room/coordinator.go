@@ -11,6 +11,7 @@ import (
 
 	"github.com/dyxj/chess/pkg/engine"
 	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/narration"
 	"github.com/dyxj/chess/pkg/safe"
 	"github.com/dyxj/chess/pkg/websocketx"
 	"github.com/gobwas/ws"
@@ -25,12 +26,16 @@ type Coordinator struct {
 	wsm           *websocketx.Manager
 	ticketCache   *TicketCache
 	tokenDuration time.Duration
+	adjourned     AdjournStore
+	// resumeMu serializes rebuilding rooms of adjourned games
+	resumeMu sync.Mutex
 }
 
 func NewCoordinator(
 	logger *zap.Logger,
 	tokenDuration time.Duration,
 	cache *MemCache,
+	adjourned AdjournStore,
 ) *Coordinator {
 	return &Coordinator{
 		logger:        logger,
@@ -38,56 +43,110 @@ func NewCoordinator(
 		wsm:           websocketx.NewManager(logger),
 		ticketCache:   NewTicketCache(),
 		tokenDuration: tokenDuration,
+		adjourned:     adjourned,
 	}
 }
 
 // CreateRoom creates a new room and adds it to the cache.
 // It retries for "createRoomMaxRetries" if the generated code already exists in the cache.
 //
-// Will add game/room configuration as input next
-func (c *Coordinator) CreateRoom() (*Room, error) {
-	room := NewEmptyRoom()
+// cfg is optional, defaults to a standard game.
+// Returns an error wrapping ErrInvalidPosition if the starting position of cfg is invalid.
+func (c *Coordinator) CreateRoom(cfg ...Config) (*Room, error) {
+	var roomCfg Config
+	if len(cfg) > 0 {
+		roomCfg = cfg[0]
+	}
+	room, err := NewRoom(roomCfg)
+	if err != nil {
+		return nil, err
+	}
+	err = c.addWithRetry(
+		func() error { return c.addRoom(room) },
+		func() { room.Code = generateCode() },
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.analyzeOnGameOver(room)
+	return room, nil
+}
+
+// addRoom adds room to the cache, see isReservedCode.
+func (c *Coordinator) addRoom(room *Room) error {
+	if c.isReservedCode(room.Code) {
+		return ErrCodeAlreadyExists
+	}
+	return c.cache.Add(room)
+}
+
+// addWithRetry calls add until it succeeds, regenerating the code
+// for "createRoomMaxRetries" if the code already exists in the cache.
+func (c *Coordinator) addWithRetry(add func() error, regenerateCode func()) error {
 	retry := 0
 	for {
-		err := c.cache.Add(room)
+		err := add()
 		if err == nil {
-			return room, nil
+			return nil
 		}
 
 		if !errors.Is(err, ErrCodeAlreadyExists) {
-			return nil, fmt.Errorf("failed to create room due to unexpected error: %w", err)
+			return fmt.Errorf("failed to create room due to unexpected error: %w", err)
 		}
 
 		if retry >= createRoomMaxRetries {
-			return nil, fmt.Errorf("failed to create room after %d attempts: %w", retry, err)
+			return fmt.Errorf("failed to create room after %d attempts: %w", retry, err)
 		}
 
 		c.logger.Warn("failed to add room to repo, retrying", zap.Error(err), zap.Int("retry", retry))
 		retry++
-		room.Code = generateCode()
+		regenerateCode()
 	}
 }
 
-func (c *Coordinator) IssueTicketToken(code string, name string, color engine.Color) (string, error) {
-	room, exist := c.cache.Find(code)
-	if !exist {
+// IssueTicketToken issues a token to join seat of room code,
+// seat board must be 0 unless the room is a bughouse room.
+func (c *Coordinator) IssueTicketToken(code string, name string, seat Seat) (string, error) {
+	var status Status
+	if room, exist := c.cache.Find(code); exist {
+		if seat.Board != 0 {
+			return "", ErrInvalidSeat
+		}
+		if room.resumed {
+			return "", ErrRoomFull
+		}
+		status = room.Status()
+	} else if bRoom, exist := c.cache.FindBughouse(code); exist {
+		if !isBughouseSeat(seat) {
+			return "", ErrInvalidSeat
+		}
+		status = bRoom.Status()
+	} else {
 		return "", ErrRoomNotFound
 	}
 
-	if room.Status() != StatusWaiting {
+	if status != StatusWaiting {
 		return "", ErrRoomFull
 	}
 
-	token := c.ticketCache.GenerateTicket(code, name, color, c.tokenDuration)
+	token := c.ticketCache.GenerateTicket(code, name, seat, c.tokenDuration)
 
 	return token, nil
 }
 
+// ConnectWithToken opts are optional, see ConnectOptions.
+// Narration and assist are not supported in bughouse rooms.
 func (c *Coordinator) ConnectWithToken(
 	token string,
 	w http.ResponseWriter,
 	r *http.Request,
+	opts ...ConnectOptions,
 ) error {
+	var opt ConnectOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
 	p := NewPlayer("")
 
 	conn, err := c.wsm.Open(p.ID.String(), w, r)
@@ -115,6 +174,10 @@ func (c *Coordinator) ConnectWithToken(
 
 	p.Name = ticket.Name
 
+	if bRoom, exist := c.cache.FindBughouse(ticket.RoomCode); exist {
+		return c.connectBughouse(bRoom, ticket.Seat, p, conn)
+	}
+
 	room, exist := c.cache.Find(ticket.RoomCode)
 	if !exist {
 		c.publishTerminalError(conn, ErrRoomNotFound)
@@ -126,38 +189,63 @@ func (c *Coordinator) ConnectWithToken(
 		return nil
 	}
 
-	if err := room.SetPlayer(ticket.Color, p); err != nil {
+	if err := room.SetPlayer(ticket.Seat.Color, p); err != nil {
 		c.publishTerminalError(conn, err)
 		return nil
 	}
-	defer room.RemovePlayer(ticket.Color)
+	defer room.RemovePlayer(ticket.Seat.Color)
+
+	if opt.Narration != "" {
+		n, err := narration.New(opt.Narration)
+		if err != nil {
+			c.publishTerminalError(conn, err)
+			return nil
+		}
+		room.setNarrator(ticket.Seat.Color, n)
+		defer room.setNarrator(ticket.Seat.Color, nil)
+	}
+	if opt.Assist && room.Variant() == engine.VariantDarkChess {
+		if err := c.publishEventMessage(conn, "Assist is not available in dark chess"); err != nil {
+			return err
+		}
+	} else if opt.Assist {
+		room.setAssist(ticket.Seat.Color, true)
+		defer room.setAssist(ticket.Seat.Color, false)
+	}
 
-	hasBoth := room.setPublisher(ticket.Color, conn)
-	defer room.removePublisher(ticket.Color)
+	hasBoth := room.setPublisher(ticket.Seat.Color, conn)
+	defer room.removePublisher(ticket.Seat.Color)
 
 	logger := c.logger.With(
 		zap.String("player", p.ID.String()),
 		zap.String("room", room.Code),
-		zap.String("color", ticket.Color.String()),
+		zap.String("color", ticket.Seat.Color.String()),
 	)
 	logger.Info("connected to room")
 
 	if !hasBoth {
 		err := c.publishEventMessage(conn, fmt.Sprintf("Waiting for %v player",
-			ticket.Color.Opposite()))
+			ticket.Seat.Color.Opposite()))
 		if err != nil {
 			return err
 		}
 	} else {
 		room.SetStatus(StatusInProgress)
+		if room.resumed {
+			c.deleteAdjourned(room.Code)
+		}
+		if room.IsTimed() {
+			room.Game.StartClock()
+			c.goWatchClock(room)
+		}
 		room.signalReady()
 	}
 
-	err = c.runLoop(room, ticket.Color, conn, logger)
+	err = c.runLoop(room, ticket.Seat.Color, conn, logger)
 	if err != nil {
 		// errors after connection is established
 		// should be communicated via socket
-		c.handleRunLoopError(err, room, ticket.Color, conn, logger)
+		c.handleRunLoopError(err, room, ticket.Seat.Color, conn, logger)
 		return nil
 	}
 
@@ -180,7 +268,11 @@ func (c *Coordinator) runLoop(
 	}
 
 	// initial board state
-	err = c.publishEventRound(ws, room.Game.Round())
+	err = c.publishEventRound(ws, room.Game.Round().For(color), room.narrator(color))
+	if err != nil {
+		return err
+	}
+	err = c.publishEventHint(ws, room, color)
 	if err != nil {
 		return err
 	}
@@ -225,8 +317,17 @@ func (c *Coordinator) publishEventMessage(p websocketPublisher, msg string) erro
 	return nil
 }
 
-func (c *Coordinator) publishEventRound(p websocketPublisher, round game.RoundResult) error {
-	e := NewEventRound(round)
+// publishEventRound n is nil unless the recipient opted in to narration.
+func (c *Coordinator) publishEventRound(
+	p websocketPublisher,
+	round game.RoundResult,
+	n *narration.Narrator,
+) error {
+	var text string
+	if n != nil {
+		text = n.Round(round)
+	}
+	e := NewEventRound(round, text)
 	err := p.PublishJson(e)
 	if err != nil {
 		return err
@@ -235,7 +336,7 @@ func (c *Coordinator) publishEventRound(p websocketPublisher, round game.RoundRe
 }
 
 func (c *Coordinator) publishEventRoomReady(p websocketPublisher, room *Room) error {
-	e := NewEventRoomReady(room.whitePlayer.Name, room.blackPlayer.Name)
+	e := NewEventRoomReady(room.whitePlayer.Name, room.blackPlayer.Name, room.Variant(), room.StartFEN())
 	err := p.PublishJson(e)
 	if err != nil {
 		return err
@@ -323,7 +424,11 @@ func (c *Coordinator) goProcessRoundResults(
 					defer safe.RecoverWithLog(lg, "goProcessRoundResults:broadcast")()
 					defer wg.Done()
 
-					err := c.publishEventRound(pub, roundResult)
+					// each color only receives its own view in dark chess
+					err := c.publishEventRound(pub, roundResult.For(pColor), room.narrator(pColor))
+					if err == nil && !roundResult.State.IsGameOver() {
+						err = c.publishEventHint(pub, room, pColor)
+					}
 					if err != nil {
 						if pColor == color {
 							errColor = fmt.Errorf("round result broadcast failed: %w", err)
@@ -382,13 +487,22 @@ func (c *Coordinator) goConsumeLoop(
 				}
 
 				switch partial.Type {
-				case ActionTypeMove:
-					err = c.processAction(room, color, partial.Payload, roundResultChan, ws, logger)
-					if err != nil {
-						logger.Error("failed to process action", zap.Error(err))
-						errChan <- err
-						return
-					}
+				case ActionTypeMove, ActionTypeDrop:
+					err = c.processAction(room, color, partial, roundResultChan, ws, logger)
+				case ActionTypeAdjourn, ActionTypeAdjournAccept:
+					err = c.processAdjournAction(room, color, partial.Type, ws, logger)
+				case ActionTypeDescribe:
+					err = c.processDescribeAction(room, color, partial, ws)
+				case ActionTypeResign:
+					err = c.processResignAction(room, color, ws)
+				case ActionTypeAbort:
+					err = c.processAbortAction(room, color, ws)
+				default:
+				}
+				if err != nil {
+					logger.Error("failed to process action", zap.Error(err))
+					errChan <- err
+					return
 				}
 			}
 		}
@@ -400,13 +514,16 @@ func (c *Coordinator) goConsumeLoop(
 func (c *Coordinator) processAction(
 	room *Room,
 	color engine.Color,
-	data json.RawMessage,
+	partial ActionPartial,
 	roundResultChan chan<- game.RoundResult,
 	pub websocketPublisher,
 	logger *zap.Logger,
 ) error {
-	var payload ActionMovePayload
-	err := json.Unmarshal(data, &payload)
+	var payload moveAction = &ActionMovePayload{}
+	if partial.Type == ActionTypeDrop {
+		payload = &ActionDropPayload{}
+	}
+	err := json.Unmarshal(partial.Payload, payload)
 	if err != nil {
 		return err
 	}
@@ -439,7 +556,7 @@ func (c *Coordinator) processAction(
 func (c *Coordinator) processMoveAction(
 	room *Room,
 	color engine.Color,
-	payload ActionMovePayload,
+	payload moveAction,
 	roundResultChan chan<- game.RoundResult,
 	pub websocketPublisher,
 ) error {
@@ -451,9 +568,13 @@ func (c *Coordinator) processMoveAction(
 		return nil
 	}
 
-	result, err := room.Game.ApplyMove(
+	result, err := room.applyMove(
 		payload.ToMove(color),
 	)
+	if errors.Is(err, game.ErrTimeout) {
+		c.broadcastTimeout(room, color)
+		return nil
+	}
 	if err != nil {
 		pErr := c.publishEventError(pub, room.Game.Round().Count, err)
 		if pErr != nil {
@@ -476,6 +597,15 @@ func (c *Coordinator) handleRunLoopError(
 ) {
 	c.resignAndNotifyOpponent(room, color)
 
+	c.logRunLoopError(err, statusWriter, logger)
+}
+
+// logRunLoopError logs err by its cause, writing a close status if the client did not close.
+func (c *Coordinator) logRunLoopError(
+	err error,
+	statusWriter websocketCloseStatusWriter,
+	logger *zap.Logger,
+) {
 	if websocketx.IsNetworkClosedError(err) {
 		logger.Info("network closed", zap.Error(err))
 		return
@@ -514,7 +644,11 @@ func (c *Coordinator) resignAndNotifyOpponent(room *Room, color engine.Color) {
 		return
 	}
 
-	err := room.Game.Resign(color)
+	err := room.resign(color)
+	if errors.Is(err, ErrRoomNotInProgress) {
+		// not started or adjourned, the saved game must stay as it was
+		return
+	}
 	if err != nil {
 		c.logger.Error("failed to resign game after websocket closed",
 			zap.String("room", room.Code),
@@ -567,3 +701,8 @@ type websocketPublisherConsumer interface {
 type websocketCloseStatusWriter interface {
 	WriteCloseStatusCode(code ws.StatusCode, message string) error
 }
+
+type websocketConn interface {
+	websocketPublisherConsumer
+	websocketCloseStatusWriter
+}
@@ -0,0 +1,126 @@
+package room
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"go.uber.org/zap"
+)
+
+var errAdjournFailed = errors.New("failed to adjourn game")
+
+// processAdjournAction offers to adjourn the game, or accepts the offer of the opponent.
+// Rejected offers and accepts are reported to pub as error events.
+func (c *Coordinator) processAdjournAction(
+	room *Room,
+	color engine.Color,
+	actionType ActionType,
+	pub websocketPublisher,
+	logger *zap.Logger,
+) error {
+	if actionType == ActionTypeAdjourn {
+		if err := room.offerAdjourn(color); err != nil {
+			return c.publishEventError(pub, room.Game.Round().Count, err)
+		}
+		opponentPub, exist := room.publisher(color.Opposite())
+		if !exist {
+			return nil
+		}
+		if err := opponentPub.PublishJson(NewEventAdjournOffer(color)); err != nil {
+			logger.Warn("failed to publish adjourn offer", zap.Error(err))
+		}
+		return nil
+	}
+
+	if err := room.acceptAdjourn(color); err != nil {
+		return c.publishEventError(pub, room.Game.Round().Count, err)
+	}
+	if err := c.adjourn(room, logger); err != nil {
+		logger.Error("failed to adjourn game", zap.Error(err))
+		room.SetStatus(StatusInProgress)
+		return c.publishEventError(pub, room.Game.Round().Count, errAdjournFailed)
+	}
+	return nil
+}
+
+// adjourn saves the game of room and sends each player its resume key.
+// The room is removed and its connections are released.
+func (c *Coordinator) adjourn(room *Room, logger *zap.Logger) error {
+	ag := room.adjournedGame()
+	keys := make(map[engine.Color]string, len(engine.Colors))
+	for _, color := range engine.Colors {
+		keys[color] = generateSecretToken()
+		p := ag.Players[color]
+		p.KeyHash = hashToken(keys[color])
+		ag.Players[color] = p
+	}
+
+	if err := c.adjourned.Save(ag); err != nil {
+		return err
+	}
+	c.cache.Delete(room.Code)
+
+	for color, pub := range room.publishers() {
+		if err := pub.PublishJson(NewEventAdjourned(room.Code, keys[color])); err != nil {
+			logger.Warn("failed to publish adjourned event",
+				zap.String("recipient", color.String()),
+				zap.Error(err))
+		}
+	}
+	logger.Info("room adjourned", zap.Int("moves", len(ag.Moves)))
+
+	room.signalGameOver()
+	return nil
+}
+
+// ResumeTicketToken issues a token to reconnect to adjourned game code, authenticated by
+// the key sent when the game was adjourned. The room is rebuilt by the first player resuming,
+// the adjourned game is deleted once both players are connected.
+func (c *Coordinator) ResumeTicketToken(code string, key string) (string, error) {
+	c.resumeMu.Lock()
+	defer c.resumeMu.Unlock()
+
+	ag, ok := c.adjourned.Find(code)
+	if !ok {
+		return "", ErrRoomNotFound
+	}
+	color, ok := ag.authenticate(key)
+	if !ok {
+		return "", ErrInvalidToken
+	}
+
+	room, exist := c.cache.Find(code)
+	if !exist {
+		var err error
+		room, err = newResumedRoom(ag)
+		if err != nil {
+			return "", err
+		}
+		if err := c.cache.Add(room); err != nil {
+			return "", fmt.Errorf("failed to resume room: %w", err)
+		}
+		c.analyzeOnGameOver(room)
+	}
+
+	if !room.resumed || room.Status() != StatusWaiting {
+		return "", ErrRoomFull
+	}
+
+	return c.ticketCache.GenerateTicket(code, ag.Players[color].Name, Seat{Color: color}, c.tokenDuration), nil
+}
+
+func (c *Coordinator) isAdjournedCode(code string) bool {
+	_, exist := c.adjourned.Find(code)
+	return exist
+}
+
+// deleteAdjourned failures are only logged, the game continues and can not be
+// resumed again while the room is cached as it is no longer waiting.
+func (c *Coordinator) deleteAdjourned(code string) {
+	if err := c.adjourned.Delete(code); err != nil {
+		c.logger.Error("failed to delete adjourned game",
+			zap.String("room", code),
+			zap.Error(err))
+	}
+}
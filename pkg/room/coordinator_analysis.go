@@ -0,0 +1,90 @@
+package room
+
+import (
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/safe"
+	"go.uber.org/zap"
+)
+
+// analysisDepth search depth of each position of a post-game analysis
+const analysisDepth = 3
+
+// analyzeOnGameOver starts the analysis of the game of room once it is over,
+// however it ends unless it is aborted. Only standard games are analyzed.
+func (c *Coordinator) analyzeOnGameOver(room *Room) {
+	if room.Variant() != engine.VariantStandard {
+		return
+	}
+	room.Game.OnStateChange(func(r game.RoundResult) {
+		if r.State.IsGameOver() && r.State != game.StateAborted {
+			c.goAnalyze(room)
+		}
+	})
+}
+
+// goAnalyze analyzes the game of room in the background, the analysis is pending until it is done.
+// A game is only analyzed once.
+func (c *Coordinator) goAnalyze(room *Room) {
+	if err := c.cache.AddAnalysis(GameAnalysis{Code: room.Code, Status: AnalysisPending}); err != nil {
+		return
+	}
+
+	logger := c.logger.With(zap.String("room", room.Code))
+	go func() {
+		defer safe.RecoverWithLog(logger, "goAnalyze")()
+
+		result := GameAnalysis{Code: room.Code, Status: AnalysisFailed}
+		defer func() {
+			if err := c.cache.SetAnalysis(result); err != nil {
+				logger.Error("failed to store analysis", zap.Error(err))
+			}
+		}()
+
+		tree, err := room.Game.Tree()
+		if err != nil {
+			logger.Error("failed to analyze game", zap.Error(err))
+			return
+		}
+		a, err := tree.Analyze(game.AnalysisOptions{Depth: analysisDepth})
+		if err != nil {
+			logger.Error("failed to analyze game", zap.Error(err))
+			return
+		}
+		result.Status = AnalysisReady
+		result.Analysis = &a
+	}()
+}
+
+// isReservedCode codes of adjourned games are reserved until they are resumed and
+// codes of analyzed games until their analysis expires, so a new room can not take them over.
+func (c *Coordinator) isReservedCode(code string) bool {
+	if c.isAdjournedCode(code) {
+		return true
+	}
+	_, analyzed := c.cache.FindAnalysis(code)
+	return analyzed
+}
+
+// Analysis post-game analysis of room code. Returns ErrGameNotOver while the game is played,
+// ErrGameAborted for aborted games and game.ErrAnalysisUnavailable if the variant of the room
+// can not be analyzed.
+func (c *Coordinator) Analysis(code string) (GameAnalysis, error) {
+	if a, ok := c.cache.FindAnalysis(code); ok {
+		return a, nil
+	}
+	if _, exist := c.cache.FindBughouse(code); exist {
+		return GameAnalysis{}, game.ErrAnalysisUnavailable
+	}
+	room, exist := c.cache.Find(code)
+	if !exist {
+		return GameAnalysis{}, ErrRoomNotFound
+	}
+	if room.Variant() != engine.VariantStandard {
+		return GameAnalysis{}, game.ErrAnalysisUnavailable
+	}
+	if room.Game.State() == game.StateAborted {
+		return GameAnalysis{}, ErrGameAborted
+	}
+	return GameAnalysis{}, ErrGameNotOver
+}
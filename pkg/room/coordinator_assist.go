@@ -0,0 +1,18 @@
+package room
+
+import (
+	"github.com/dyxj/chess/pkg/engine"
+)
+
+// publishEventHint publishes a hint for color to its own publisher only,
+// nothing is published unless color opted in to assist.
+func (c *Coordinator) publishEventHint(p websocketPublisher, room *Room, color engine.Color) error {
+	if !room.assisted(color) {
+		return nil
+	}
+	h, err := room.Game.Hint(color)
+	if err != nil {
+		return err
+	}
+	return p.PublishJson(NewEventHint(h))
+}
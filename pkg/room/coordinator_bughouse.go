@@ -0,0 +1,331 @@
+package room
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/safe"
+	"go.uber.org/zap"
+)
+
+// CreateBughouseRoom creates a new bughouse room and adds it to the cache.
+func (c *Coordinator) CreateBughouseRoom() (*BughouseRoom, error) {
+	room := NewEmptyBughouseRoom()
+	err := c.addWithRetry(
+		func() error {
+			if c.isReservedCode(room.Code) {
+				return ErrCodeAlreadyExists
+			}
+			return c.cache.AddBughouse(room)
+		},
+		func() { room.Code = generateCode() },
+	)
+	if err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+func (c *Coordinator) connectBughouse(
+	room *BughouseRoom,
+	seat Seat,
+	p *Player,
+	conn websocketConn,
+) error {
+	if room.Status() != StatusWaiting {
+		c.publishTerminalError(conn, ErrRoomFull)
+		return nil
+	}
+
+	if err := room.SetPlayer(seat, p); err != nil {
+		c.publishTerminalError(conn, err)
+		return nil
+	}
+	defer room.RemovePlayer(seat)
+
+	hasAll := room.setPublisher(seat, conn)
+	defer room.removePublisher(seat)
+
+	logger := c.logger.With(
+		zap.String("player", p.ID.String()),
+		zap.String("room", room.Code),
+		zap.Int("board", seat.Board),
+		zap.String("color", seat.Color.String()),
+	)
+	logger.Info("connected to bughouse room")
+
+	if !hasAll {
+		err := room.publish(func() error {
+			return c.publishEventMessage(conn, "Waiting for players")
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		room.SetStatus(StatusInProgress)
+		room.signalReady()
+	}
+
+	err := c.runBughouseLoop(room, seat, conn, logger)
+	if err != nil {
+		c.resignBughouse(room, seat)
+		c.logRunLoopError(err, conn, logger)
+		return nil
+	}
+
+	return nil
+}
+
+func (c *Coordinator) runBughouseLoop(
+	room *BughouseRoom,
+	seat Seat,
+	ws websocketPublisherConsumer,
+	logger *zap.Logger,
+) error {
+	consumeErrChan := c.goConsumeBughouseLoop(room, seat, ws, logger)
+
+	<-room.readyChan
+
+	// room ready and initial state of both boards
+	err := room.publish(func() error {
+		if err := ws.PublishJson(NewEventBughouseReady(seat, room.seatPlayers())); err != nil {
+			return err
+		}
+		for board := range game.BughouseBoards {
+			g, _ := room.Match.Game(board)
+			if err := ws.PublishJson(NewEventBughouseRound(board, g.Round())); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ws.Context().Done():
+			logger.Debug("exiting run loop, websocket context closed")
+			return nil
+		case consumeErr, ok := <-consumeErrChan:
+			if !ok {
+				consumeErrChan = nil
+				continue
+			}
+			logger.Debug("exiting run loop, due to consume error")
+			return consumeErr
+		case <-room.gameOverChan:
+			logger.Debug("exiting run loop, due to game over")
+			return nil
+		}
+	}
+}
+
+func (c *Coordinator) goConsumeBughouseLoop(
+	room *BughouseRoom,
+	seat Seat,
+	ws websocketPublisherConsumer,
+	logger *zap.Logger,
+) <-chan error {
+	errChan := make(chan error)
+	go func() {
+		defer safe.RecoverWithLog(logger, "goConsumeBughouseLoop")()
+		defer logger.Debug("consume loop exited")
+		defer close(errChan)
+
+		for {
+			select {
+			case <-ws.Context().Done():
+				logger.Info("websocket context closed")
+				return
+
+			default:
+				var partial ActionPartial
+				err := ws.ConsumeJson(&partial)
+				if err != nil {
+					errChan <- fmt.Errorf("consume action failed: %w", err)
+					return
+				}
+
+				switch partial.Type {
+				case ActionTypeMove, ActionTypeDrop, ActionTypeChat:
+					err = c.processBughouseAction(room, seat, partial, ws, logger)
+					if err != nil {
+						logger.Error("failed to process action", zap.Error(err))
+						errChan <- err
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return errChan
+}
+
+func (c *Coordinator) processBughouseAction(
+	room *BughouseRoom,
+	seat Seat,
+	partial ActionPartial,
+	pub websocketPublisher,
+	logger *zap.Logger,
+) error {
+	if room.Status() != StatusInProgress {
+		logger.Debug("discarding input as room is not in progress")
+		return room.publish(func() error {
+			return c.publishEventMessage(pub, "Discarding input as room is not in progress")
+		})
+	}
+
+	if partial.Type == ActionTypeChat {
+		var payload ActionChatPayload
+		if err := json.Unmarshal(partial.Payload, &payload); err != nil {
+			return err
+		}
+		return c.processChatAction(room, seat, payload, pub)
+	}
+
+	var payload moveAction = &ActionMovePayload{}
+	if partial.Type == ActionTypeDrop {
+		payload = &ActionDropPayload{}
+	}
+	if err := json.Unmarshal(partial.Payload, payload); err != nil {
+		return err
+	}
+	logger.Debug("processing move action", zap.Any("payload", payload))
+
+	return c.processBughouseMoveAction(room, seat, payload, pub)
+}
+
+// processChatAction sends the message to the partner of seat,
+// it is dropped if the partner is not connected.
+func (c *Coordinator) processChatAction(
+	room *BughouseRoom,
+	seat Seat,
+	payload ActionChatPayload,
+	pub websocketPublisher,
+) error {
+	g, _ := room.Match.Game(seat.Board)
+
+	return room.publish(func() error {
+		if err := payload.Validate(); err != nil {
+			return c.publishEventError(pub, g.Round().Count, err)
+		}
+
+		partnerPub, ok := room.publisher(seat.Partner())
+		if !ok {
+			return nil
+		}
+
+		var name string
+		if p := room.Player(seat); p != nil {
+			name = p.Name
+		}
+		err := partnerPub.PublishJson(NewEventChat(seat, name, payload.Message))
+		if err != nil {
+			c.logger.Warn("failed to publish chat to partner",
+				zap.String("room", room.Code),
+				zap.Error(err))
+		}
+		return nil
+	})
+}
+
+func (c *Coordinator) processBughouseMoveAction(
+	room *BughouseRoom,
+	seat Seat,
+	payload moveAction,
+	pub websocketPublisher,
+) error {
+	g, _ := room.Match.Game(seat.Board)
+
+	return room.publish(func() error {
+		if err := payload.Validate(); err != nil {
+			return c.publishEventError(pub, g.Round().Count, err)
+		}
+
+		result, err := room.Match.ApplyMove(seat.Board, payload.ToMove(seat.Color))
+		if err != nil {
+			return c.publishEventError(pub, g.Round().Count, err)
+		}
+
+		err = c.broadcastBughouse(room, seat, NewEventBughouseRound(result.Board, result.Round))
+		if err != nil {
+			return err
+		}
+
+		if result.PartnerRound != nil {
+			partnerEvent := NewEventBughouseRound(1-result.Board, *result.PartnerRound)
+			if err := c.broadcastBughouse(room, seat, partnerEvent); err != nil {
+				return err
+			}
+		}
+
+		if board, over := room.Match.Over(); over {
+			defer room.signalGameOver()
+			return c.broadcastBughouse(room, seat, c.matchOverEvent(room, board))
+		}
+
+		return nil
+	})
+}
+
+// broadcastBughouse publishes e to all seats sequentially, caller must hold room.publishMu.
+// Only an error publishing to the acting seat is returned, others are logged.
+func (c *Coordinator) broadcastBughouse(room *BughouseRoom, acting Seat, e Event) error {
+	var actingErr error
+	for seat, pub := range room.publishers() {
+		err := pub.PublishJson(e)
+		if err == nil {
+			continue
+		}
+		if seat == acting {
+			actingErr = fmt.Errorf("bughouse broadcast failed: %w", err)
+			continue
+		}
+		c.logger.Warn("failed to publish bughouse event",
+			zap.String("room", room.Code),
+			zap.Stringer("seat", seat),
+			zap.Error(err))
+	}
+	return actingErr
+}
+
+// matchOverEvent winners are the winner of board and their partner
+func (c *Coordinator) matchOverEvent(room *BughouseRoom, board int) Event {
+	g, _ := room.Match.Game(board)
+	state := g.State()
+
+	var winners []Seat
+	if !g.IsDraw() {
+		winner := Seat{Board: board, Color: g.Winner()}
+		winners = []Seat{winner, winner.Partner()}
+	}
+
+	return NewEventMatchOver(board, state, winners)
+}
+
+// resignBughouse resigns seat and notifies the remaining players the match is over.
+func (c *Coordinator) resignBughouse(room *BughouseRoom, seat Seat) {
+	if _, over := room.Match.Over(); over {
+		return
+	}
+
+	err := room.Match.Resign(seat.Board, seat.Color)
+	if err != nil {
+		c.logger.Error("failed to resign bughouse match after websocket closed",
+			zap.String("room", room.Code),
+			zap.Stringer("seat", seat),
+			zap.Error(err))
+		return
+	}
+
+	_ = room.publish(func() error {
+		// acting seat is disconnected, publish errors are only logged
+		return c.broadcastBughouse(room, Seat{}, c.matchOverEvent(room, seat.Board))
+	})
+
+	room.signalGameOver()
+}
@@ -0,0 +1,43 @@
+package room
+
+import (
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/safe"
+	"go.uber.org/zap"
+)
+
+// goWatchClock flags the side to move as soon as its time runs out.
+// The timer is reset after every move and the watcher exits when the game is over.
+func (c *Coordinator) goWatchClock(room *Room) {
+	logger := c.logger.With(zap.String("room", room.Code))
+
+	go func() {
+		defer safe.RecoverWithLog(logger, "goWatchClock")()
+		defer logger.Debug("clock watcher exited")
+
+		timer := time.NewTimer(room.Game.Remaining(room.Game.ActiveColor()))
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-room.gameOverChan:
+				return
+			case <-room.clockChan:
+			case <-timer.C:
+				if room.Game.CheckTimeout() {
+					// no move can be played once flagged, active color is the flagged color
+					c.broadcastTimeout(room, room.Game.ActiveColor())
+					return
+				}
+			}
+			timer.Reset(room.Game.Remaining(room.Game.ActiveColor()))
+		}
+	}()
+}
+
+// broadcastTimeout notifies both players that flagged ran out of time and ends the room.
+func (c *Coordinator) broadcastTimeout(room *Room, flagged engine.Color) {
+	c.broadcastGameOver(room, NewEventTimeout(flagged, room.Game.State(), room.Game.Winner()))
+}
@@ -0,0 +1,43 @@
+package room
+
+import (
+	"encoding/json"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/narration"
+)
+
+// processDescribeAction describes the position as seen by color, in the language
+// color opted in to or English. Invalid payloads are reported to pub as error events.
+func (c *Coordinator) processDescribeAction(
+	room *Room,
+	color engine.Color,
+	partial ActionPartial,
+	pub websocketPublisher,
+) error {
+	var payload ActionDescribePayload
+	if len(partial.Payload) > 0 {
+		if err := json.Unmarshal(partial.Payload, &payload); err != nil {
+			return err
+		}
+	}
+	if err := payload.Validate(); err != nil {
+		return c.publishEventError(pub, room.Game.Round().Count, err)
+	}
+
+	n := room.narrator(color)
+	if n == nil {
+		var err error
+		n, err = narration.New(narration.English)
+		if err != nil {
+			return err
+		}
+	}
+
+	grid := room.Game.Round().For(color).Grid
+	text := n.PositionByPiece(grid)
+	if payload.By == DescribeByRank {
+		text = n.PositionByRank(grid)
+	}
+	return pub.PublishJson(NewEventDescription(text))
+}
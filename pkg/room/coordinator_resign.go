@@ -0,0 +1,42 @@
+package room
+
+import (
+	"github.com/dyxj/chess/pkg/engine"
+	"go.uber.org/zap"
+)
+
+// processResignAction resigns the game for color and confirms it to both players.
+// Resigning while the room is not in progress is reported to pub as an error event.
+func (c *Coordinator) processResignAction(room *Room, color engine.Color, pub websocketPublisher) error {
+	if err := room.resign(color); err != nil {
+		return c.publishEventError(pub, room.Game.Round().Count, err)
+	}
+	c.broadcastGameOver(room, NewEventResign(color))
+	return nil
+}
+
+// processAbortAction calls off the game and confirms it to both players.
+// Aborting after both sides moved is reported to pub as an error event.
+func (c *Coordinator) processAbortAction(room *Room, color engine.Color, pub websocketPublisher) error {
+	if err := room.abort(); err != nil {
+		return c.publishEventError(pub, room.Game.Round().Count, err)
+	}
+	c.broadcastGameOver(room, NewEventAbort(color))
+	return nil
+}
+
+// broadcastGameOver publishes e to both players and ends the room.
+// Publish errors are logged, the run loops exit on game over regardless.
+func (c *Coordinator) broadcastGameOver(room *Room, e Event) {
+	for color, pub := range room.publishers() {
+		if err := pub.PublishJson(e); err != nil {
+			c.logger.Warn("failed to publish game over event",
+				zap.String("room", room.Code),
+				zap.String("color", color.String()),
+				zap.String("event", string(e.EventType)),
+				zap.Error(err))
+		}
+	}
+
+	room.signalGameOver()
+}
@@ -0,0 +1,349 @@
+package room
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/errorx"
+	"github.com/dyxj/chess/pkg/game"
+	"go.uber.org/zap"
+)
+
+const (
+	CorrespondenceMinDaysPerMove = 1
+	CorrespondenceMaxDaysPerMove = 30
+)
+
+// CorrespondenceGame persisted state of a correspondence game.
+// The game is rebuilt from FEN by replaying Moves on every request.
+type CorrespondenceGame struct {
+	Code        string         `json:"code"`
+	Variant     engine.Variant `json:"variant"`
+	FEN         string         `json:"fen"`
+	DaysPerMove int            `json:"daysPerMove"`
+	// Rules the game is replayed with, untimed
+	Rules   game.Rules                            `json:"rules"`
+	Players map[engine.Color]CorrespondencePlayer `json:"players"`
+	Moves   []string                              `json:"moves"`
+	// Deadline of the side to move, zero until both players joined or once the game is over
+	Deadline time.Time `json:"deadline"`
+	// Forfeited color that missed its deadline, 0 if none
+	Forfeited   engine.Color `json:"forfeited,omitempty"`
+	CreatedTime time.Time    `json:"createdTime"`
+}
+
+// CorrespondencePlayer only the hash of the token is persisted
+type CorrespondencePlayer struct {
+	Name      string `json:"name"`
+	TokenHash string `json:"tokenHash"`
+}
+
+// CorrespondenceView current position and move list as seen by Color
+type CorrespondenceView struct {
+	Code        string                  `json:"code"`
+	Variant     engine.Variant          `json:"variant"`
+	FEN         string                  `json:"fen"`
+	DaysPerMove int                     `json:"daysPerMove"`
+	Color       engine.Color            `json:"color"`
+	Players     map[engine.Color]string `json:"players"`
+	Moves       []string                `json:"moves"`
+	Deadline    *time.Time              `json:"deadline,omitempty"`
+	Round       game.RoundResult        `json:"round"`
+}
+
+// CorrespondenceStore persists correspondence games between moves.
+type CorrespondenceStore interface {
+	// Add returns ErrCodeAlreadyExists if the code is taken
+	Add(cg CorrespondenceGame) error
+	Find(code string) (CorrespondenceGame, bool)
+	// Update returns ErrRoomNotFound if the code does not exist
+	Update(cg CorrespondenceGame) error
+}
+
+// Correspondence games played over REST, each move has to be played within DaysPerMove days.
+// A player missing the deadline forfeits, deadlines are enforced whenever the game is accessed.
+type Correspondence struct {
+	// mu serializes read, replay and update of games
+	mu     sync.Mutex
+	logger *zap.Logger
+	store  CorrespondenceStore
+	ts     game.TimeSource
+}
+
+// NewCorrespondence ts is optional, defaults to game.SystemTime.
+func NewCorrespondence(
+	logger *zap.Logger,
+	store CorrespondenceStore,
+	ts ...game.TimeSource,
+) *Correspondence {
+	var source game.TimeSource = game.SystemTime{}
+	if len(ts) > 0 {
+		source = ts[0]
+	}
+	return &Correspondence{
+		logger: logger,
+		store:  store,
+		ts:     source,
+	}
+}
+
+// Create validates the starting position of cfg and stores a new game played with cfg.Rules,
+// timed rules, bughouse and dark chess are not supported.
+func (c *Correspondence) Create(cfg Config, daysPerMove int) (CorrespondenceGame, error) {
+	if daysPerMove < CorrespondenceMinDaysPerMove || daysPerMove > CorrespondenceMaxDaysPerMove {
+		return CorrespondenceGame{}, fmt.Errorf("%w: must be between %d and %d",
+			ErrInvalidDaysPerMove, CorrespondenceMinDaysPerMove, CorrespondenceMaxDaysPerMove)
+	}
+	if cfg.Variant == engine.VariantBughouse || cfg.Variant == engine.VariantDarkChess {
+		return CorrespondenceGame{}, fmt.Errorf("%w: %v", ErrUnsupportedVariant, cfg.Variant)
+	}
+	if cfg.Rules.TimeControl.IsTimed() {
+		return CorrespondenceGame{}, fmt.Errorf("%w: correspondence games use days per move",
+			game.ErrInvalidTimeControl)
+	}
+
+	b, err := cfg.board()
+	if err != nil {
+		return CorrespondenceGame{}, err
+	}
+
+	cg := CorrespondenceGame{
+		Code:        generateCode(),
+		Variant:     cfg.Variant,
+		FEN:         b.FEN(),
+		DaysPerMove: daysPerMove,
+		Rules:       cfg.Rules,
+		Players:     map[engine.Color]CorrespondencePlayer{},
+		Moves:       []string{},
+		CreatedTime: c.ts.Now(),
+	}
+
+	for retry := 0; ; retry++ {
+		err = c.store.Add(cg)
+		if err == nil {
+			return cg, nil
+		}
+		if !errors.Is(err, ErrCodeAlreadyExists) || retry >= createRoomMaxRetries {
+			return CorrespondenceGame{}, fmt.Errorf("failed to create correspondence game: %w", err)
+		}
+		cg.Code = generateCode()
+	}
+}
+
+// Join takes color in game code and returns the token authenticating the player.
+// The deadline of the first move starts once both players joined.
+// Returns an *errorx.ValidationError if color is not white or black.
+func (c *Correspondence) Join(code string, name string, color engine.Color) (string, error) {
+	if err := validateCorrespondenceColor(color); err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cg, ok := c.store.Find(code)
+	if !ok {
+		return "", ErrRoomNotFound
+	}
+	if _, taken := cg.Players[color]; taken {
+		return "", ErrColorOccupied
+	}
+
+	token := generateSecretToken()
+	cg.Players[color] = CorrespondencePlayer{
+		Name:      strings.TrimSpace(name),
+		TokenHash: hashToken(token),
+	}
+	if len(cg.Players) == len(engine.Colors) {
+		cg.Deadline = c.deadline(cg.DaysPerMove)
+	}
+
+	if err := c.store.Update(cg); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func validateCorrespondenceColor(color engine.Color) *errorx.ValidationError {
+	if !slices.Contains(engine.Colors, color) {
+		return &errorx.ValidationError{
+			Properties: map[string]string{"color": "color must be white or black"},
+		}
+	}
+	return nil
+}
+
+// Move plays move in file rank notation, see game.Game.ApplyMoveWithFileRank,
+// for the player authenticated by token.
+func (c *Correspondence) Move(code string, token string, move string) (CorrespondenceView, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cg, color, g, err := c.load(code, token)
+	if err != nil {
+		return CorrespondenceView{}, err
+	}
+
+	if len(cg.Players) < len(engine.Colors) {
+		return CorrespondenceView{}, ErrWaitingForOpponent
+	}
+	if g.State().IsGameOver() {
+		return CorrespondenceView{}, fmt.Errorf("%w: game is already over", game.ErrInvalidMove)
+	}
+	if color != g.ActiveColor() {
+		return CorrespondenceView{}, engine.ErrNotActiveColor
+	}
+
+	move = strings.ReplaceAll(move, " ", "")
+	if _, err := g.ApplyMoveWithFileRank(move); err != nil {
+		return CorrespondenceView{}, err
+	}
+
+	cg.Moves = append(cg.Moves, move)
+	cg.Deadline = c.deadline(cg.DaysPerMove)
+	if g.State().IsGameOver() {
+		cg.Deadline = time.Time{}
+	}
+	if err := c.store.Update(cg); err != nil {
+		return CorrespondenceView{}, err
+	}
+
+	return cg.view(color, g), nil
+}
+
+// View current position and move list for the player authenticated by token.
+func (c *Correspondence) View(code string, token string) (CorrespondenceView, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cg, color, g, err := c.load(code, token)
+	if err != nil {
+		return CorrespondenceView{}, err
+	}
+	return cg.view(color, g), nil
+}
+
+// load finds game code, authenticates token and rebuilds the game.
+// The side to move forfeits if its deadline has passed.
+func (c *Correspondence) load(code string, token string) (CorrespondenceGame, engine.Color, *game.Game, error) {
+	cg, ok := c.store.Find(code)
+	if !ok {
+		return CorrespondenceGame{}, 0, nil, ErrRoomNotFound
+	}
+
+	color, ok := cg.authenticate(token)
+	if !ok {
+		return CorrespondenceGame{}, 0, nil, ErrInvalidToken
+	}
+
+	g, err := cg.game()
+	if err != nil {
+		return CorrespondenceGame{}, 0, nil, err
+	}
+
+	if !cg.Deadline.IsZero() && c.ts.Now().After(cg.Deadline) && !g.State().IsGameOver() {
+		cg.Forfeited = g.ActiveColor()
+		cg.Deadline = time.Time{}
+		if err := g.Forfeit(cg.Forfeited); err != nil {
+			return CorrespondenceGame{}, 0, nil, err
+		}
+		if err := c.store.Update(cg); err != nil {
+			return CorrespondenceGame{}, 0, nil, err
+		}
+		c.logger.Info("correspondence game forfeited on time",
+			zap.String("code", cg.Code),
+			zap.String("color", cg.Forfeited.String()))
+	}
+
+	return cg, color, g, nil
+}
+
+// deadline of the next move, days from now
+func (c *Correspondence) deadline(days int) time.Time {
+	return c.ts.Now().Add(time.Duration(days) * 24 * time.Hour)
+}
+
+// game rebuilt from the starting position and moves
+func (cg CorrespondenceGame) game() (*game.Game, error) {
+	b, err := engine.NewBoardFromFEN(cg.FEN)
+	if err != nil {
+		return nil, fmt.Errorf("correspondence game %s has invalid fen: %w", cg.Code, err)
+	}
+	b.SetVariant(cg.Variant)
+
+	g := game.NewGame(b, cg.Rules)
+	for i, m := range cg.Moves {
+		if _, err := g.ApplyMoveWithFileRank(m); err != nil {
+			return nil, fmt.Errorf("correspondence game %s failed to replay move %d %s: %w", cg.Code, i+1, m, err)
+		}
+	}
+	if cg.Forfeited != 0 {
+		if err := g.Forfeit(cg.Forfeited); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+func (cg CorrespondenceGame) authenticate(token string) (engine.Color, bool) {
+	hash := hashToken(token)
+	for _, color := range engine.Colors {
+		p, ok := cg.Players[color]
+		if ok && subtle.ConstantTimeCompare([]byte(p.TokenHash), []byte(hash)) == 1 {
+			return color, true
+		}
+	}
+	return 0, false
+}
+
+func (cg CorrespondenceGame) view(color engine.Color, g *game.Game) CorrespondenceView {
+	players := make(map[engine.Color]string, len(cg.Players))
+	for c, p := range cg.Players {
+		players[c] = p.Name
+	}
+
+	var deadline *time.Time
+	if !cg.Deadline.IsZero() {
+		deadline = new(cg.Deadline)
+	}
+
+	return CorrespondenceView{
+		Code:        cg.Code,
+		Variant:     cg.Variant,
+		FEN:         cg.FEN,
+		DaysPerMove: cg.DaysPerMove,
+		Color:       color,
+		Players:     players,
+		Moves:       slices.Clone(cg.Moves),
+		Deadline:    deadline,
+		Round:       g.Round().For(color),
+	}
+}
+
+// clone copies Players and Moves so stored games are not shared with callers
+func (cg CorrespondenceGame) clone() CorrespondenceGame {
+	cg.Players = maps.Clone(cg.Players)
+	cg.Moves = slices.Clone(cg.Moves)
+	return cg
+}
+
+func generateSecretToken() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
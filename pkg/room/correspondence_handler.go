@@ -0,0 +1,273 @@
+package room
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/errorx"
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/httpx"
+	"go.uber.org/zap"
+)
+
+type CorrespondenceCreator interface {
+	Create(cfg Config, daysPerMove int) (CorrespondenceGame, error)
+}
+
+type CorrespondenceJoiner interface {
+	Join(code string, name string, color engine.Color) (string, error)
+}
+
+type CorrespondenceMover interface {
+	Move(code string, token string, move string) (CorrespondenceView, error)
+}
+
+type CorrespondenceViewer interface {
+	View(code string, token string) (CorrespondenceView, error)
+}
+
+// CorrespondenceCreateRequest FEN and Odds are mutually exclusive
+type CorrespondenceCreateRequest struct {
+	Variant     engine.Variant `json:"variant"`
+	FEN         string         `json:"fen,omitempty"`
+	Odds        engine.Odds    `json:"odds,omitempty"`
+	DaysPerMove int            `json:"daysPerMove"`
+}
+
+type CorrespondenceCreateResponse struct {
+	Code        string         `json:"code"`
+	Variant     engine.Variant `json:"variant"`
+	FEN         string         `json:"fen"`
+	DaysPerMove int            `json:"daysPerMove"`
+	CreatedTime time.Time      `json:"createdTime"`
+}
+
+type CorrespondenceJoinRequest struct {
+	Name  string       `json:"name"`
+	Color engine.Color `json:"color"`
+}
+
+// CorrespondenceMoveRequest move in file rank notation, e.g. e2e4, e7e8=Q or N@f3
+type CorrespondenceMoveRequest struct {
+	Move string `json:"move"`
+}
+
+const headerKeyAuthorization = "Authorization"
+const bearerPrefix = "Bearer "
+
+type CorrespondenceCreateHandler struct {
+	logger  *zap.Logger
+	creator CorrespondenceCreator
+}
+
+func NewCorrespondenceCreateHandler(
+	logger *zap.Logger,
+	creator CorrespondenceCreator,
+) *CorrespondenceCreateHandler {
+	return &CorrespondenceCreateHandler{
+		logger:  logger,
+		creator: creator,
+	}
+}
+
+func (h *CorrespondenceCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer func() { _ = r.Body.Close() }()
+
+	var createReq CorrespondenceCreateRequest
+	err := json.NewDecoder(r.Body).Decode(&createReq)
+	if err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn("failed to decode correspondence create request", zap.Error(err))
+		httpx.BadRequestResponse("invalid request body",
+			map[string]string{"error": err.Error()},
+			w)
+		return
+	}
+
+	cg, err := h.creator.Create(Config{
+		Variant: createReq.Variant,
+		FEN:     strings.TrimSpace(createReq.FEN),
+		Odds:    createReq.Odds,
+	}, createReq.DaysPerMove)
+	if err != nil {
+		correspondenceErrorResponse(h.logger, err, w)
+		return
+	}
+
+	httpx.JsonResponse(http.StatusOK, CorrespondenceCreateResponse{
+		Code:        cg.Code,
+		Variant:     cg.Variant,
+		FEN:         cg.FEN,
+		DaysPerMove: cg.DaysPerMove,
+		CreatedTime: cg.CreatedTime,
+	}, w)
+}
+
+type CorrespondenceJoinHandler struct {
+	logger *zap.Logger
+	joiner CorrespondenceJoiner
+}
+
+func NewCorrespondenceJoinHandler(
+	logger *zap.Logger,
+	joiner CorrespondenceJoiner,
+) *CorrespondenceJoinHandler {
+	return &CorrespondenceJoinHandler{
+		logger: logger,
+		joiner: joiner,
+	}
+}
+
+// ServeHTTP responds with the token to authenticate moves,
+// it is not stored by the server and can not be issued again.
+func (h *CorrespondenceJoinHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer func() { _ = r.Body.Close() }()
+	code := r.PathValue(pathKeyCode)
+
+	var joinReq CorrespondenceJoinRequest
+	err := json.NewDecoder(r.Body).Decode(&joinReq)
+	if err != nil {
+		h.logger.Warn("failed to decode correspondence join request", zap.Error(err))
+		httpx.BadRequestResponse("invalid request body",
+			map[string]string{"error": err.Error()},
+			w)
+		return
+	}
+
+	errs := make(map[string]string, 2)
+	if strings.TrimSpace(joinReq.Name) == "" {
+		errs["name"] = "name is required"
+	}
+	if vErr := validateCorrespondenceColor(joinReq.Color); vErr != nil {
+		maps.Copy(errs, vErr.Properties)
+	}
+	if len(errs) > 0 {
+		httpx.ValidationFailedResponse(&errorx.ValidationError{Properties: errs}, w)
+		return
+	}
+
+	token, err := h.joiner.Join(code, joinReq.Name, joinReq.Color)
+	if err != nil {
+		correspondenceErrorResponse(h.logger, err, w)
+		return
+	}
+
+	httpx.JsonResponse(http.StatusOK, JoinResponse{Token: token}, w)
+}
+
+type CorrespondenceMoveHandler struct {
+	logger *zap.Logger
+	mover  CorrespondenceMover
+}
+
+func NewCorrespondenceMoveHandler(
+	logger *zap.Logger,
+	mover CorrespondenceMover,
+) *CorrespondenceMoveHandler {
+	return &CorrespondenceMoveHandler{
+		logger: logger,
+		mover:  mover,
+	}
+}
+
+func (h *CorrespondenceMoveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer func() { _ = r.Body.Close() }()
+	code := r.PathValue(pathKeyCode)
+
+	token, ok := bearerToken(r)
+	if !ok {
+		httpx.UnauthorizedResponse(w)
+		return
+	}
+
+	var moveReq CorrespondenceMoveRequest
+	err := json.NewDecoder(r.Body).Decode(&moveReq)
+	if err != nil {
+		h.logger.Warn("failed to decode correspondence move request", zap.Error(err))
+		httpx.BadRequestResponse("invalid request body",
+			map[string]string{"error": err.Error()},
+			w)
+		return
+	}
+
+	view, err := h.mover.Move(code, token, moveReq.Move)
+	if err != nil {
+		correspondenceErrorResponse(h.logger, err, w)
+		return
+	}
+
+	httpx.JsonResponse(http.StatusOK, view, w)
+}
+
+type CorrespondenceViewHandler struct {
+	logger *zap.Logger
+	viewer CorrespondenceViewer
+}
+
+func NewCorrespondenceViewHandler(
+	logger *zap.Logger,
+	viewer CorrespondenceViewer,
+) *CorrespondenceViewHandler {
+	return &CorrespondenceViewHandler{
+		logger: logger,
+		viewer: viewer,
+	}
+}
+
+func (h *CorrespondenceViewHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue(pathKeyCode)
+
+	token, ok := bearerToken(r)
+	if !ok {
+		httpx.UnauthorizedResponse(w)
+		return
+	}
+
+	view, err := h.viewer.View(code, token)
+	if err != nil {
+		correspondenceErrorResponse(h.logger, err, w)
+		return
+	}
+
+	httpx.JsonResponse(http.StatusOK, view, w)
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get(headerKeyAuthorization), bearerPrefix)
+	token = strings.TrimSpace(token)
+	return token, ok && token != ""
+}
+
+func correspondenceErrorResponse(logger *zap.Logger, err error, w http.ResponseWriter) {
+	if vErr, ok := errors.AsType[*errorx.ValidationError](err); ok {
+		httpx.ValidationFailedResponse(vErr, w)
+		return
+	}
+	switch {
+	case errors.Is(err, ErrRoomNotFound):
+		httpx.NotFoundResponse(w)
+	case errors.Is(err, ErrInvalidToken):
+		httpx.UnauthorizedResponse(w)
+	case errors.Is(err, ErrInvalidPosition):
+		logger.Warn("invalid starting position", zap.Error(err))
+		invalidPositionResponse(err, w)
+	case errors.Is(err, ErrColorOccupied),
+		errors.Is(err, ErrWaitingForOpponent),
+		errors.Is(err, ErrInvalidDaysPerMove),
+		errors.Is(err, ErrUnsupportedVariant),
+		errors.Is(err, game.ErrInvalidTimeControl):
+		httpx.BadRequestResponse(err.Error(), nil, w)
+	case gameErrCode(err) != "":
+		httpx.BadRequestResponse(err.Error(),
+			map[string]string{"code": gameErrCode(err)},
+			w)
+	default:
+		logger.Error("correspondence request failed", zap.Error(err))
+		httpx.InternalServerErrorResponse("", w)
+	}
+}
@@ -0,0 +1,118 @@
+package room
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dyxj/chess/pkg/store"
+)
+
+// CorrespondenceMemStore in memory CorrespondenceStore, games do not expire
+// and are lost on restart.
+type CorrespondenceMemStore struct {
+	cache *store.MemCache
+}
+
+func NewCorrespondenceMemStore(cache *store.MemCache) *CorrespondenceMemStore {
+	return &CorrespondenceMemStore{
+		cache: cache,
+	}
+}
+
+func (s *CorrespondenceMemStore) Add(cg CorrespondenceGame) error {
+	err := s.cache.Add(correspondenceKey(cg.Code), cg.clone(), time.Time{})
+	if err != nil {
+		return ErrCodeAlreadyExists
+	}
+	return nil
+}
+
+func (s *CorrespondenceMemStore) Find(code string) (CorrespondenceGame, bool) {
+	item, ok := s.cache.Find(correspondenceKey(code))
+	if !ok {
+		return CorrespondenceGame{}, false
+	}
+	cg, isGame := item.(CorrespondenceGame)
+	if !isGame {
+		return CorrespondenceGame{}, false
+	}
+	return cg.clone(), true
+}
+
+func (s *CorrespondenceMemStore) Update(cg CorrespondenceGame) error {
+	err := s.cache.Update(correspondenceKey(cg.Code), cg.clone(), time.Time{})
+	if err != nil {
+		return ErrRoomNotFound
+	}
+	return nil
+}
+
+// correspondenceKey keeps correspondence codes apart from room codes sharing the cache
+func correspondenceKey(code string) string {
+	return "correspondence:" + code
+}
+
+// CorrespondenceFileStore CorrespondenceStore keeping each game as a json file in dir,
+// games survive restarts.
+type CorrespondenceFileStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewCorrespondenceFileStore creates dir if it does not exist.
+func NewCorrespondenceFileStore(dir string) (*CorrespondenceFileStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create correspondence dir: %w", err)
+	}
+	return &CorrespondenceFileStore{
+		dir: dir,
+	}, nil
+}
+
+func (s *CorrespondenceFileStore) Add(cg CorrespondenceGame) error {
+	path, ok := codePath(s.dir, cg.Code)
+	if !ok {
+		return fmt.Errorf("invalid code %q", cg.Code)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if fileExists(path) {
+		return ErrCodeAlreadyExists
+	}
+	return writeJSONFile(path, cg)
+}
+
+func (s *CorrespondenceFileStore) Find(code string) (CorrespondenceGame, bool) {
+	path, ok := codePath(s.dir, code)
+	if !ok {
+		return CorrespondenceGame{}, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var cg CorrespondenceGame
+	if err := readJSONFile(path, &cg); err != nil {
+		return CorrespondenceGame{}, false
+	}
+	return cg, true
+}
+
+func (s *CorrespondenceFileStore) Update(cg CorrespondenceGame) error {
+	path, ok := codePath(s.dir, cg.Code)
+	if !ok {
+		return ErrRoomNotFound
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !fileExists(path) {
+		return ErrRoomNotFound
+	}
+	return writeJSONFile(path, cg)
+}
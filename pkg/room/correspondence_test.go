@@ -0,0 +1,198 @@
+package room
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/errorx"
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeTime struct {
+	now time.Time
+}
+
+func (f *fakeTime) Now() time.Time {
+	return f.now
+}
+
+func newTestCorrespondence(t *testing.T, cfg Config, days int) (*Correspondence, *fakeTime, string, string, string) {
+	t.Helper()
+	ft := &fakeTime{now: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	c := NewCorrespondence(zap.NewNop(), NewCorrespondenceMemStore(store.NewMemCache()), ft)
+
+	cg, err := c.Create(cfg, days)
+	require.NoError(t, err)
+
+	wToken, err := c.Join(cg.Code, "white player", engine.White)
+	require.NoError(t, err)
+	bToken, err := c.Join(cg.Code, "black player", engine.Black)
+	require.NoError(t, err)
+
+	return c, ft, cg.Code, wToken, bToken
+}
+
+func TestCorrespondence_Moves(t *testing.T) {
+	c, ft, code, wToken, bToken := newTestCorrespondence(t, Config{}, 3)
+
+	view, err := c.View(code, bToken)
+	require.NoError(t, err)
+	assert.Equal(t, engine.Black, view.Color)
+	assert.Equal(t, map[engine.Color]string{engine.White: "white player", engine.Black: "black player"}, view.Players)
+	require.NotNil(t, view.Deadline)
+	assert.Equal(t, ft.now.Add(3*24*time.Hour), *view.Deadline)
+
+	_, err = c.Move(code, bToken, "e7e5")
+	assert.ErrorIs(t, err, engine.ErrNotActiveColor)
+
+	ft.now = ft.now.Add(2 * 24 * time.Hour)
+	view, err = c.Move(code, wToken, "e2e4")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"e2e4"}, view.Moves)
+	assert.Equal(t, engine.Black, view.Round.ActiveColor)
+	assert.Equal(t, ft.now.Add(3*24*time.Hour), *view.Deadline)
+
+	_, err = c.Move(code, bToken, "e7e4")
+	assert.ErrorIs(t, err, game.ErrIllegalMove)
+
+	_, err = c.Move(code, bToken, "e7 e5")
+	require.NoError(t, err)
+
+	// a new instance on the same store continues the game
+	restarted := NewCorrespondence(zap.NewNop(), c.store, ft)
+	view, err = restarted.View(code, wToken)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"e2e4", "e7e5"}, view.Moves)
+	assert.Equal(t, 2, view.Round.Count)
+	assert.Equal(t, game.StateInProgress, view.Round.State)
+}
+
+func TestCorrespondence_Forfeit(t *testing.T) {
+	c, ft, code, wToken, bToken := newTestCorrespondence(t, Config{}, 1)
+
+	_, err := c.Move(code, wToken, "e2e4")
+	require.NoError(t, err)
+
+	ft.now = ft.now.Add(24*time.Hour + time.Second)
+	view, err := c.View(code, wToken)
+	require.NoError(t, err)
+	assert.Equal(t, game.StateTimeout, view.Round.State)
+	assert.Nil(t, view.Deadline)
+
+	_, err = c.Move(code, bToken, "e7e5")
+	assert.ErrorIs(t, err, game.ErrInvalidMove)
+
+	cg, ok := c.store.Find(code)
+	require.True(t, ok)
+	assert.Equal(t, engine.Black, cg.Forfeited)
+
+	g, err := cg.game()
+	require.NoError(t, err)
+	assert.Equal(t, engine.White, g.Winner())
+}
+
+func TestCorrespondence_Errors(t *testing.T) {
+	c, _, code, _, _ := newTestCorrespondence(t, Config{}, 1)
+
+	_, err := c.View(code, "not a token")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = c.View("ABCDEF", "not a token")
+	assert.ErrorIs(t, err, ErrRoomNotFound)
+
+	_, err = c.Join(code, "third player", engine.White)
+	assert.ErrorIs(t, err, ErrColorOccupied)
+
+	// a join without a color must not take a seat
+	cg, err := c.Create(Config{}, 1)
+	require.NoError(t, err)
+	for _, color := range []engine.Color{0, 2} {
+		_, err = c.Join(cg.Code, "no color", color)
+		_, isValidation := errors.AsType[*errorx.ValidationError](err)
+		assert.True(t, isValidation, "color %d", color)
+	}
+	wToken, err := c.Join(cg.Code, "white player", engine.White)
+	require.NoError(t, err)
+	_, err = c.Move(cg.Code, wToken, "e2e4")
+	assert.ErrorIs(t, err, ErrWaitingForOpponent)
+	_, err = c.Join(cg.Code, "black player", engine.Black)
+	require.NoError(t, err)
+
+	_, err = c.Create(Config{}, 0)
+	assert.ErrorIs(t, err, ErrInvalidDaysPerMove)
+
+	_, err = c.Create(Config{Variant: engine.VariantBughouse}, 1)
+	assert.ErrorIs(t, err, ErrUnsupportedVariant)
+
+	_, err = c.Create(Config{FEN: "not a fen"}, 1)
+	assert.ErrorIs(t, err, ErrInvalidPosition)
+
+	_, err = c.Create(Config{Rules: game.Rules{TimeControl: game.SuddenDeath(time.Minute)}}, 1)
+	assert.ErrorIs(t, err, game.ErrInvalidTimeControl)
+}
+
+func TestCorrespondence_Rules(t *testing.T) {
+	rules := game.Rules{Draw: game.DrawAutomatic, NoTakebacks: true, DrawOfferMinMove: 30, Armageddon: true}
+	// Kh6 stalemates black
+	c, _, code, wToken, _ := newTestCorrespondence(t, Config{FEN: "7k/5Q2/6K1/8/8/8/8/8 w - - 0 1", Rules: rules}, 3)
+
+	_, err := c.Move(code, wToken, "g6h6")
+	require.NoError(t, err)
+
+	// a new instance on the same store replays the game with its rules
+	restarted := NewCorrespondence(zap.NewNop(), c.store, &fakeTime{})
+	cg, ok := restarted.store.Find(code)
+	require.True(t, ok)
+	assert.Equal(t, rules, cg.Rules)
+
+	g, err := cg.game()
+	require.NoError(t, err)
+	assert.Equal(t, rules, g.Rules())
+	assert.Equal(t, game.StateStalemate, g.State())
+	assert.Equal(t, engine.Black, g.Winner(), "armageddon draw counts as a win for black")
+	assert.ErrorIs(t, g.UndoLastMove(), game.ErrTakebackNotAllowed)
+}
+
+func TestCorrespondenceFileStore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewCorrespondenceFileStore(dir)
+	require.NoError(t, err)
+
+	ft := &fakeTime{now: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	c := NewCorrespondence(zap.NewNop(), s, ft)
+	cg, err := c.Create(Config{Variant: engine.VariantThreeCheck}, 3)
+	require.NoError(t, err)
+	assert.ErrorIs(t, s.Add(cg), ErrCodeAlreadyExists)
+
+	wToken, err := c.Join(cg.Code, "white player", engine.White)
+	require.NoError(t, err)
+	_, err = c.Join(cg.Code, "black player", engine.Black)
+	require.NoError(t, err)
+	_, err = c.Move(cg.Code, wToken, "e2e4")
+	require.NoError(t, err)
+
+	// a new store on the same dir, as after a restart
+	restarted, err := NewCorrespondenceFileStore(dir)
+	require.NoError(t, err)
+	found, ok := restarted.Find(cg.Code)
+	require.True(t, ok)
+	assert.Equal(t, []string{"e2e4"}, found.Moves)
+	assert.Equal(t, ft.now.Add(3*24*time.Hour), found.Deadline.UTC())
+
+	view, err := NewCorrespondence(zap.NewNop(), restarted, ft).View(cg.Code, wToken)
+	require.NoError(t, err)
+	assert.Equal(t, engine.VariantThreeCheck, view.Variant)
+	assert.Equal(t, engine.Black, view.Round.ActiveColor)
+
+	assert.ErrorIs(t, restarted.Update(CorrespondenceGame{Code: "ABC123"}), ErrRoomNotFound)
+	assert.ErrorIs(t, restarted.Update(CorrespondenceGame{Code: "../" + cg.Code}), ErrRoomNotFound)
+	assert.Error(t, restarted.Add(CorrespondenceGame{Code: "../ABC123"}))
+	_, ok = restarted.Find("../" + cg.Code)
+	assert.False(t, ok)
+}
@@ -1,18 +1,64 @@
 package room
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+)
 
 var ErrCodeAlreadyExists = errors.New("code already exists")
 var ErrRoomNotFound = errors.New("room not found")
 var ErrRoomFull = errors.New("room is full")
 var ErrColorOccupied = errors.New("color is occupied")
 var ErrInvalidToken = errors.New("invalid token")
+var ErrInvalidSeat = errors.New("invalid seat")
+var ErrInvalidPosition = errors.New("invalid starting position")
+var ErrInvalidDaysPerMove = errors.New("invalid days per move")
+var ErrUnsupportedVariant = errors.New("variant not supported")
+var ErrWaitingForOpponent = errors.New("waiting for opponent")
+var ErrRoomNotInProgress = errors.New("room is not in progress")
+var ErrNoAdjournOffer = errors.New("opponent has not offered to adjourn")
+var ErrGameNotOver = errors.New("game is not over")
+var ErrGameAborted = errors.New("game was aborted")
+
+// ProblemNoLegalMoves the side to move of a starting position has no legal moves,
+// the game would be over before it starts.
+const ProblemNoLegalMoves engine.PositionProblemCode = "no_legal_moves"
+
+// PositionError starting position can not be played, unwraps to ErrInvalidPosition.
+type PositionError struct {
+	Problems []engine.PositionProblem
+}
+
+func (e *PositionError) Error() string {
+	msgs := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		msgs[i] = p.String()
+	}
+	return fmt.Sprintf("%v: %s", ErrInvalidPosition, strings.Join(msgs, ", "))
+}
+
+func (e *PositionError) Unwrap() error {
+	return ErrInvalidPosition
+}
 
 const (
 	ErrCodeInvalidToken  = "invalid_token"
 	ErrCodeRoomNotFound  = "room_not_found"
 	ErrCodeRoomFull      = "room_full"
 	ErrCodeColorOccupied = "color_occupied"
+	ErrCodeInvalidSeat   = "invalid_seat"
+)
+
+// Game error codes, illegal moves with a known reason use engine.IllegalMoveReason as code.
+const (
+	ErrCodeNotActiveColor = "not_active_color"
+	ErrCodePieceNotFound  = "piece_not_found"
+	ErrCodeIllegalMove    = "illegal_move"
+	ErrCodeInvalidMove    = "invalid_move"
 )
 
 func terminalErrCode(err error) string {
@@ -25,6 +71,26 @@ func terminalErrCode(err error) string {
 		return ErrCodeRoomFull
 	case errors.Is(err, ErrColorOccupied):
 		return ErrCodeColorOccupied
+	case errors.Is(err, ErrInvalidSeat):
+		return ErrCodeInvalidSeat
+	default:
+		return ""
+	}
+}
+
+func gameErrCode(err error) string {
+	if illegalErr, ok := errors.AsType[*game.IllegalMoveError](err); ok {
+		return illegalErr.Code()
+	}
+	switch {
+	case errors.Is(err, engine.ErrNotActiveColor):
+		return ErrCodeNotActiveColor
+	case errors.Is(err, engine.ErrPieceNotFound):
+		return ErrCodePieceNotFound
+	case errors.Is(err, game.ErrIllegalMove):
+		return ErrCodeIllegalMove
+	case errors.Is(err, game.ErrInvalidMove):
+		return ErrCodeInvalidMove
 	default:
 		return ""
 	}
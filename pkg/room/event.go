@@ -2,6 +2,7 @@ package room
 
 import (
 	"encoding/json"
+	"errors"
 
 	"github.com/dyxj/chess/pkg/engine"
 	"github.com/dyxj/chess/pkg/game"
@@ -10,11 +11,19 @@ import (
 type EventType string
 
 const (
-	EventTypeMessage     EventType = "message"
-	EventTypeRoundResult EventType = "round"
-	EventTypeError       EventType = "error"
-	EventTypeResign      EventType = "resign"
-	EventTypeRoomReady   EventType = "room_ready"
+	EventTypeMessage      EventType = "message"
+	EventTypeRoundResult  EventType = "round"
+	EventTypeError        EventType = "error"
+	EventTypeResign       EventType = "resign"
+	EventTypeRoomReady    EventType = "room_ready"
+	EventTypeChat         EventType = "chat"
+	EventTypeMatchOver    EventType = "match_over"
+	EventTypeTimeout      EventType = "timeout"
+	EventTypeAdjournOffer EventType = "adjourn_offer"
+	EventTypeAdjourned    EventType = "adjourned"
+	EventTypeDescription  EventType = "description"
+	EventTypeHint         EventType = "hint"
+	EventTypeAbort        EventType = "abort"
 )
 
 type EventPartial struct {
@@ -30,11 +39,20 @@ type EventMessagePayload struct {
 	Message string `json:"message"`
 }
 
+// EventRoundPayload round result, Narration describes the round
+// in the language the recipient opted in to.
+type EventRoundPayload struct {
+	game.RoundResult
+	Narration string `json:"narration,omitempty"`
+}
+
 type EventErrorPayload struct {
 	LastValidMoveCount int    `json:"lastValidMoveCount"`
 	Error              string `json:"error"`
 	Code               string `json:"code,omitempty"`
 	Terminal           bool   `json:"terminal,omitempty"`
+	// Square of the blocking piece, pinner or checker of an illegal move
+	Square *int `json:"square,omitempty"`
 }
 
 type EventResignPayload struct {
@@ -42,9 +60,75 @@ type EventResignPayload struct {
 	Winner   engine.Color `json:"winner"`
 }
 
+// EventAbortPayload Aborter called off the game, it ends with game.StateAborted and no winner.
+type EventAbortPayload struct {
+	Aborter engine.Color `json:"aborter"`
+}
+
+// EventTimeoutPayload Flagged ran out of time, State is game.StateDraw
+// without a Winner if the opponent cannot checkmate.
+type EventTimeoutPayload struct {
+	Flagged engine.Color `json:"flagged"`
+	State   game.State   `json:"state"`
+	Winner  engine.Color `json:"winner,omitempty"`
+}
+
+// EventAdjournOfferPayload Color offered to adjourn, accepted with ActionTypeAdjournAccept
+type EventAdjournOfferPayload struct {
+	Color engine.Color `json:"color"`
+}
+
+// EventAdjournedPayload Key of the recipient to resume room Code, it is only sent once.
+type EventAdjournedPayload struct {
+	Code string `json:"code"`
+	Key  string `json:"key"`
+}
+
+// EventDescriptionPayload position described in reply to ActionTypeDescribe
+type EventDescriptionPayload struct {
+	Text string `json:"text"`
+}
+
 type EventRoomReadyPayload struct {
-	WhitePlayerName string `json:"whitePlayerName"`
-	BlackPlayerName string `json:"blackPlayerName"`
+	WhitePlayerName string         `json:"whitePlayerName"`
+	BlackPlayerName string         `json:"blackPlayerName"`
+	Variant         engine.Variant `json:"variant"`
+	// FEN starting position of the game
+	FEN string `json:"fen"`
+}
+
+// EventBughouseReadyPayload room_ready payload of a bughouse room,
+// Seat is the seat of the recipient.
+type EventBughouseReadyPayload struct {
+	Variant engine.Variant `json:"variant"`
+	Seat    Seat           `json:"seat"`
+	Players []SeatPlayer   `json:"players"`
+}
+
+type SeatPlayer struct {
+	Seat
+	Name string `json:"name"`
+}
+
+// EventBughouseRoundPayload round payload of a bughouse room, round of Board
+type EventBughouseRoundPayload struct {
+	Board int `json:"board"`
+	game.RoundResult
+}
+
+// EventChatPayload message from a teammate
+type EventChatPayload struct {
+	From    Seat   `json:"from"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// EventMatchOverPayload bughouse match ended by Board,
+// Winners is empty for a draw.
+type EventMatchOverPayload struct {
+	Board   int        `json:"board"`
+	State   game.State `json:"state"`
+	Winners []Seat     `json:"winners"`
 }
 
 func NewEventMessage(message string) Event {
@@ -56,19 +140,30 @@ func NewEventMessage(message string) Event {
 	}
 }
 
-func NewEventRound(round game.RoundResult) Event {
+// NewEventRound narration is optional, omitted if empty.
+func NewEventRound(round game.RoundResult, narration ...string) Event {
+	p := EventRoundPayload{RoundResult: round}
+	if len(narration) > 0 {
+		p.Narration = narration[0]
+	}
 	return Event{
 		EventType: EventTypeRoundResult,
-		Payload:   round,
+		Payload:   p,
 	}
 }
 
 func NewEventError(lastValidMoveCount int, err error) Event {
+	var square *int
+	if illegalErr, ok := errors.AsType[*game.IllegalMoveError](err); ok && illegalErr.Square >= 0 {
+		square = new(illegalErr.Square)
+	}
 	return Event{
 		EventType: EventTypeError,
 		Payload: EventErrorPayload{
 			LastValidMoveCount: lastValidMoveCount,
 			Error:              err.Error(),
+			Code:               gameErrCode(err),
+			Square:             square,
 		},
 	}
 }
@@ -94,15 +189,118 @@ func NewEventResign(resigner engine.Color) Event {
 	}
 }
 
+func NewEventAbort(aborter engine.Color) Event {
+	return Event{
+		EventType: EventTypeAbort,
+		Payload: EventAbortPayload{
+			Aborter: aborter,
+		},
+	}
+}
+
+func NewEventTimeout(flagged engine.Color, state game.State, winner engine.Color) Event {
+	return Event{
+		EventType: EventTypeTimeout,
+		Payload: EventTimeoutPayload{
+			Flagged: flagged,
+			State:   state,
+			Winner:  winner,
+		},
+	}
+}
+
+func NewEventAdjournOffer(color engine.Color) Event {
+	return Event{
+		EventType: EventTypeAdjournOffer,
+		Payload: EventAdjournOfferPayload{
+			Color: color,
+		},
+	}
+}
+
+func NewEventAdjourned(code string, key string) Event {
+	return Event{
+		EventType: EventTypeAdjourned,
+		Payload: EventAdjournedPayload{
+			Code: code,
+			Key:  key,
+		},
+	}
+}
+
+func NewEventDescription(text string) Event {
+	return Event{
+		EventType: EventTypeDescription,
+		Payload: EventDescriptionPayload{
+			Text: text,
+		},
+	}
+}
+
+// NewEventHint private to the assisted player, never sent to the opponent.
+func NewEventHint(h game.Hint) Event {
+	return Event{
+		EventType: EventTypeHint,
+		Payload:   h,
+	}
+}
+
 func NewEventRoomReady(
 	whitePlayerName string,
 	blackPlayerName string,
+	variant engine.Variant,
+	fen string,
 ) Event {
 	return Event{
 		EventType: EventTypeRoomReady,
 		Payload: EventRoomReadyPayload{
 			WhitePlayerName: whitePlayerName,
 			BlackPlayerName: blackPlayerName,
+			Variant:         variant,
+			FEN:             fen,
+		},
+	}
+}
+
+func NewEventBughouseReady(seat Seat, players []SeatPlayer) Event {
+	return Event{
+		EventType: EventTypeRoomReady,
+		Payload: EventBughouseReadyPayload{
+			Variant: engine.VariantBughouse,
+			Seat:    seat,
+			Players: players,
+		},
+	}
+}
+
+func NewEventBughouseRound(board int, round game.RoundResult) Event {
+	return Event{
+		EventType: EventTypeRoundResult,
+		Payload: EventBughouseRoundPayload{
+			Board:       board,
+			RoundResult: round,
+		},
+	}
+}
+
+func NewEventChat(from Seat, name string, message string) Event {
+	return Event{
+		EventType: EventTypeChat,
+		Payload: EventChatPayload{
+			From:    from,
+			Name:    name,
+			Message: message,
+		},
+	}
+}
+
+func NewEventMatchOver(board int, state game.State, winners []Seat) Event {
+	return Event{
+		EventType: EventTypeMatchOver,
+		Payload: EventMatchOverPayload{
+			Board:   board,
+			State:   state,
+			Winners: winners,
 		},
 	}
 }
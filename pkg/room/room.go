@@ -1,11 +1,13 @@
 package room
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/dyxj/chess/pkg/engine"
 	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/narration"
 	"github.com/google/uuid"
 )
 
@@ -35,6 +37,7 @@ const (
 	StatusWaiting Status = iota
 	StatusInProgress
 	StatusCompleted
+	StatusAdjourned
 )
 
 func (s Status) String() string {
@@ -45,17 +48,23 @@ func (s Status) String() string {
 		return "in_progress"
 	case StatusCompleted:
 		return "completed"
+	case StatusAdjourned:
+		return "adjourned"
 	default:
 		return "unknown"
 	}
 }
 
 type Room struct {
-	mu           sync.RWMutex
+	mu sync.RWMutex
+	// playMu serializes changes to the game with the status they depend on. The game is
+	// changed without holding mu so its listeners may read the room.
+	playMu       sync.Mutex
 	ID           uuid.UUID
 	Code         string
 	status       Status
 	Game         *game.Game
+	startFEN     string
 	whitePlayer  *Player
 	blackPlayer  *Player
 	whitePub     websocketPublisher
@@ -65,18 +74,131 @@ type Room struct {
 	readyOnce    sync.Once
 	gameOverChan chan struct{}
 	gameOverOnce sync.Once
+	// clockChan notifies the clock watcher that a move was played
+	clockChan chan struct{}
+	// moves played in order, kept to adjourn the game
+	moves []game.Move
+	// adjournOffer color offering to adjourn at move adjournOfferCount, 0 if none
+	adjournOffer      engine.Color
+	adjournOfferCount int
+	// resumed rooms are rebuilt from an adjourned game, seats are reserved for its players
+	resumed bool
+	// narrators of players who opted in to narrated round events, nil otherwise
+	whiteNarrator *narration.Narrator
+	blackNarrator *narration.Narrator
+	// assisted players receive hint events
+	whiteAssist bool
+	blackAssist bool
 }
 
-func NewEmptyRoom() *Room {
-	return &Room{
+// Config game configuration of a room, zero value is an untimed standard game.
+// FEN and Odds set the starting position, at most one of them may be set.
+type Config struct {
+	Variant engine.Variant
+	FEN     string
+	Odds    engine.Odds
+	Rules   game.Rules
+}
+
+// NewEmptyRoom creates a room starting from the standard position,
+// only the variant of cfg is used. See NewRoom for custom positions.
+func NewEmptyRoom(cfg ...Config) *Room {
+	var c Config
+	if len(cfg) > 0 {
+		c = cfg[0]
+	}
+	return newRoom(engine.NewVariantBoard(c.Variant), game.Rules{})
+}
+
+// NewRoom creates a room from cfg, the starting position is validated
+// and an error wrapping ErrInvalidPosition is returned if it can not be played.
+// An invalid time control returns an error wrapping game.ErrInvalidTimeControl.
+func NewRoom(cfg Config) (*Room, error) {
+	if err := cfg.validateTimeControl(); err != nil {
+		return nil, err
+	}
+	b, err := cfg.board()
+	if err != nil {
+		return nil, err
+	}
+	return newRoom(b, cfg.Rules), nil
+}
+
+func newRoom(b *engine.Board, rules game.Rules) *Room {
+	r := &Room{
 		ID:           uuid.New(),
 		Code:         generateCode(),
-		Game:         game.NewGame(engine.NewBoard()),
+		Game:         game.NewGame(b, rules),
+		startFEN:     b.FEN(),
 		status:       StatusWaiting,
 		CreatedTime:  time.Now(),
 		readyChan:    make(chan struct{}),
 		gameOverChan: make(chan struct{}),
+		clockChan:    make(chan struct{}, 1),
+	}
+	r.Game.IncludeLegalMoves(true)
+	r.Game.OnMove(func(game.RoundResult) { r.notifyClock() })
+	return r
+}
+
+func (c Config) validateTimeControl() error {
+	tc := c.Rules.TimeControl
+	if c.Variant == engine.VariantBughouse && tc.IsTimed() {
+		return fmt.Errorf("%w: bughouse rooms are untimed", game.ErrInvalidTimeControl)
 	}
+	return tc.Validate()
+}
+
+// board starting position of cfg
+func (c Config) board() (*engine.Board, error) {
+	if c.FEN == "" && c.Odds == engine.OddsNone {
+		return engine.NewVariantBoard(c.Variant), nil
+	}
+
+	if c.FEN != "" && c.Odds != engine.OddsNone {
+		return nil, fmt.Errorf("%w: fen and odds are mutually exclusive", ErrInvalidPosition)
+	}
+	if c.Variant == engine.VariantBughouse {
+		return nil, fmt.Errorf("%w: bughouse does not support custom positions", ErrInvalidPosition)
+	}
+
+	fen := c.FEN
+	if c.Odds != engine.OddsNone {
+		fen = c.Odds.FEN()
+	}
+
+	b, err := engine.NewBoardFromFEN(fen)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPosition, err)
+	}
+	b.SetVariant(c.Variant)
+
+	problems := b.Validate()
+	if !b.HasLegalMoves(b.ActiveColor()) {
+		problems = append(problems, engine.PositionProblem{
+			Code:    ProblemNoLegalMoves,
+			Color:   b.ActiveColor(),
+			Message: fmt.Sprintf("%v has no legal moves", b.ActiveColor()),
+		})
+	}
+	if len(problems) > 0 {
+		return nil, &PositionError{Problems: problems}
+	}
+
+	return b, nil
+}
+
+func (r *Room) Variant() engine.Variant {
+	return r.Game.Variant()
+}
+
+func (r *Room) IsTimed() bool {
+	return r.Game.Rules().TimeControl.IsTimed()
+}
+
+// StartFEN starting position of the game
+func (r *Room) StartFEN() string {
+	return r.startFEN
 }
 
 func (r *Room) Player(color engine.Color) *Player {
@@ -180,8 +302,54 @@ func (r *Room) removePublisher(color engine.Color) {
 
 	if color == engine.White {
 		r.whitePub = nil
+	} else {
+		r.blackPub = nil
+	}
+}
+
+// setNarrator narrates round events published to color, nil to stop narrating.
+func (r *Room) setNarrator(color engine.Color, n *narration.Narrator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if color == engine.White {
+		r.whiteNarrator = n
+	} else {
+		r.blackNarrator = n
+	}
+}
+
+// narrator of color, nil if color did not opt in.
+func (r *Room) narrator(color engine.Color) *narration.Narrator {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if color == engine.White {
+		return r.whiteNarrator
 	}
-	r.blackPub = nil
+	return r.blackNarrator
+}
+
+// setAssist sends hint events to color while enabled.
+func (r *Room) setAssist(color engine.Color, enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if color == engine.White {
+		r.whiteAssist = enabled
+	} else {
+		r.blackAssist = enabled
+	}
+}
+
+func (r *Room) assisted(color engine.Color) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if color == engine.White {
+		return r.whiteAssist
+	}
+	return r.blackAssist
 }
 
 func (r *Room) signalReady() {
@@ -190,6 +358,85 @@ func (r *Room) signalReady() {
 	})
 }
 
+// applyMove applies m to the game while the room is in progress.
+func (r *Room) applyMove(m game.Move) (game.RoundResult, error) {
+	r.playMu.Lock()
+	defer r.playMu.Unlock()
+
+	if r.Status() != StatusInProgress {
+		return game.RoundResult{}, ErrRoomNotInProgress
+	}
+	result, err := r.Game.ApplyMove(m)
+	if err != nil {
+		return game.RoundResult{}, err
+	}
+
+	r.mu.Lock()
+	r.moves = append(r.moves, m)
+	r.mu.Unlock()
+	return result, nil
+}
+
+// offerAdjourn the offer lapses as soon as another move is played.
+func (r *Room) offerAdjourn(color engine.Color) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.status != StatusInProgress {
+		return ErrRoomNotInProgress
+	}
+	r.adjournOffer = color
+	r.adjournOfferCount = len(r.moves)
+	return nil
+}
+
+// acceptAdjourn accepts the offer of the opponent of color and stops accepting moves.
+func (r *Room) acceptAdjourn(color engine.Color) error {
+	r.playMu.Lock()
+	defer r.playMu.Unlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.status != StatusInProgress {
+		return ErrRoomNotInProgress
+	}
+	if r.adjournOffer != color.Opposite() || r.adjournOfferCount != len(r.moves) {
+		return ErrNoAdjournOffer
+	}
+	r.status = StatusAdjourned
+	return nil
+}
+
+// resign resigns the game for color while the room is in progress.
+func (r *Room) resign(color engine.Color) error {
+	r.playMu.Lock()
+	defer r.playMu.Unlock()
+
+	if r.Status() != StatusInProgress {
+		return ErrRoomNotInProgress
+	}
+	return r.Game.Resign(color)
+}
+
+// abort calls off the game while the room is in progress, see game.Game.Abort.
+func (r *Room) abort() error {
+	r.playMu.Lock()
+	defer r.playMu.Unlock()
+
+	if r.Status() != StatusInProgress {
+		return ErrRoomNotInProgress
+	}
+	return r.Game.Abort()
+}
+
+// notifyClock wakes the clock watcher, does not block if a notification is pending.
+func (r *Room) notifyClock() {
+	select {
+	case r.clockChan <- struct{}{}:
+	default:
+	}
+}
+
 func (r *Room) signalGameOver() {
 	r.gameOverOnce.Do(func() {
 		close(r.gameOverChan)
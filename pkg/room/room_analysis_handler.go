@@ -0,0 +1,64 @@
+package room
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/httpx"
+	"go.uber.org/zap"
+)
+
+type Analyzer interface {
+	Analysis(code string) (GameAnalysis, error)
+}
+
+// AnalysisHandler responds with the post-game analysis of a room,
+// 202 Accepted while the analysis is pending.
+type AnalysisHandler struct {
+	logger   *zap.Logger
+	analyzer Analyzer
+}
+
+func NewAnalysisHandler(
+	logger *zap.Logger,
+	analyzer Analyzer,
+) *AnalysisHandler {
+	return &AnalysisHandler{
+		logger:   logger,
+		analyzer: analyzer,
+	}
+}
+
+func (h *AnalysisHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue(pathKeyCode)
+
+	a, err := h.analyzer.Analysis(code)
+	if err != nil {
+		h.handlerError(err, w)
+		return
+	}
+
+	switch a.Status {
+	case AnalysisReady:
+		httpx.JsonResponse(http.StatusOK, a, w)
+	case AnalysisPending:
+		httpx.JsonResponse(http.StatusAccepted, a, w)
+	default:
+		httpx.InternalServerErrorResponse("analysis failed", w)
+	}
+}
+
+func (h *AnalysisHandler) handlerError(err error, w http.ResponseWriter) {
+	switch {
+	case errors.Is(err, ErrRoomNotFound):
+		httpx.NotFoundResponse(w)
+	case errors.Is(err, ErrGameNotOver),
+		errors.Is(err, ErrGameAborted),
+		errors.Is(err, game.ErrAnalysisUnavailable):
+		httpx.BadRequestResponse(err.Error(), nil, w)
+	default:
+		h.logger.Error("failed to find analysis", zap.Error(err))
+		httpx.InternalServerErrorResponse("", w)
+	}
+}
@@ -2,7 +2,11 @@ package room
 
 import (
 	"net/http"
+	"net/url"
+	"strconv"
 
+	"github.com/dyxj/chess/pkg/httpx"
+	"github.com/dyxj/chess/pkg/narration"
 	"go.uber.org/zap"
 )
 
@@ -12,7 +16,15 @@ type ConnectHandler struct {
 }
 
 type Connector interface {
-	ConnectWithToken(token string, w http.ResponseWriter, r *http.Request) error
+	ConnectWithToken(token string, w http.ResponseWriter, r *http.Request, opts ...ConnectOptions) error
+}
+
+// ConnectOptions features a player opts in to when connecting
+type ConnectOptions struct {
+	// Narration language round events are narrated in, not narrated if empty
+	Narration narration.Language
+	// Assist sends the player a private hint after every move
+	Assist bool
 }
 
 func NewConnectHandler(
@@ -25,19 +37,54 @@ func NewConnectHandler(
 	}
 }
 
-const queryKeyToken = "token"
+const (
+	queryKeyToken = "token"
+	// queryKeyNarration opts in to narrated round events in a narration.Language
+	queryKeyNarration = "narration"
+	// queryKeyAssist opts in to hint events
+	queryKeyAssist = "assist"
+)
 
 func (h *ConnectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
 	token := q.Get(queryKeyToken)
 
-	err := h.connector.ConnectWithToken(token, w, r)
+	opts, errs := parseConnectOptions(q)
+	if len(errs) > 0 {
+		h.logger.Warn("invalid connect options", zap.Any("errors", errs))
+		httpx.BadRequestResponse("invalid connect options", errs, w)
+		return
+	}
+
+	err := h.connector.ConnectWithToken(token, w, r, opts)
 	if err != nil {
 		h.handleError(err, w)
 		return
 	}
 }
 
+// parseConnectOptions returns the errors of invalid options by query key
+func parseConnectOptions(q url.Values) (ConnectOptions, map[string]string) {
+	var opts ConnectOptions
+	errs := make(map[string]string, 2)
+
+	if q.Has(queryKeyNarration) {
+		if err := opts.Narration.UnmarshalText([]byte(q.Get(queryKeyNarration))); err != nil {
+			errs[queryKeyNarration] = err.Error()
+		}
+	}
+
+	if q.Has(queryKeyAssist) {
+		assist, err := strconv.ParseBool(q.Get(queryKeyAssist))
+		if err != nil {
+			errs[queryKeyAssist] = "assist must be true or false"
+		}
+		opts.Assist = assist
+	}
+
+	return opts, errs
+}
+
 func (h *ConnectHandler) handleError(err error, w http.ResponseWriter) {
 	h.logger.Error("failed to upgrade websocket connection", zap.Error(err))
 	w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,41 @@
+package room
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/dyxj/chess/pkg/narration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConnectOptions(t *testing.T) {
+	tt := []struct {
+		name    string
+		query   string
+		opts    ConnectOptions
+		errKeys []string
+	}{
+		{name: "none", query: "token=abc"},
+		{name: "narration", query: "narration=es", opts: ConnectOptions{Narration: narration.Spanish}},
+		{name: "assist", query: "assist=true", opts: ConnectOptions{Assist: true}},
+		{name: "assist disabled", query: "assist=0"},
+		{name: "invalid", query: "narration=xx&assist=maybe", errKeys: []string{queryKeyNarration, queryKeyAssist}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := url.ParseQuery(tc.query)
+			assert.NoError(t, err)
+
+			opts, errs := parseConnectOptions(q)
+			if len(tc.errKeys) > 0 {
+				for _, k := range tc.errKeys {
+					assert.Contains(t, errs, k)
+				}
+				return
+			}
+			assert.Empty(t, errs)
+			assert.Equal(t, tc.opts, opts)
+		})
+	}
+}
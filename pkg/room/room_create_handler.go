@@ -1,9 +1,16 @@
 package room
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/errorx"
+	"github.com/dyxj/chess/pkg/game"
 	"github.com/dyxj/chess/pkg/httpx"
 	"go.uber.org/zap"
 )
@@ -14,13 +21,68 @@ type CreateHandler struct {
 }
 
 type Creator interface {
-	CreateRoom() (*Room, error)
+	CreateRoom(cfg ...Config) (*Room, error)
+	CreateBughouseRoom() (*BughouseRoom, error)
+}
+
+// CreateRequest body is optional, defaults to an untimed standard game
+// from the start position. FEN and Odds are mutually exclusive.
+type CreateRequest struct {
+	Variant     engine.Variant      `json:"variant"`
+	FEN         string              `json:"fen,omitempty"`
+	Odds        engine.Odds         `json:"odds,omitempty"`
+	TimeControl *TimeControlSeconds `json:"timeControl,omitempty"`
 }
 
 type CreateResponse struct {
-	Code        string    `json:"code"`
-	Status      string    `json:"status"`
-	CreatedTime time.Time `json:"createdTime"`
+	Code        string              `json:"code"`
+	Status      string              `json:"status"`
+	Variant     engine.Variant      `json:"variant"`
+	FEN         string              `json:"fen,omitempty"`
+	TimeControl *TimeControlSeconds `json:"timeControl,omitempty"`
+	CreatedTime time.Time           `json:"createdTime"`
+}
+
+// TimeControlSeconds game.TimeControl with durations in seconds
+type TimeControlSeconds struct {
+	Bonus  game.BonusMode `json:"bonus"`
+	Stages []StageSeconds `json:"stages"`
+}
+
+type StageSeconds struct {
+	Moves     int `json:"moves,omitempty"`
+	Time      int `json:"time"`
+	Increment int `json:"increment,omitempty"`
+}
+
+func newTimeControlSeconds(tc game.TimeControl) *TimeControlSeconds {
+	if !tc.IsTimed() {
+		return nil
+	}
+	stages := make([]StageSeconds, len(tc.Stages))
+	for i, s := range tc.Stages {
+		stages[i] = StageSeconds{
+			Moves:     s.Moves,
+			Time:      int(s.Time / time.Second),
+			Increment: int(s.Increment / time.Second),
+		}
+	}
+	return &TimeControlSeconds{Bonus: tc.Bonus, Stages: stages}
+}
+
+func (t *TimeControlSeconds) timeControl() game.TimeControl {
+	if t == nil {
+		return game.TimeControl{}
+	}
+	stages := make([]game.Stage, len(t.Stages))
+	for i, s := range t.Stages {
+		stages[i] = game.Stage{
+			Moves:     s.Moves,
+			Time:      time.Duration(s.Time) * time.Second,
+			Increment: time.Duration(s.Increment) * time.Second,
+		}
+	}
+	return game.TimeControl{Bonus: t.Bonus, Stages: stages}
 }
 
 func NewCreateHandler(
@@ -35,8 +97,41 @@ func NewCreateHandler(
 
 func (h *CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	defer func() { _ = r.Body.Close() }()
-	room, err := h.creator.CreateRoom()
+
+	var createReq CreateRequest
+	err := json.NewDecoder(r.Body).Decode(&createReq)
+	if err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn("failed to decode create request", zap.Error(err))
+		httpx.BadRequestResponse("invalid request body",
+			map[string]string{"error": err.Error()},
+			w)
+		return
+	}
+
+	if createReq.Variant == engine.VariantBughouse && createReq.FEN == "" &&
+		createReq.Odds == engine.OddsNone && createReq.TimeControl == nil {
+		h.createBughouse(w)
+		return
+	}
+
+	room, err := h.creator.CreateRoom(Config{
+		Variant: createReq.Variant,
+		FEN:     strings.TrimSpace(createReq.FEN),
+		Odds:    createReq.Odds,
+		Rules:   game.Rules{TimeControl: createReq.TimeControl.timeControl()},
+	})
 	if err != nil {
+		if errors.Is(err, ErrInvalidPosition) {
+			h.handleInvalidPosition(err, w)
+			return
+		}
+		if errors.Is(err, game.ErrInvalidTimeControl) {
+			h.logger.Warn("invalid time control", zap.Error(err))
+			httpx.BadRequestResponse(game.ErrInvalidTimeControl.Error(),
+				map[string]string{"error": err.Error()},
+				w)
+			return
+		}
 		h.logger.Error("failed to create room", zap.Error(err))
 		httpx.InternalServerErrorResponse("failed to create room", w)
 		return
@@ -45,6 +140,52 @@ func (h *CreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	resp := CreateResponse{
 		Code:        room.Code,
 		Status:      room.Status().String(),
+		Variant:     room.Variant(),
+		FEN:         room.StartFEN(),
+		TimeControl: newTimeControlSeconds(room.Game.Rules().TimeControl),
+		CreatedTime: room.CreatedTime,
+	}
+	httpx.JsonResponse(http.StatusOK, resp, w)
+}
+
+// handleInvalidPosition responds with the problems of the starting position keyed by code
+func (h *CreateHandler) handleInvalidPosition(err error, w http.ResponseWriter) {
+	h.logger.Warn("invalid starting position", zap.Error(err))
+	invalidPositionResponse(err, w)
+}
+
+func invalidPositionResponse(err error, w http.ResponseWriter) {
+	posErr, ok := errors.AsType[*PositionError](err)
+	if !ok {
+		httpx.BadRequestResponse(ErrInvalidPosition.Error(),
+			map[string]string{"error": err.Error()},
+			w)
+		return
+	}
+
+	props := make(map[string]string, len(posErr.Problems))
+	for _, p := range posErr.Problems {
+		if msg, exists := props[string(p.Code)]; exists {
+			props[string(p.Code)] = msg + ", " + p.Message
+			continue
+		}
+		props[string(p.Code)] = p.Message
+	}
+	httpx.ValidationFailedResponse(&errorx.ValidationError{Properties: props}, w)
+}
+
+func (h *CreateHandler) createBughouse(w http.ResponseWriter) {
+	room, err := h.creator.CreateBughouseRoom()
+	if err != nil {
+		h.logger.Error("failed to create bughouse room", zap.Error(err))
+		httpx.InternalServerErrorResponse("failed to create room", w)
+		return
+	}
+
+	resp := CreateResponse{
+		Code:        room.Code,
+		Status:      room.Status().String(),
+		Variant:     room.Variant(),
 		CreatedTime: room.CreatedTime,
 	}
 	httpx.JsonResponse(http.StatusOK, resp, w)
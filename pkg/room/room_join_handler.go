@@ -18,12 +18,14 @@ type JoinHandler struct {
 }
 
 type Joiner interface {
-	IssueTicketToken(code string, name string, color engine.Color) (string, error)
+	IssueTicketToken(code string, name string, seat Seat) (string, error)
 }
 
+// JoinRequest board is only used by bughouse rooms, 0 or 1
 type JoinRequest struct {
 	Name  string       `json:"name"`
 	Color engine.Color `json:"color"`
+	Board int          `json:"board"`
 }
 
 type JoinResponse struct {
@@ -62,7 +64,8 @@ func (h *JoinHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.joiner.IssueTicketToken(code, joinReq.Name, joinReq.Color)
+	seat := Seat{Board: joinReq.Board, Color: joinReq.Color}
+	token, err := h.joiner.IssueTicketToken(code, joinReq.Name, seat)
 	if err != nil {
 		h.handlerError(err, w)
 		return
@@ -101,6 +104,11 @@ func (h *JoinHandler) handlerError(err error, w http.ResponseWriter) {
 		return
 	}
 
+	if errors.Is(err, ErrInvalidSeat) {
+		httpx.BadRequestResponse("invalid seat", nil, w)
+		return
+	}
+
 	h.logger.Error("failed to join room", zap.Error(err))
 	httpx.InternalServerErrorResponse("", w)
 }
@@ -0,0 +1,62 @@
+package room
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dyxj/chess/pkg/httpx"
+	"go.uber.org/zap"
+)
+
+type Resumer interface {
+	ResumeTicketToken(code string, key string) (string, error)
+}
+
+// ResumeHandler issues a ticket to reconnect to an adjourned game,
+// authenticated by the key of the adjourned event as bearer token.
+type ResumeHandler struct {
+	logger  *zap.Logger
+	resumer Resumer
+}
+
+func NewResumeHandler(
+	logger *zap.Logger,
+	resumer Resumer,
+) *ResumeHandler {
+	return &ResumeHandler{
+		logger:  logger,
+		resumer: resumer,
+	}
+}
+
+func (h *ResumeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	code := r.PathValue(pathKeyCode)
+
+	key, ok := bearerToken(r)
+	if !ok {
+		httpx.UnauthorizedResponse(w)
+		return
+	}
+
+	token, err := h.resumer.ResumeTicketToken(code, key)
+	if err != nil {
+		h.handlerError(err, w)
+		return
+	}
+
+	httpx.JsonResponse(http.StatusOK, JoinResponse{Token: token}, w)
+}
+
+func (h *ResumeHandler) handlerError(err error, w http.ResponseWriter) {
+	switch {
+	case errors.Is(err, ErrRoomNotFound):
+		httpx.NotFoundResponse(w)
+	case errors.Is(err, ErrInvalidToken):
+		httpx.UnauthorizedResponse(w)
+	case errors.Is(err, ErrRoomFull):
+		httpx.BadRequestResponse("room is full", nil, w)
+	default:
+		h.logger.Error("failed to resume room", zap.Error(err))
+		httpx.InternalServerErrorResponse("", w)
+	}
+}
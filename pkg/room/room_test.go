@@ -0,0 +1,64 @@
+package room
+
+import (
+	"testing"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nopPublisher struct{}
+
+func (nopPublisher) PublishJson(any) error { return nil }
+
+func TestRoom_RemovePublisher(t *testing.T) {
+	r, err := NewRoom(Config{})
+	require.NoError(t, err)
+	r.setPublisher(engine.White, nopPublisher{})
+	require.True(t, r.setPublisher(engine.Black, nopPublisher{}))
+
+	r.removePublisher(engine.White)
+	_, exist := r.publisher(engine.White)
+	assert.False(t, exist)
+	_, exist = r.publisher(engine.Black)
+	assert.True(t, exist, "black keeps its publisher")
+
+	r.removePublisher(engine.Black)
+	assert.Empty(t, r.publishers())
+}
+
+func TestRoom_ResignAndAbort(t *testing.T) {
+	r, err := NewRoom(Config{})
+	require.NoError(t, err)
+	assert.ErrorIs(t, r.resign(engine.White), ErrRoomNotInProgress)
+	assert.ErrorIs(t, r.abort(), ErrRoomNotInProgress)
+
+	r = newTestRoomInProgress(t, Config{})
+	require.NoError(t, r.abort())
+	assert.Equal(t, game.StateAborted, r.Game.State())
+
+	r = newTestRoomInProgress(t, Config{})
+	require.NoError(t, r.resign(engine.Black))
+	assert.Equal(t, game.StateBlackResign, r.Game.State())
+	assert.Equal(t, engine.White, r.Game.Winner())
+}
+
+func TestRoom_ListenersMayReadRoom(t *testing.T) {
+	r := newTestRoomInProgress(t, Config{})
+	var statuses []Status
+	read := func(game.RoundResult) {
+		statuses = append(statuses, r.Status())
+		r.publishers()
+		r.narrator(engine.White)
+		r.assisted(engine.White)
+	}
+	r.Game.OnMove(read)
+	r.Game.OnStateChange(read)
+
+	_, err := r.applyMove(game.Move{Color: engine.White, Symbol: engine.Pawn, From: 12, To: 28})
+	require.NoError(t, err)
+	require.NoError(t, r.resign(engine.Black))
+	assert.Equal(t, []Status{StatusInProgress, StatusInProgress}, statuses)
+}
@@ -0,0 +1,30 @@
+package room
+
+import (
+	"fmt"
+
+	"github.com/dyxj/chess/pkg/engine"
+)
+
+// Seat color played on a board, board is always 0 outside of bughouse.
+type Seat struct {
+	Board int          `json:"board"`
+	Color engine.Color `json:"color"`
+}
+
+func (s Seat) String() string {
+	return fmt.Sprintf("board %d %v", s.Board, s.Color)
+}
+
+// Team bughouse team, team 0 plays white on board 0 and black on board 1.
+func (s Seat) Team() int {
+	if (s.Board == 0) == (s.Color == engine.White) {
+		return 0
+	}
+	return 1
+}
+
+// Partner teammate seat on the other bughouse board, playing the opposite color.
+func (s Seat) Partner() Seat {
+	return Seat{Board: 1 - s.Board, Color: s.Color.Opposite()}
+}
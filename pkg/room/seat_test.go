@@ -0,0 +1,29 @@
+package room
+
+import (
+	"testing"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeat_TeamAndPartner(t *testing.T) {
+	tt := []struct {
+		seat    Seat
+		team    int
+		partner Seat
+	}{
+		{Seat{Board: 0, Color: engine.White}, 0, Seat{Board: 1, Color: engine.Black}},
+		{Seat{Board: 1, Color: engine.Black}, 0, Seat{Board: 0, Color: engine.White}},
+		{Seat{Board: 0, Color: engine.Black}, 1, Seat{Board: 1, Color: engine.White}},
+		{Seat{Board: 1, Color: engine.White}, 1, Seat{Board: 0, Color: engine.Black}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.seat.String(), func(t *testing.T) {
+			assert.Equal(t, tc.team, tc.seat.Team())
+			assert.Equal(t, tc.partner, tc.seat.Partner())
+			assert.Equal(t, tc.team, tc.seat.Partner().Team())
+		})
+	}
+}
@@ -5,14 +5,12 @@ import (
 	"encoding/hex"
 	"sync"
 	"time"
-
-	"github.com/dyxj/chess/pkg/engine"
 )
 
 type PlayerTicket struct {
 	RoomCode string
 	Name     string
-	Color    engine.Color
+	Seat     Seat
 }
 
 type TicketCache struct {
@@ -26,14 +24,14 @@ func NewTicketCache() *TicketCache {
 func (c *TicketCache) GenerateTicket(
 	roomCode string,
 	name string,
-	color engine.Color,
+	seat Seat,
 	duration time.Duration,
 ) string {
 	b := make([]byte, 16)
 	_, _ = rand.Read(b)
 	token := hex.EncodeToString(b)
 
-	c.cache.Store(token, PlayerTicket{RoomCode: roomCode, Name: name, Color: color})
+	c.cache.Store(token, PlayerTicket{RoomCode: roomCode, Name: name, Seat: seat})
 
 	time.AfterFunc(duration, func() {
 		c.cache.Delete(token)
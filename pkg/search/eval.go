@@ -0,0 +1,105 @@
+package search
+
+import "github.com/dyxj/chess/pkg/engine"
+
+// PieceValues material values in centipawns
+var PieceValues = map[engine.Symbol]int{
+	engine.Pawn:   100,
+	engine.Knight: 320,
+	engine.Bishop: 330,
+	engine.Rook:   500,
+	engine.Queen:  900,
+	engine.King:   0,
+}
+
+// piece square tables from white's perspective, index 0 is a1.
+var pieceSquareTables = map[engine.Symbol][64]int{
+	engine.Pawn: {
+		0, 0, 0, 0, 0, 0, 0, 0,
+		5, 10, 10, -20, -20, 10, 10, 5,
+		5, -5, -10, 0, 0, -10, -5, 5,
+		0, 0, 0, 20, 20, 0, 0, 0,
+		5, 5, 10, 25, 25, 10, 5, 5,
+		10, 10, 20, 30, 30, 20, 10, 10,
+		50, 50, 50, 50, 50, 50, 50, 50,
+		0, 0, 0, 0, 0, 0, 0, 0,
+	},
+	engine.Knight: {
+		-50, -40, -30, -30, -30, -30, -40, -50,
+		-40, -20, 0, 5, 5, 0, -20, -40,
+		-30, 5, 10, 15, 15, 10, 5, -30,
+		-30, 0, 15, 20, 20, 15, 0, -30,
+		-30, 5, 15, 20, 20, 15, 5, -30,
+		-30, 0, 10, 15, 15, 10, 0, -30,
+		-40, -20, 0, 0, 0, 0, -20, -40,
+		-50, -40, -30, -30, -30, -30, -40, -50,
+	},
+	engine.Bishop: {
+		-20, -10, -10, -10, -10, -10, -10, -20,
+		-10, 5, 0, 0, 0, 0, 5, -10,
+		-10, 10, 10, 10, 10, 10, 10, -10,
+		-10, 0, 10, 10, 10, 10, 0, -10,
+		-10, 5, 5, 10, 10, 5, 5, -10,
+		-10, 0, 5, 10, 10, 5, 0, -10,
+		-10, 0, 0, 0, 0, 0, 0, -10,
+		-20, -10, -10, -10, -10, -10, -10, -20,
+	},
+	engine.Rook: {
+		0, 0, 0, 5, 5, 0, 0, 0,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		-5, 0, 0, 0, 0, 0, 0, -5,
+		5, 10, 10, 10, 10, 10, 10, 5,
+		0, 0, 0, 0, 0, 0, 0, 0,
+	},
+	engine.Queen: {
+		-20, -10, -10, -5, -5, -10, -10, -20,
+		-10, 0, 5, 0, 0, 0, 0, -10,
+		-10, 5, 5, 5, 5, 5, 0, -10,
+		0, 0, 5, 5, 5, 5, 0, -5,
+		-5, 0, 5, 5, 5, 5, 0, -5,
+		-10, 0, 5, 5, 5, 5, 0, -10,
+		-10, 0, 0, 0, 0, 0, 0, -10,
+		-20, -10, -10, -5, -5, -10, -10, -20,
+	},
+	engine.King: {
+		20, 30, 10, 0, 0, 10, 30, 20,
+		20, 20, 0, 0, 0, 0, 20, 20,
+		-10, -20, -20, -20, -20, -20, -20, -10,
+		-20, -30, -30, -40, -40, -30, -30, -20,
+		-30, -40, -40, -50, -50, -40, -40, -30,
+		-30, -40, -40, -50, -50, -40, -40, -30,
+		-30, -40, -40, -50, -50, -40, -40, -30,
+		-30, -40, -40, -50, -50, -40, -40, -30,
+	},
+}
+
+// Evaluate static evaluation in centipawns from the perspective of color.
+func Evaluate(b *engine.Board, color engine.Color) int {
+	score := 0
+	for _, c := range engine.Colors {
+		sign := 1
+		if c != color {
+			sign = -1
+		}
+		for _, p := range b.Pieces(c) {
+			score += sign * (PieceValues[p.Symbol()] + pieceSquareValue(p))
+		}
+	}
+	return score
+}
+
+func pieceSquareValue(p engine.Piece) int {
+	idx := engine.MailboxToIndex(p.Position())
+	if idx < 0 {
+		return 0
+	}
+	if p.Color() == engine.Black {
+		// mirror rank for black
+		idx = (7-idx/8)*8 + idx%8
+	}
+	table := pieceSquareTables[p.Symbol()]
+	return table[idx]
+}
@@ -0,0 +1,240 @@
+package search
+
+import (
+	"slices"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+)
+
+const (
+	MateScore    = 100000
+	infinity     = MateScore + 1
+	defaultDepth = 4
+	// nodes between deadline checks
+	timeCheckInterval = 1024
+)
+
+// Limits bounds a search. A zero value field is unbounded,
+// if both are zero defaultDepth is used.
+type Limits struct {
+	Depth    int
+	MoveTime time.Duration
+}
+
+type Result struct {
+	// Move best move found, zero value if there are no legal moves
+	Move engine.Move
+	// Score in centipawns from the perspective of the side to move
+	Score int
+	// Depth last fully completed depth
+	Depth int
+	Nodes int
+}
+
+// IsMate reports whether score indicates a forced mate.
+func IsMate(score int) bool {
+	return score >= MateScore-1000 || score <= -MateScore+1000
+}
+
+type searcher struct {
+	b        *engine.Board
+	deadline time.Time
+	nodes    int
+	aborted  bool
+}
+
+// Search finds the best move for the active color using iterative deepening
+// alpha-beta with quiescence search.
+// The board is restored to its original position before returning.
+func Search(b *engine.Board, limits Limits) Result {
+	maxDepth := limits.Depth
+	if maxDepth <= 0 {
+		maxDepth = defaultDepth
+		if limits.MoveTime > 0 {
+			maxDepth = 64
+		}
+	}
+
+	s := &searcher{b: b}
+	if limits.MoveTime > 0 {
+		s.deadline = time.Now().Add(limits.MoveTime)
+	}
+
+	rootMoves := b.GenerateLegalMoves(b.ActiveColor())
+	if len(rootMoves) == 0 {
+		score := 0
+		if b.IsCheck(b.ActiveColor()) {
+			score = -MateScore
+		}
+		return Result{Score: score}
+	}
+	orderMoves(rootMoves)
+
+	result := Result{Move: rootMoves[0]}
+	for depth := 1; depth <= maxDepth; depth++ {
+		bestScore := -infinity
+		bestIndex := 0
+		alpha := -infinity
+		for i, m := range rootMoves {
+			score, ok := s.searchMove(m, depth-1, 1, -infinity, -alpha)
+			if !ok {
+				break
+			}
+			if score > bestScore {
+				bestScore = score
+				bestIndex = i
+			}
+			if score > alpha {
+				alpha = score
+			}
+		}
+		if s.aborted {
+			break
+		}
+
+		result = Result{
+			Move:  rootMoves[bestIndex],
+			Score: bestScore,
+			Depth: depth,
+			Nodes: s.nodes,
+		}
+
+		// search best move first on next iteration
+		best := rootMoves[bestIndex]
+		copy(rootMoves[1:bestIndex+1], rootMoves[:bestIndex])
+		rootMoves[0] = best
+
+		if IsMate(bestScore) {
+			break
+		}
+	}
+
+	result.Nodes = s.nodes
+	return result
+}
+
+// searchMove applies m and returns its negated score, ok is false if search was aborted.
+func (s *searcher) searchMove(m engine.Move, depth int, ply int, alpha int, beta int) (int, bool) {
+	if err := s.b.ApplyMove(m); err != nil {
+		// only legal moves are searched, board is out of sync
+		panic(err)
+	}
+	score := -s.negamax(depth, ply, alpha, beta)
+	s.b.UndoLastMove()
+	return score, !s.aborted
+}
+
+func (s *searcher) negamax(depth int, ply int, alpha int, beta int) int {
+	if s.checkAbort() {
+		return 0
+	}
+
+	if s.b.Is100MoveDraw() {
+		return 0
+	}
+
+	if depth <= 0 {
+		return s.quiescence(ply, alpha, beta)
+	}
+
+	color := s.b.ActiveColor()
+	moves := s.b.GenerateLegalMoves(color)
+	if len(moves) == 0 {
+		if s.b.IsCheck(color) {
+			return -MateScore + ply
+		}
+		return 0
+	}
+	orderMoves(moves)
+
+	for _, m := range moves {
+		score, ok := s.searchMove(m, depth-1, ply+1, -beta, -alpha)
+		if !ok {
+			return 0
+		}
+		if score >= beta {
+			return beta
+		}
+		if score > alpha {
+			alpha = score
+		}
+	}
+	return alpha
+}
+
+func (s *searcher) quiescence(ply int, alpha int, beta int) int {
+	if s.checkAbort() {
+		return 0
+	}
+
+	color := s.b.ActiveColor()
+	standPat := Evaluate(s.b, color)
+	if standPat >= beta {
+		return beta
+	}
+	if standPat > alpha {
+		alpha = standPat
+	}
+
+	moves := s.b.GenerateLegalMoves(color)
+	if len(moves) == 0 {
+		if s.b.IsCheck(color) {
+			return -MateScore + ply
+		}
+		return 0
+	}
+
+	moves = slices.DeleteFunc(moves, func(m engine.Move) bool {
+		return m.Captured == 0 && m.Promotion == 0
+	})
+	orderMoves(moves)
+
+	for _, m := range moves {
+		if err := s.b.ApplyMove(m); err != nil {
+			panic(err)
+		}
+		score := -s.quiescence(ply+1, -beta, -alpha)
+		s.b.UndoLastMove()
+		if s.aborted {
+			return 0
+		}
+		if score >= beta {
+			return beta
+		}
+		if score > alpha {
+			alpha = score
+		}
+	}
+	return alpha
+}
+
+func (s *searcher) checkAbort() bool {
+	s.nodes++
+	if s.aborted {
+		return true
+	}
+	if !s.deadline.IsZero() && s.nodes%timeCheckInterval == 0 && time.Now().After(s.deadline) {
+		s.aborted = true
+	}
+	return s.aborted
+}
+
+// orderMoves sorts captures by most valuable victim, least valuable attacker
+// followed by promotions and quiet moves.
+func orderMoves(moves []engine.Move) {
+	slices.SortStableFunc(moves, func(a, b engine.Move) int {
+		return moveOrderValue(b) - moveOrderValue(a)
+	})
+}
+
+func moveOrderValue(m engine.Move) int {
+	v := 0
+	if m.Captured != 0 {
+		v += 10*PieceValues[m.Captured] - PieceValues[m.Symbol] + 10000
+	}
+	if m.Promotion != 0 {
+		v += PieceValues[m.Promotion]
+	}
+	return v
+}
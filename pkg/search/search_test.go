@@ -0,0 +1,59 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch_MateInOne(t *testing.T) {
+	b, err := engine.NewBoardFromFEN("6k1/5ppp/8/8/8/8/5PPP/R5K1 w - - 0 1")
+	require.NoError(t, err)
+	before := b.FEN()
+
+	result := Search(b, Limits{Depth: 3})
+
+	assert.Equal(t, "Ra8#", b.SAN(result.Move))
+	assert.True(t, IsMate(result.Score))
+	assert.Equal(t, before, b.FEN(), "board should be restored")
+}
+
+func TestSearch_WinsMaterial(t *testing.T) {
+	b, err := engine.NewBoardFromFEN("4k3/8/8/3q4/8/8/3R4/3K4 w - - 0 1")
+	require.NoError(t, err)
+
+	result := Search(b, Limits{Depth: 2})
+
+	assert.Equal(t, "Rxd5", b.SAN(result.Move))
+	assert.Positive(t, result.Score)
+}
+
+func TestSearch_MoveTime(t *testing.T) {
+	b := engine.NewBoard()
+
+	start := time.Now()
+	result := Search(b, Limits{MoveTime: 100 * time.Millisecond})
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.NotZero(t, result.Move.Symbol)
+	assert.Positive(t, result.Depth)
+}
+
+func TestSearch_NoLegalMoves(t *testing.T) {
+	b, err := engine.NewBoardFromFEN("k7/8/1QK5/8/8/8/8/8 b - - 0 1")
+	require.NoError(t, err)
+
+	result := Search(b, Limits{Depth: 2})
+
+	assert.Zero(t, result.Move)
+	assert.Zero(t, result.Score, "stalemate")
+}
+
+func TestEvaluate_Symmetric(t *testing.T) {
+	b := engine.NewBoard()
+	assert.Equal(t, 0, Evaluate(b, engine.White))
+	assert.Equal(t, 0, Evaluate(b, engine.Black))
+}
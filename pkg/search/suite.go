@@ -0,0 +1,190 @@
+package search
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/dyxj/chess/pkg/engine"
+)
+
+const (
+	resultPass  = "PASS"
+	resultFail  = "FAIL"
+	resultError = "ERROR"
+)
+
+// SuiteReport results of running an EPD test suite.
+// WriteText output is stable across runs with the same results so reports can be diffed between commits.
+type SuiteReport struct {
+	Name      string
+	Limits    Limits
+	Positions []PositionResult
+}
+
+type PositionResult struct {
+	// Line 1-based line number in the suite file
+	Line int
+	ID   string
+	// BestMoves "bm" operands
+	BestMoves []string
+	// AvoidMoves "am" operands
+	AvoidMoves []string
+	// Found best move found by search in SAN
+	Found  string
+	Score  int
+	Depth  int
+	Passed bool
+	Err    error
+}
+
+// RunSuite searches every EPD record read from r and scores the found move against
+// its "bm" and "am" operations. Blank lines and lines starting with '#' are skipped.
+// Records that fail to parse are reported as errors without stopping the run.
+func RunSuite(name string, r io.Reader, limits Limits) (SuiteReport, error) {
+	report := SuiteReport{
+		Name:      name,
+		Limits:    limits,
+		Positions: make([]PositionResult, 0, 64),
+	}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		report.Positions = append(report.Positions, RunPosition(lineNumber, line, limits))
+	}
+	if err := scanner.Err(); err != nil {
+		return SuiteReport{}, fmt.Errorf("failed to read suite %s: %w", name, err)
+	}
+
+	return report, nil
+}
+
+// RunPosition searches a single EPD record.
+func RunPosition(lineNumber int, line string, limits Limits) PositionResult {
+	pr := PositionResult{
+		Line: lineNumber,
+		ID:   fmt.Sprintf("line-%d", lineNumber),
+	}
+
+	epd, err := engine.ParseEPD(line)
+	if err != nil {
+		pr.Err = err
+		return pr
+	}
+	if id := epd.ID(); id != "" {
+		pr.ID = id
+	}
+	pr.BestMoves, _ = epd.Operation("bm")
+	pr.AvoidMoves, _ = epd.Operation("am")
+
+	b, err := epd.Board()
+	if err != nil {
+		pr.Err = err
+		return pr
+	}
+
+	if len(pr.BestMoves) == 0 && len(pr.AvoidMoves) == 0 {
+		pr.Err = fmt.Errorf("%w: record has neither bm nor am", engine.ErrInvalidEPD)
+		return pr
+	}
+
+	bestMoves, err := parseSANs(b, pr.BestMoves)
+	if err != nil {
+		pr.Err = err
+		return pr
+	}
+	avoidMoves, err := parseSANs(b, pr.AvoidMoves)
+	if err != nil {
+		pr.Err = err
+		return pr
+	}
+
+	result := Search(b, limits)
+	pr.Score = result.Score
+	pr.Depth = result.Depth
+	if result.Move.Symbol == 0 {
+		pr.Err = fmt.Errorf("no legal moves in position")
+		return pr
+	}
+	pr.Found = b.SAN(result.Move)
+
+	pr.Passed = true
+	if len(bestMoves) > 0 && !slices.Contains(bestMoves, result.Move) {
+		pr.Passed = false
+	}
+	if slices.Contains(avoidMoves, result.Move) {
+		pr.Passed = false
+	}
+
+	return pr
+}
+
+func parseSANs(b *engine.Board, sans []string) ([]engine.Move, error) {
+	moves := make([]engine.Move, 0, len(sans))
+	for _, san := range sans {
+		m, err := b.ParseSAN(san)
+		if err != nil {
+			return nil, err
+		}
+		moves = append(moves, m)
+	}
+	return moves, nil
+}
+
+func (r SuiteReport) Passed() int {
+	count := 0
+	for _, p := range r.Positions {
+		if p.Passed {
+			count++
+		}
+	}
+	return count
+}
+
+func (r SuiteReport) Errors() int {
+	count := 0
+	for _, p := range r.Positions {
+		if p.Err != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// WriteText writes one line per position followed by a summary.
+// Search depth and score are excluded as they vary with machine speed.
+func (r SuiteReport) WriteText(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	_, _ = fmt.Fprintf(bw, "# suite: %s depth: %d movetime: %v\n", r.Name, r.Limits.Depth, r.Limits.MoveTime)
+	for _, p := range r.Positions {
+		status := resultFail
+		if p.Passed {
+			status = resultPass
+		}
+		if p.Err != nil {
+			_, _ = fmt.Fprintf(bw, "%s %s %v\n", p.ID, resultError, p.Err)
+			continue
+		}
+
+		_, _ = fmt.Fprintf(bw, "%s %s found=%s", p.ID, status, p.Found)
+		if len(p.BestMoves) > 0 {
+			_, _ = fmt.Fprintf(bw, " bm=%s", strings.Join(p.BestMoves, ","))
+		}
+		if len(p.AvoidMoves) > 0 {
+			_, _ = fmt.Fprintf(bw, " am=%s", strings.Join(p.AvoidMoves, ","))
+		}
+		_, _ = bw.WriteString("\n")
+	}
+	_, _ = fmt.Fprintf(bw, "# passed: %d/%d errors: %d\n", r.Passed(), len(r.Positions), r.Errors())
+
+	return bw.Flush()
+}
@@ -0,0 +1,44 @@
+package search
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSuite(t *testing.T) {
+	f, err := os.Open("testdata/tactics.epd")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	report, err := RunSuite("tactics", f, Limits{Depth: 3})
+	require.NoError(t, err)
+
+	require.Len(t, report.Positions, 5)
+	assert.NotEqual(t, "Qd3", report.Positions[3].Found, "avoid move must not be played")
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteText(&buf))
+
+	expected := `# suite: tactics depth: 3 movetime: 0s
+mate.001 PASS found=Qxf7# bm=Qxf7#
+mate.002 PASS found=Ra8# bm=Ra8#
+capture.001 PASS found=Rxd5 bm=Rxd5
+avoid.001 PASS found=Qe3+ am=Qd3
+line-6 ERROR invalid epd: invalid fen: expected 8 ranks, got 4
+# passed: 4/5 errors: 1
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRunPosition_Fail(t *testing.T) {
+	pr := RunPosition(1, `6k1/5ppp/8/8/8/8/5PPP/R5K1 w - - am Ra8#; id "fail.001";`, Limits{Depth: 2})
+
+	require.NoError(t, pr.Err)
+	assert.Equal(t, "fail.001", pr.ID)
+	assert.False(t, pr.Passed)
+	assert.Equal(t, "Ra8#", pr.Found)
+}
@@ -0,0 +1,52 @@
+// Package versionx upgrades versioned JSON documents with a chain of migrations.
+// A versioned document is a JSON object with an integer "version" field starting at 1.
+package versionx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const versionKey = "version"
+
+var ErrUnsupportedVersion = errors.New("unsupported format version")
+
+// Migration upgrades doc by one version, the version field is updated by Upgrade.
+type Migration func(doc map[string]json.RawMessage) error
+
+// IsDocument data looks like a JSON object, as opposed to legacy binary formats.
+func IsDocument(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '{'
+}
+
+// Upgrade migrates the document data to version current,
+// migrations[i] upgrades version i+1 to i+2 and len(migrations) must be current-1.
+// Documents newer than current or without a version return ErrUnsupportedVersion.
+func Upgrade(data []byte, current int, migrations []Migration) ([]byte, error) {
+	if len(migrations) != current-1 {
+		return nil, fmt.Errorf("%d migrations for version %d", len(migrations), current)
+	}
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	var version int
+	if err := json.Unmarshal(doc[versionKey], &version); err != nil || version < 1 || version > current {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedVersion, doc[versionKey])
+	}
+	if version == current {
+		return data, nil
+	}
+
+	for ; version < current; version++ {
+		if err := migrations[version-1](doc); err != nil {
+			return nil, fmt.Errorf("failed to migrate version %d: %w", version, err)
+		}
+		doc[versionKey] = json.RawMessage(fmt.Sprint(version + 1))
+	}
+	return json.Marshal(doc)
+}
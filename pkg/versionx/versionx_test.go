@@ -0,0 +1,56 @@
+package versionx
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgrade(t *testing.T) {
+	migrations := []Migration{
+		// v2 renamed "name" to "title"
+		func(doc map[string]json.RawMessage) error {
+			doc["title"] = doc["name"]
+			delete(doc, "name")
+			return nil
+		},
+		// v3 added "tags"
+		func(doc map[string]json.RawMessage) error {
+			doc["tags"] = json.RawMessage(`[]`)
+			return nil
+		},
+	}
+
+	tt := []struct {
+		name     string
+		data     string
+		expected string
+		err      error
+	}{
+		{name: "v1", data: `{"version":1,"name":"a"}`, expected: `{"tags":[],"title":"a","version":3}`},
+		{name: "v2", data: `{"version":2,"title":"a"}`, expected: `{"tags":[],"title":"a","version":3}`},
+		{name: "current is unchanged", data: `{"version": 3, "title": "a", "tags": []}`, expected: `{"version": 3, "title": "a", "tags": []}`},
+		{name: "newer", data: `{"version":4}`, err: ErrUnsupportedVersion},
+		{name: "missing version", data: `{"title":"a"}`, err: ErrUnsupportedVersion},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := Upgrade([]byte(tc.data), 3, migrations)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(data))
+		})
+	}
+}
+
+func TestIsDocument(t *testing.T) {
+	assert.True(t, IsDocument([]byte(" \n{\"version\":1}")))
+	assert.False(t, IsDocument([]byte{0x3e, 0xff, 0x81}))
+	assert.False(t, IsDocument(nil))
+}
@@ -20,6 +20,8 @@ type Connection struct {
 	cancelFunc context.CancelFunc
 	mu         sync.Mutex
 	isClosed   bool
+	// writeMu serializes frames written by concurrent publishers
+	writeMu sync.Mutex
 }
 
 func NewConnection(
@@ -48,6 +50,10 @@ func (c *Connection) PublishJson(v any) error {
 	if err != nil {
 		return err
 	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
 	if err = wsutil.WriteServerText(c.rw, data); err != nil {
 		return err
 	}
@@ -73,6 +79,9 @@ func (c *Connection) ConsumeJson(v any) error {
 // The first close status written will be communicated to the client, subsequent calls will be ignored.
 // Does not close the connection, caller should call Close() to close the connection after writing close status code.
 func (c *Connection) WriteCloseStatusCode(code ws.StatusCode, msg string) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
 	if err := wsutil.WriteServerMessage(c.rw, ws.OpClose, ws.NewCloseFrameBody(code, msg)); err != nil {
 		return err
 	}
@@ -0,0 +1,153 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/httpx"
+	"github.com/dyxj/chess/pkg/room"
+	"github.com/dyxj/chess/test/testx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doRequest(t *testing.T, method string, path string, token string, payload any) *http.Response {
+	t.Helper()
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	buffer := new(bytes.Buffer)
+	if payload != nil {
+		require.NoError(t, json.NewEncoder(buffer).Encode(payload))
+	}
+
+	request, err := http.NewRequest(method, testSvr.URL+path, buffer)
+	require.NoError(t, err)
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := testSvr.Client().Do(request)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = resp.Body.Close()
+	})
+	return resp
+}
+
+func createCorrespondenceGame(t *testing.T) (string, map[engine.Color]string) {
+	t.Helper()
+
+	resp := doRequest(t, "POST", "/correspondence", "",
+		room.CorrespondenceCreateRequest{DaysPerMove: 3})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var created room.CorrespondenceCreateResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	assert.Equal(t, 3, created.DaysPerMove)
+	assert.Equal(t, engine.StartFEN, created.FEN)
+
+	tokens := make(map[engine.Color]string)
+	for _, color := range engine.Colors {
+		resp = doRequest(t, "POST", fmt.Sprintf("/correspondence/%s/join", created.Code), "",
+			room.CorrespondenceJoinRequest{Name: color.String() + " player", Color: color})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var joined room.JoinResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&joined))
+		require.NotEmpty(t, joined.Token)
+		tokens[color] = joined.Token
+	}
+	return created.Code, tokens
+}
+
+func TestCorrespondenceHandler(t *testing.T) {
+	code, tokens := createCorrespondenceGame(t)
+
+	resp := doRequest(t, "POST", fmt.Sprintf("/correspondence/%s/move", code), tokens[engine.White],
+		room.CorrespondenceMoveRequest{Move: "e2e4"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = doRequest(t, "GET", fmt.Sprintf("/correspondence/%s", code), tokens[engine.Black], nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var view room.CorrespondenceView
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&view))
+	assert.Equal(t, engine.Black, view.Color)
+	assert.Equal(t, []string{"e2e4"}, view.Moves)
+	assert.Equal(t, "white player", view.Players[engine.White])
+	assert.NotNil(t, view.Deadline)
+	assert.Equal(t, game.StateInProgress, view.Round.State)
+	assert.Equal(t, engine.Black, view.Round.ActiveColor)
+	assert.Equal(t, 1, view.Round.Count)
+}
+
+func TestCorrespondenceHandler_ShouldReturnBadRequest_IllegalMove(t *testing.T) {
+	code, tokens := createCorrespondenceGame(t)
+
+	tt := []struct {
+		name  string
+		token string
+		move  string
+		code  string
+	}{
+		{name: "not active color", token: tokens[engine.Black], move: "e7e5", code: "not_active_color"},
+		{name: "illegal move", token: tokens[engine.White], move: "e2e5", code: "invalid_piece_movement"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := doRequest(t, "POST", fmt.Sprintf("/correspondence/%s/move", code), tc.token,
+				room.CorrespondenceMoveRequest{Move: tc.move})
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+			var result httpx.ErrorResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			assert.Equal(t, tc.code, result.Details["code"])
+		})
+	}
+}
+
+func TestCorrespondenceHandler_ShouldReturnUnauthorized(t *testing.T) {
+	code, _ := createCorrespondenceGame(t)
+
+	for _, token := range []string{"", "invalid"} {
+		resp := doRequest(t, "GET", fmt.Sprintf("/correspondence/%s", code), token, nil)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		var result httpx.ErrorResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.Equal(t, httpx.CodeUnauthorized, result.Code)
+	}
+}
+
+func TestCorrespondenceHandler_ShouldReturnNotFound(t *testing.T) {
+	resp := doRequest(t, "GET", "/correspondence/invalid", "token", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestCorrespondenceHandler_ShouldReturnBadRequest_InvalidDaysPerMove(t *testing.T) {
+	resp := doRequest(t, "POST", "/correspondence", "",
+		room.CorrespondenceCreateRequest{DaysPerMove: 31})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestCorrespondenceHandler_ShouldReturnBadRequest_MissingColor(t *testing.T) {
+	resp := doRequest(t, "POST", "/correspondence", "",
+		room.CorrespondenceCreateRequest{DaysPerMove: 3})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var created room.CorrespondenceCreateResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+
+	resp = doRequest(t, "POST", fmt.Sprintf("/correspondence/%s/join", created.Code), "",
+		map[string]string{"name": "no color"})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var result httpx.ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Contains(t, result.Details, "color")
+}
@@ -0,0 +1,161 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/room"
+	"github.com/dyxj/chess/test/testx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// connectPlayers connects black then white and consumes the events up to the initial round,
+// which is returned for each color.
+func connectPlayers(
+	t *testing.T,
+	wToken string,
+	bToken string,
+) (map[engine.Color]chan room.EventPartial, map[engine.Color]net.Conn, map[engine.Color]game.RoundResult) {
+	t.Helper()
+	logger := testx.GlobalEnv().Logger()
+	addr := testx.GlobalEnv().HTTTPTestServer().Listener.Addr().String()
+
+	eventChans := make(map[engine.Color]chan room.EventPartial)
+	conns := make(map[engine.Color]net.Conn)
+	rounds := make(map[engine.Color]game.RoundResult)
+
+	for _, color := range []engine.Color{engine.Black, engine.White} {
+		token := wToken
+		if color == engine.Black {
+			token = bToken
+		}
+		eventChan, conn, err := websocketDialAndListen(fmt.Sprintf(connectURLFormat, addr, token), logger)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+		eventChans[color] = eventChan
+		conns[color] = conn
+
+		if color == engine.Black {
+			e, ok := <-eventChan
+			require.True(t, ok)
+			require.Equal(t, room.EventTypeMessage, e.EventType)
+		}
+	}
+
+	for color, eventChan := range eventChans {
+		e, ok := <-eventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoomReady, e.EventType)
+
+		e, ok = <-eventChan
+		require.True(t, ok)
+		rr, err := extractRoundResult(e)
+		require.NoError(t, err)
+		rounds[color] = rr
+	}
+
+	return eventChans, conns, rounds
+}
+
+func resumeRoom(t *testing.T, code string, key string) *http.Response {
+	t.Helper()
+	return doRequest(t, "POST", fmt.Sprintf("/room/%s/resume", code), key, nil)
+}
+
+func TestRoomConnectHandler_AdjournAndResume(t *testing.T) {
+	go testx.SetTimeout(t.Context(), 10*time.Second)
+
+	c := testx.GlobalEnv().RoomCoordinator()
+
+	code, wToken, bToken, err := createRoomAndTokens(c, room.Config{
+		Rules: game.Rules{TimeControl: game.Fischer(time.Minute, time.Second)},
+	})
+	require.NoError(t, err)
+
+	eventChans, conns, _ := connectPlayers(t, wToken, bToken)
+
+	// 1. e4
+	require.NoError(t, writeActionMove(conns[engine.White], engine.Pawn, new(12), new(28)))
+	for _, eventChan := range eventChans {
+		e, ok := <-eventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoundResult, e.EventType)
+	}
+
+	require.NoError(t, writeAction(conns[engine.Black], room.NewActionAdjourn(room.ActionTypeAdjournAccept)))
+	e, ok := <-eventChans[engine.Black]
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeError, e.EventType, "accept without offer")
+
+	require.NoError(t, writeAction(conns[engine.White], room.NewActionAdjourn(room.ActionTypeAdjourn)))
+	e, ok = <-eventChans[engine.Black]
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeAdjournOffer, e.EventType)
+	var offer room.EventAdjournOfferPayload
+	require.NoError(t, json.Unmarshal(e.Payload, &offer))
+	assert.Equal(t, engine.White, offer.Color)
+
+	require.NoError(t, writeAction(conns[engine.Black], room.NewActionAdjourn(room.ActionTypeAdjournAccept)))
+
+	keys := make(map[engine.Color]string)
+	for color, eventChan := range eventChans {
+		e, ok := <-eventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeAdjourned, e.EventType)
+
+		var adjourned room.EventAdjournedPayload
+		require.NoError(t, json.Unmarshal(e.Payload, &adjourned))
+		assert.Equal(t, code, adjourned.Code)
+		require.NotEmpty(t, adjourned.Key)
+		keys[color] = adjourned.Key
+
+		// connections are released
+		_, ok = <-eventChan
+		assert.False(t, ok)
+	}
+
+	resp := resumeRoom(t, code, "invalid")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	tokens := make(map[engine.Color]string)
+	for color, key := range keys {
+		resp := resumeRoom(t, code, key)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var joined room.JoinResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&joined))
+		tokens[color] = joined.Token
+	}
+
+	_, err = c.IssueTicketToken(code, "stranger", room.Seat{Color: engine.White})
+	assert.ErrorIs(t, err, room.ErrRoomFull, "seats are reserved")
+
+	eventChans, conns, rounds := connectPlayers(t, tokens[engine.White], tokens[engine.Black])
+	for _, rr := range rounds {
+		assert.Equal(t, 1, rr.Count)
+		assert.Equal(t, engine.Black, rr.ActiveColor)
+		assert.Equal(t, engine.Pawn, engine.Symbol(rr.Grid[28]))
+		assert.Greater(t, rr.Remaining[engine.White], int64(60000), "increment kept")
+		assert.LessOrEqual(t, rr.Remaining[engine.Black], int64(60000))
+	}
+
+	// 1... e5
+	require.NoError(t, writeActionMove(conns[engine.Black], engine.Pawn, new(52), new(36)))
+	for _, eventChan := range eventChans {
+		e, ok := <-eventChan
+		require.True(t, ok)
+		rr, err := extractRoundResult(e)
+		require.NoError(t, err)
+		assert.Equal(t, 2, rr.Count)
+	}
+
+	resp = resumeRoom(t, code, keys[engine.White])
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "adjourned game deleted once resumed")
+}
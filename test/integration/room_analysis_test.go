@@ -0,0 +1,121 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/room"
+	"github.com/dyxj/chess/test/testx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const analysisURLFormat = "http://%s/room/%s/analysis"
+
+func TestRoomAnalysisHandler(t *testing.T) {
+	go testx.SetTimeout(t.Context(), 10*time.Second)
+
+	logger := testx.GlobalEnv().Logger()
+
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	c := testx.GlobalEnv().RoomCoordinator()
+
+	code, wToken, bToken, err := createRoomAndTokens(c)
+	require.NoError(t, err)
+	logger.Printf("code: %s, wToken: %s, bToken: %s\n", code, wToken, bToken)
+
+	status, _ := getAnalysis(t, testSvr.Listener.Addr().String(), code)
+	assert.Equal(t, http.StatusBadRequest, status, "game is not over")
+
+	bEventChan, bConn, err := websocketDialAndListen(
+		fmt.Sprintf(connectURLFormat, testSvr.Listener.Addr().String(), bToken),
+		logger,
+	)
+	require.NoError(t, err)
+	defer bConn.Close()
+
+	b1, ok := <-bEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeMessage, b1.EventType)
+
+	wEventChan, wConn, err := websocketDialAndListen(
+		fmt.Sprintf(connectURLFormat, testSvr.Listener.Addr().String(), wToken),
+		logger,
+	)
+	require.NoError(t, err)
+	defer wConn.Close()
+
+	for _, eventChan := range []chan room.EventPartial{wEventChan, bEventChan} {
+		e, ok := <-eventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoomReady, e.EventType)
+
+		e, ok = <-eventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoundResult, e.EventType)
+	}
+
+	mConn := wConn
+	for i, move := range quickestCheckmate() {
+		err := writeActionMove(mConn, move.Payload.Symbol, move.Payload.From, move.Payload.To)
+		require.NoError(t, err, fmt.Sprintf("move %d failed", i))
+		if mConn == wConn {
+			mConn = bConn
+		} else {
+			mConn = wConn
+		}
+
+		for _, eventChan := range []chan room.EventPartial{wEventChan, bEventChan} {
+			e, ok := <-eventChan
+			require.True(t, ok)
+			require.Equal(t, room.EventTypeRoundResult, e.EventType, fmt.Sprintf("move %d", i))
+		}
+	}
+
+	var a room.GameAnalysis
+	require.Eventually(t, func() bool {
+		status, body := getAnalysis(t, testSvr.Listener.Addr().String(), code)
+		if status == http.StatusAccepted {
+			return false
+		}
+		require.Equal(t, http.StatusOK, status)
+		require.NoError(t, json.Unmarshal(body, &a))
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, code, a.Code)
+	assert.Equal(t, room.AnalysisReady, a.Status)
+	require.NotNil(t, a.Analysis)
+	require.Len(t, a.Analysis.Moves, 4)
+
+	f3 := a.Analysis.Moves[2]
+	assert.Equal(t, "f3", f3.SAN)
+	assert.Equal(t, game.ClassificationBlunder, f3.Classification)
+	assert.Equal(t, 1, a.Analysis.White.Blunders)
+	assert.Equal(t, "Qh4#", a.Analysis.Moves[3].SAN)
+	assert.Zero(t, a.Analysis.Moves[3].Loss)
+	assert.Contains(t, a.Analysis.PGN, "2. f3 $4 {"+f3.Best+" was best.} 2... Qh4# 0-1")
+}
+
+func TestRoomAnalysisHandler_ShouldReturnNotFound(t *testing.T) {
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	status, _ := getAnalysis(t, testSvr.Listener.Addr().String(), "unknown")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func getAnalysis(t *testing.T, addr string, code string) (int, []byte) {
+	t.Helper()
+	resp, err := http.Get(fmt.Sprintf(analysisURLFormat, addr, code))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body json.RawMessage
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
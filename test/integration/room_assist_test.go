@@ -0,0 +1,129 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/room"
+	"github.com/dyxj/chess/test/testx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomConnectHandler_Assist(t *testing.T) {
+	go testx.SetTimeout(t.Context(), 10*time.Second)
+
+	logger := testx.GlobalEnv().Logger()
+
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	c := testx.GlobalEnv().RoomCoordinator()
+
+	code, wToken, bToken, err := createRoomAndTokens(c)
+	require.NoError(t, err)
+	logger.Printf("code: %s, wToken: %s, bToken: %s\n", code, wToken, bToken)
+
+	bEventChan, bConn, err := websocketDialAndListen(
+		fmt.Sprintf(connectURLFormat, testSvr.Listener.Addr().String(), bToken),
+		logger,
+	)
+	require.NoError(t, err)
+	defer bConn.Close()
+
+	b1, ok := <-bEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeMessage, b1.EventType)
+
+	// only white opts in to assist
+	wEventChan, wConn, err := websocketDialAndListen(
+		fmt.Sprintf(connectURLFormat+"&assist=true", testSvr.Listener.Addr().String(), wToken),
+		logger,
+	)
+	require.NoError(t, err)
+	defer wConn.Close()
+
+	for _, eventChan := range []chan room.EventPartial{wEventChan, bEventChan} {
+		e, ok := <-eventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoomReady, e.EventType)
+
+		e, ok = <-eventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoundResult, e.EventType)
+	}
+
+	h := readHint(t, wEventChan)
+	assert.Equal(t, game.Hint{Count: 0, Color: engine.White, Hanging: []int{}}, h)
+
+	moves := []room.ActionMove{
+		room.NewActionMove(engine.Pawn, new(12), new(28)), // 1. e4   (e2->e4)
+		room.NewActionMove(engine.Pawn, new(51), new(35)), // 1... d5 (d7->d5)
+		room.NewActionMove(engine.Queen, new(3), new(30)), // 2. Qg4?? (d1->g4)
+	}
+	expHints := []game.Hint{
+		{Count: 1, Color: engine.White, Hanging: []int{}},
+		{
+			Count:   2,
+			Color:   engine.White,
+			Hanging: []int{28},
+			Captures: []game.MoveResult{
+				{Color: engine.White, Symbol: engine.Pawn, From: 28, To: 35, Captured: engine.Pawn},
+			},
+		},
+		{Count: 3, Color: engine.White, Hanging: []int{30}, MaterialLoss: 9},
+	}
+
+	mConn := wConn
+	for i, move := range moves {
+		err := writeActionMove(mConn, move.Payload.Symbol, move.Payload.From, move.Payload.To)
+		require.NoError(t, err, fmt.Sprintf("move %d failed", i))
+		if mConn == wConn {
+			mConn = bConn
+		} else {
+			mConn = wConn
+		}
+
+		wm, ok := <-wEventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoundResult, wm.EventType)
+		assert.Equal(t, expHints[i], readHint(t, wEventChan), fmt.Sprintf("move %d", i))
+
+		bm, ok := <-bEventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoundResult, bm.EventType, fmt.Sprintf("move %d", i))
+	}
+
+	// black never receives a hint, the next event is the reply to describe
+	err = writeAction(bConn, room.NewActionDescribe(room.DescribeByPiece))
+	require.NoError(t, err)
+	be, ok := <-bEventChan
+	require.True(t, ok)
+	assert.Equal(t, room.EventTypeDescription, be.EventType)
+}
+
+func TestRoomConnectHandler_ShouldReturnBadRequest_InvalidAssist(t *testing.T) {
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/room/connect?token=abc&assist=maybe",
+		testSvr.Listener.Addr().String()))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func readHint(t *testing.T, eventChan chan room.EventPartial) game.Hint {
+	t.Helper()
+	e, ok := <-eventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeHint, e.EventType)
+
+	var h game.Hint
+	require.NoError(t, json.Unmarshal(e.Payload, &h))
+	return h
+}
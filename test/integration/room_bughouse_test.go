@@ -0,0 +1,153 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/room"
+	"github.com/dyxj/chess/test/testx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bughouseClient struct {
+	seat      room.Seat
+	conn      net.Conn
+	eventChan chan room.EventPartial
+}
+
+func nextEvent(t *testing.T, client bughouseClient, eventType room.EventType) room.EventPartial {
+	t.Helper()
+	e, ok := <-client.eventChan
+	require.True(t, ok, client.seat.String())
+	require.Equal(t, eventType, e.EventType, client.seat.String())
+	return e
+}
+
+func nextBughouseRound(t *testing.T, client bughouseClient) room.EventBughouseRoundPayload {
+	t.Helper()
+	e := nextEvent(t, client, room.EventTypeRoundResult)
+	var p room.EventBughouseRoundPayload
+	require.NoError(t, json.Unmarshal(e.Payload, &p))
+	return p
+}
+
+func TestRoomConnectHandler_Bughouse(t *testing.T) {
+	go testx.SetTimeout(t.Context(), 10*time.Second)
+
+	logger := testx.GlobalEnv().Logger()
+
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	c := testx.GlobalEnv().RoomCoordinator()
+
+	r, err := c.CreateBughouseRoom()
+	require.NoError(t, err)
+
+	_, err = c.IssueTicketToken(r.Code, "player", room.Seat{Board: 2, Color: engine.White})
+	assert.ErrorIs(t, err, room.ErrInvalidSeat)
+
+	clients := make([]bughouseClient, 0, len(room.BughouseSeats))
+	for i, seat := range room.BughouseSeats {
+		token, err := c.IssueTicketToken(r.Code, fmt.Sprintf("player %d", i), seat)
+		require.NoError(t, err)
+
+		eventChan, conn, err := websocketDialAndListen(
+			fmt.Sprintf(connectURLFormat, testSvr.Listener.Addr().String(), token),
+			logger,
+		)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		client := bughouseClient{seat: seat, conn: conn, eventChan: eventChan}
+		clients = append(clients, client)
+
+		if i < len(room.BughouseSeats)-1 {
+			nextEvent(t, client, room.EventTypeMessage)
+		}
+	}
+	w0, b0, w1, b1 := clients[0], clients[1], clients[2], clients[3]
+
+	for _, client := range clients {
+		e := nextEvent(t, client, room.EventTypeRoomReady)
+		var p room.EventBughouseReadyPayload
+		require.NoError(t, json.Unmarshal(e.Payload, &p))
+		assert.Equal(t, engine.VariantBughouse, p.Variant)
+		assert.Equal(t, client.seat, p.Seat)
+		assert.Len(t, p.Players, 4)
+
+		for board := range game.BughouseBoards {
+			rr := nextBughouseRound(t, client)
+			assert.Equal(t, board, rr.Board)
+			assert.Equal(t, 0, rr.Count)
+		}
+	}
+
+	// board 0: 1. e4 d5 2. exd5
+	moves := []struct {
+		client bughouseClient
+		move   room.ActionMove
+	}{
+		{w0, room.NewActionMove(engine.Pawn, new(12), new(28))},
+		{b0, room.NewActionMove(engine.Pawn, new(51), new(35))},
+		{w0, room.NewActionMove(engine.Pawn, new(28), new(35))},
+	}
+	for i, m := range moves {
+		err := writeActionMove(m.client.conn, m.move.Payload.Symbol, m.move.Payload.From, m.move.Payload.To)
+		require.NoError(t, err, fmt.Sprintf("move %d failed", i))
+		for _, client := range clients {
+			rr := nextBughouseRound(t, client)
+			assert.Equal(t, 0, rr.Board)
+			assert.Equal(t, i+1, rr.Count)
+		}
+	}
+
+	// captured pawn is passed to the partner of white on board 0
+	for _, client := range clients {
+		rr := nextBughouseRound(t, client)
+		assert.Equal(t, 1, rr.Board)
+		assert.Equal(t, []engine.Symbol{engine.Pawn}, rr.Pockets[engine.Black])
+	}
+
+	// team chat only reaches the partner
+	require.NoError(t, writeAction(w0.conn, room.NewActionChat("pawn incoming")))
+	e := nextEvent(t, b1, room.EventTypeChat)
+	var chat room.EventChatPayload
+	require.NoError(t, json.Unmarshal(e.Payload, &chat))
+	assert.Equal(t, w0.seat, chat.From)
+	assert.Equal(t, "player 0", chat.Name)
+	assert.Equal(t, "pawn incoming", chat.Message)
+
+	// board 1: fool's mate ends the match
+	moves = []struct {
+		client bughouseClient
+		move   room.ActionMove
+	}{
+		{w1, room.NewActionMove(engine.Pawn, new(13), new(21))},
+		{b1, room.NewActionMove(engine.Pawn, new(52), new(36))},
+		{w1, room.NewActionMove(engine.Pawn, new(14), new(30))},
+		{b1, room.NewActionMove(engine.Queen, new(59), new(31))},
+	}
+	for i, m := range moves {
+		err := writeActionMove(m.client.conn, m.move.Payload.Symbol, m.move.Payload.From, m.move.Payload.To)
+		require.NoError(t, err, fmt.Sprintf("move %d failed", i))
+		for _, client := range clients {
+			rr := nextBughouseRound(t, client)
+			assert.Equal(t, 1, rr.Board)
+		}
+	}
+
+	for _, client := range clients {
+		e := nextEvent(t, client, room.EventTypeMatchOver)
+		var p room.EventMatchOverPayload
+		require.NoError(t, json.Unmarshal(e.Payload, &p))
+		assert.Equal(t, 1, p.Board)
+		assert.Equal(t, game.StateCheckmate, p.State)
+		assert.Equal(t, []room.Seat{b1.seat, w0.seat}, p.Winners)
+	}
+}
@@ -0,0 +1,149 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/room"
+	"github.com/dyxj/chess/test/testx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomConnectHandler_Timeout(t *testing.T) {
+	go testx.SetTimeout(t.Context(), 10*time.Second)
+
+	logger := testx.GlobalEnv().Logger()
+
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	c := testx.GlobalEnv().RoomCoordinator()
+
+	timeControl := game.SuddenDeath(500 * time.Millisecond)
+	code, wToken, bToken, err := createRoomAndTokens(c, room.Config{
+		Rules: game.Rules{TimeControl: timeControl},
+	})
+	require.NoError(t, err)
+	logger.Printf("code: %s, wToken: %s, bToken: %s\n", code, wToken, bToken)
+
+	bEventChan, bConn, err := websocketDialAndListen(
+		fmt.Sprintf(connectURLFormat, testSvr.Listener.Addr().String(), bToken),
+		logger,
+	)
+	require.NoError(t, err)
+	defer bConn.Close()
+
+	// Wait for black player to get "waiting" message
+	b1, ok := <-bEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeMessage, b1.EventType)
+
+	wEventChan, wConn, err := websocketDialAndListen(
+		fmt.Sprintf(connectURLFormat, testSvr.Listener.Addr().String(), wToken),
+		logger,
+	)
+	require.NoError(t, err)
+	defer wConn.Close()
+
+	for _, eventChan := range []chan room.EventPartial{wEventChan, bEventChan} {
+		e, ok := <-eventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoomReady, e.EventType)
+
+		e, ok = <-eventChan
+		require.True(t, ok)
+		rr, err := extractRoundResult(e)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, rr.Remaining[engine.White], int64(500))
+		assert.Equal(t, int64(500), rr.Remaining[engine.Black])
+	}
+
+	// 1. e4, black never replies
+	err = writeActionMove(wConn, engine.Pawn, new(12), new(28))
+	require.NoError(t, err)
+
+	for _, eventChan := range []chan room.EventPartial{wEventChan, bEventChan} {
+		e, ok := <-eventChan
+		require.True(t, ok)
+		rr, err := extractRoundResult(e)
+		require.NoError(t, err)
+		assert.Equal(t, 1, rr.Count)
+		assert.Less(t, rr.Remaining[engine.White], int64(500))
+		assert.LessOrEqual(t, rr.Remaining[engine.Black], int64(500))
+	}
+
+	for _, eventChan := range []chan room.EventPartial{wEventChan, bEventChan} {
+		e, ok := <-eventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeTimeout, e.EventType)
+
+		var payload room.EventTimeoutPayload
+		require.NoError(t, json.Unmarshal(e.Payload, &payload))
+		assert.Equal(t, room.EventTimeoutPayload{
+			Flagged: engine.Black,
+			State:   game.StateTimeout,
+			Winner:  engine.White,
+		}, payload)
+
+		// room ends after timeout
+		_, ok = <-eventChan
+		assert.False(t, ok)
+	}
+}
+
+func TestRoomConnectHandler_TimeoutDrawInsufficientMaterial(t *testing.T) {
+	go testx.SetTimeout(t.Context(), 10*time.Second)
+
+	logger := testx.GlobalEnv().Logger()
+
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	c := testx.GlobalEnv().RoomCoordinator()
+
+	code, wToken, bToken, err := createRoomAndTokens(c, room.Config{
+		FEN:   "4k3/8/8/8/8/8/8/R3K3 w - - 0 1",
+		Rules: game.Rules{TimeControl: game.SuddenDeath(200 * time.Millisecond)},
+	})
+	require.NoError(t, err)
+	logger.Printf("code: %s, wToken: %s, bToken: %s\n", code, wToken, bToken)
+
+	wEventChan, wConn, err := websocketDialAndListen(
+		fmt.Sprintf(connectURLFormat, testSvr.Listener.Addr().String(), wToken),
+		logger,
+	)
+	require.NoError(t, err)
+	defer wConn.Close()
+
+	w1, ok := <-wEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeMessage, w1.EventType)
+
+	bEventChan, bConn, err := websocketDialAndListen(
+		fmt.Sprintf(connectURLFormat, testSvr.Listener.Addr().String(), bToken),
+		logger,
+	)
+	require.NoError(t, err)
+	defer bConn.Close()
+
+	// white never moves and flags against a lone king
+	for _, eventChan := range []chan room.EventPartial{wEventChan, bEventChan} {
+		var e room.EventPartial
+		for e = range eventChan {
+			if e.EventType == room.EventTypeTimeout {
+				break
+			}
+		}
+		require.Equal(t, room.EventTypeTimeout, e.EventType)
+
+		var payload room.EventTimeoutPayload
+		require.NoError(t, json.Unmarshal(e.Payload, &payload))
+		assert.Equal(t, room.EventTimeoutPayload{
+			Flagged: engine.White,
+			State:   game.StateDraw,
+		}, payload)
+	}
+}
@@ -164,6 +164,19 @@ func TestRoomConnectHandler_SendActionMove(t *testing.T) {
 		State:       game.StateInProgress,
 		Grid:        [64]int{4, 2, 3, 5, 6, 3, 2, 4, 1, 1, 1, 0, 1, 1, 1, 1, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, -1, -1, -1, -1, -1, -1, -1, -1, -4, -2, -3, -5, -6, -3, -2, -4},
 		ActiveColor: engine.Black,
+		Material:    map[engine.Color]int{engine.White: 39, engine.Black: 39},
+		LegalMoves: map[int][]game.LegalMove{
+			48: {{To: 32}, {To: 40}},
+			49: {{To: 33}, {To: 41}},
+			50: {{To: 34}, {To: 42}},
+			51: {{To: 35}, {To: 43}},
+			52: {{To: 36}, {To: 44}},
+			53: {{To: 37}, {To: 45}},
+			54: {{To: 38}, {To: 46}},
+			55: {{To: 39}, {To: 47}},
+			57: {{To: 40}, {To: 42}},
+			62: {{To: 45}, {To: 47}},
+		},
 	}
 
 	w2, ok := <-wEventChan
@@ -197,6 +210,22 @@ func TestRoomConnectHandler_SendActionMove(t *testing.T) {
 		State:       game.StateInProgress,
 		Grid:        [64]int{4, 2, 3, 5, 6, 3, 2, 4, 1, 1, 1, 0, 1, 1, 1, 1, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, -1, 0, 0, 0, 0, 0, 0, 0, 0, -1, -1, -1, -1, -1, -1, -1, -4, -2, -3, -5, -6, -3, -2, -4},
 		ActiveColor: engine.White,
+		Material:    map[engine.Color]int{engine.White: 39, engine.Black: 39},
+		LegalMoves: map[int][]game.LegalMove{
+			1:  {{To: 11}, {To: 16}, {To: 18}},
+			2:  {{To: 11}, {To: 20}, {To: 29}, {To: 38}, {To: 47}},
+			3:  {{To: 11}},
+			4:  {{To: 11}},
+			6:  {{To: 21}, {To: 23}},
+			8:  {{To: 16}, {To: 24}},
+			9:  {{To: 17}, {To: 25}},
+			10: {{To: 18}, {To: 26}},
+			12: {{To: 20}, {To: 28}},
+			13: {{To: 21}, {To: 29}},
+			14: {{To: 22}, {To: 30}},
+			15: {{To: 23}, {To: 31}},
+			19: {{To: 27}},
+		},
 	}
 
 	w3, ok := <-wEventChan
@@ -471,9 +500,9 @@ func TestRoomConnectHandler_TerminalError_ColorOccupied(t *testing.T) {
 	require.NoError(t, err)
 
 	// Issue two tokens for the same color while room is still waiting
-	w1Token, err := c.IssueTicketToken(r.Code, "white player 1", engine.White)
+	w1Token, err := c.IssueTicketToken(r.Code, "white player 1", room.Seat{Color: engine.White})
 	require.NoError(t, err)
-	w2Token, err := c.IssueTicketToken(r.Code, "white player 2", engine.White)
+	w2Token, err := c.IssueTicketToken(r.Code, "white player 2", room.Seat{Color: engine.White})
 	require.NoError(t, err)
 	logger.Printf("code: %s, w1Token: %s, w2Token: %s\n", r.Code, w1Token, w2Token)
 
@@ -523,7 +552,7 @@ func TestRoomConnectHandler_TerminalError_RoomFull(t *testing.T) {
 	// Issue all tokens before anyone connects (room still StatusWaiting)
 	code, wToken, bToken, err := createRoomAndTokens(c)
 	require.NoError(t, err)
-	w2Token, err := c.IssueTicketToken(code, "white player 2", engine.White)
+	w2Token, err := c.IssueTicketToken(code, "white player 2", room.Seat{Color: engine.White})
 	require.NoError(t, err)
 	logger.Printf("code: %s\n", code)
 
@@ -632,6 +661,7 @@ func TestRoomConnectHandler_GameError_WrongColor(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, errPayload.Terminal)
 	require.Equal(t, engine.ErrNotActiveColor.Error(), errPayload.Error)
+	require.Equal(t, room.ErrCodeNotActiveColor, errPayload.Code)
 }
 
 func TestRoomConnectHandler_GameError_IllegalMove(t *testing.T) {
@@ -685,7 +715,25 @@ func TestRoomConnectHandler_GameError_IllegalMove(t *testing.T) {
 	err = json.Unmarshal(wErr.Payload, &errPayload)
 	require.NoError(t, err)
 	require.False(t, errPayload.Terminal)
-	require.Equal(t, game.ErrIllegalMove.Error(), errPayload.Error)
+	require.Equal(t, "illegal move: pawn cannot move from a2 to a1", errPayload.Error)
+	require.Equal(t, string(engine.IllegalPieceMovement), errPayload.Code)
+	require.Nil(t, errPayload.Square)
+
+	// White tries to move bishop c1 to e3 through pawn on d2
+	err = writeActionMove(wConn, engine.Bishop, new(2), new(20))
+	require.NoError(t, err)
+
+	wErr, ok = <-wEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeError, wErr.EventType)
+
+	errPayload = room.EventErrorPayload{}
+	err = json.Unmarshal(wErr.Payload, &errPayload)
+	require.NoError(t, err)
+	require.Equal(t, "illegal move: path from c1 to e3 is blocked by white pawn on d2", errPayload.Error)
+	require.Equal(t, string(engine.IllegalPathBlocked), errPayload.Code)
+	require.NotNil(t, errPayload.Square)
+	require.Equal(t, 11, *errPayload.Square)
 }
 
 func TestRoomConnectHandler_ActionPayload_ValidationErrors(t *testing.T) {
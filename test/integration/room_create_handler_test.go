@@ -3,12 +3,17 @@ package integration
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/httpx"
 	"github.com/dyxj/chess/pkg/room"
 	"github.com/dyxj/chess/test/testx"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRoomCreateHandler(t *testing.T) {
@@ -41,5 +46,250 @@ func TestRoomCreateHandler(t *testing.T) {
 
 	assert.Len(t, result.Code, 6)
 	assert.Equal(t, "waiting", result.Status)
+	assert.Equal(t, engine.VariantStandard, result.Variant)
 	assert.WithinDuration(t, time.Now(), result.CreatedTime, 5*time.Second)
 }
+
+func TestRoomCreateHandler_Variant(t *testing.T) {
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	memCache := testx.GlobalEnv().MemCache()
+	t.Cleanup(func() {
+		memCache.Clear()
+	})
+
+	request, err := http.NewRequest(
+		"POST",
+		testSvr.URL+"/room",
+		strings.NewReader(`{"variant":"atomic"}`))
+	assert.NoError(t, err)
+
+	resp, err := testSvr.Client().Do(request)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result room.CreateResponse
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, engine.VariantAtomic, result.Variant)
+
+	r, ok := room.NewMemCache(memCache).Find(result.Code)
+	require.True(t, ok)
+	assert.Equal(t, engine.VariantAtomic, r.Variant())
+}
+
+func TestRoomCreateHandler_ShouldReturnBadRequest_UnknownVariant(t *testing.T) {
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	request, err := http.NewRequest(
+		"POST",
+		testSvr.URL+"/room",
+		strings.NewReader(`{"variant":"chess960"}`))
+	assert.NoError(t, err)
+
+	resp, err := testSvr.Client().Do(request)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestRoomCreateHandler_StartingPosition(t *testing.T) {
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	memCache := testx.GlobalEnv().MemCache()
+	t.Cleanup(func() {
+		memCache.Clear()
+	})
+
+	tt := []struct {
+		name        string
+		body        string
+		expectedFEN string
+	}{
+		{
+			name:        "knight odds",
+			body:        `{"odds":"knight"}`,
+			expectedFEN: engine.OddsKnight.FEN(),
+		},
+		{
+			name:        "custom position",
+			body:        `{"fen":"4k3/8/8/8/8/8/4P3/4K3 b - - 0 1"}`,
+			expectedFEN: "4k3/8/8/8/8/8/4P3/4K3 b - - 0 1",
+		},
+		{
+			name:        "default start position",
+			body:        `{}`,
+			expectedFEN: engine.StartFEN,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", testSvr.URL+"/room", strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			resp, err := testSvr.Client().Do(request)
+			require.NoError(t, err)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var result room.CreateResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			assert.Equal(t, tc.expectedFEN, result.FEN)
+
+			r, ok := room.NewMemCache(memCache).Find(result.Code)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedFEN, r.StartFEN())
+		})
+	}
+}
+
+func TestRoomCreateHandler_ShouldReturnBadRequest_InvalidStartingPosition(t *testing.T) {
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	tt := []struct {
+		name        string
+		body        string
+		expectedKey string
+	}{
+		{
+			name: "malformed fen",
+			body: `{"fen":"not a fen"}`,
+		},
+		{
+			name:        "two white kings",
+			body:        `{"fen":"4k3/8/8/8/8/8/8/3KK3 w - - 0 1"}`,
+			expectedKey: string(engine.ProblemKingCount),
+		},
+		{
+			name:        "checkmated before the start",
+			body:        `{"fen":"k7/1Q6/1K6/8/8/8/8/8 b - - 0 1"}`,
+			expectedKey: string(room.ProblemNoLegalMoves),
+		},
+		{
+			name: "fen and odds",
+			body: `{"fen":"4k3/8/8/8/8/8/4P3/4K3 w - - 0 1","odds":"queen"}`,
+		},
+		{
+			name: "unknown odds",
+			body: `{"odds":"bishop"}`,
+		},
+		{
+			name: "bughouse from a position",
+			body: `{"variant":"bughouse","odds":"rook"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", testSvr.URL+"/room", strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			resp, err := testSvr.Client().Do(request)
+			require.NoError(t, err)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+			var result httpx.ErrorResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			if tc.expectedKey != "" {
+				assert.Contains(t, result.Details, tc.expectedKey)
+			}
+		})
+	}
+}
+
+func TestRoomCreateHandler_TimeControl(t *testing.T) {
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	memCache := testx.GlobalEnv().MemCache()
+	t.Cleanup(func() {
+		memCache.Clear()
+	})
+
+	body := `{"timeControl":{"bonus":"fischer","stages":[` +
+		`{"moves":40,"time":5400,"increment":30},{"time":1800,"increment":30}]}}`
+	request, err := http.NewRequest("POST", testSvr.URL+"/room", strings.NewReader(body))
+	require.NoError(t, err)
+
+	resp, err := testSvr.Client().Do(request)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result room.CreateResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Equal(t, &room.TimeControlSeconds{
+		Bonus: game.BonusFischer,
+		Stages: []room.StageSeconds{
+			{Moves: 40, Time: 5400, Increment: 30},
+			{Time: 1800, Increment: 30},
+		},
+	}, result.TimeControl)
+
+	r, ok := room.NewMemCache(memCache).Find(result.Code)
+	require.True(t, ok)
+	assert.True(t, r.IsTimed())
+	assert.Equal(t, 90*time.Minute, r.Game.Remaining(engine.White))
+}
+
+func TestRoomCreateHandler_ShouldReturnBadRequest_InvalidTimeControl(t *testing.T) {
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	tt := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "no time",
+			body: `{"timeControl":{"stages":[{"time":0}]}}`,
+		},
+		{
+			name: "unknown bonus",
+			body: `{"timeControl":{"bonus":"hourglass","stages":[{"time":60}]}}`,
+		},
+		{
+			name: "final stage with moves",
+			body: `{"timeControl":{"stages":[{"moves":40,"time":60}]}}`,
+		},
+		{
+			name: "timed bughouse",
+			body: `{"variant":"bughouse","timeControl":{"stages":[{"time":60}]}}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := http.NewRequest("POST", testSvr.URL+"/room", strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			resp, err := testSvr.Client().Do(request)
+			require.NoError(t, err)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
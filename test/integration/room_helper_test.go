@@ -72,18 +72,19 @@ func websocketDialAndListen(
 
 func createRoomAndTokens(
 	c *room.Coordinator,
+	cfg ...room.Config,
 ) (code string, wToken string, bToken string, err error) {
 
-	r, err := c.CreateRoom()
+	r, err := c.CreateRoom(cfg...)
 	if err != nil {
 		return "", "", "", err
 	}
 
-	wToken, err = c.IssueTicketToken(r.Code, "white player", engine.White)
+	wToken, err = c.IssueTicketToken(r.Code, "white player", room.Seat{Color: engine.White})
 	if err != nil {
 		return "", "", "", err
 	}
-	bToken, err = c.IssueTicketToken(r.Code, "black player", engine.Black)
+	bToken, err = c.IssueTicketToken(r.Code, "black player", room.Seat{Color: engine.Black})
 	if err != nil {
 		return "", "", "", err
 	}
@@ -115,6 +116,23 @@ func writeActionMove(
 	return nil
 }
 
+func writeActionDrop(
+	conn net.Conn,
+	symbol engine.Symbol,
+	to *int,
+) error {
+	return writeAction(conn, room.NewActionDrop(symbol, to))
+}
+
+func writeAction(conn net.Conn, action any) error {
+	data, err := json.Marshal(action)
+	if err != nil {
+		return err
+	}
+
+	return wsutil.WriteClientText(conn, data)
+}
+
 func extractRoundResult(event room.EventPartial) (game.RoundResult, error) {
 	var result game.RoundResult
 	err := json.Unmarshal(event.Payload, &result)
@@ -0,0 +1,131 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dyxj/chess/pkg/room"
+	"github.com/dyxj/chess/test/testx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomConnectHandler_Narration(t *testing.T) {
+	go testx.SetTimeout(t.Context(), 10*time.Second)
+
+	logger := testx.GlobalEnv().Logger()
+
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	c := testx.GlobalEnv().RoomCoordinator()
+
+	code, wToken, bToken, err := createRoomAndTokens(c)
+	require.NoError(t, err)
+	logger.Printf("code: %s, wToken: %s, bToken: %s\n", code, wToken, bToken)
+
+	// black opts in to spanish narration, white does not
+	bEventChan, bConn, err := websocketDialAndListen(
+		fmt.Sprintf(connectURLFormat+"&narration=es", testSvr.Listener.Addr().String(), bToken),
+		logger,
+	)
+	require.NoError(t, err)
+	defer bConn.Close()
+
+	b1, ok := <-bEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeMessage, b1.EventType)
+
+	wEventChan, wConn, err := websocketDialAndListen(
+		fmt.Sprintf(connectURLFormat, testSvr.Listener.Addr().String(), wToken),
+		logger,
+	)
+	require.NoError(t, err)
+	defer wConn.Close()
+
+	for _, eventChan := range []chan room.EventPartial{wEventChan, bEventChan} {
+		e, ok := <-eventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoomReady, e.EventType)
+
+		e, ok = <-eventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoundResult, e.EventType)
+		assert.Empty(t, extractNarration(t, e), "no move to narrate")
+	}
+
+	// positions are described in english without narration
+	err = writeAction(wConn, room.NewActionDescribe(room.DescribeByRank))
+	require.NoError(t, err)
+
+	we, ok := <-wEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeDescription, we.EventType)
+	var wep room.EventDescriptionPayload
+	require.NoError(t, json.Unmarshal(we.Payload, &wep))
+	assert.Contains(t, wep.Text, "Rank 1: white rook, white knight, white bishop")
+
+	err = writeAction(bConn, room.NewActionDescribe(""))
+	require.NoError(t, err)
+
+	be, ok := <-bEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeDescription, be.EventType)
+	var bep room.EventDescriptionPayload
+	require.NoError(t, json.Unmarshal(be.Payload, &bep))
+	assert.Contains(t, bep.Text, "Las negras: rey en e8, dama en d8")
+
+	err = writeAction(bConn, room.NewActionDescribe("diagonal"))
+	require.NoError(t, err)
+
+	be, ok = <-bEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeError, be.EventType)
+
+	expNarrations := []string{
+		"Peón blanco de g2 a g4",
+		"Peón negro de e7 a e6",
+		"Peón blanco de f2 a f3",
+		"Dama negra de d8 a h4, jaque mate",
+	}
+	mConn := wConn
+	for i, move := range quickestCheckmate() {
+		err := writeActionMove(mConn, move.Payload.Symbol, move.Payload.From, move.Payload.To)
+		require.NoError(t, err, fmt.Sprintf("move %d failed", i))
+		if mConn == wConn {
+			mConn = bConn
+		} else {
+			mConn = wConn
+		}
+
+		wm, ok := <-wEventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoundResult, wm.EventType)
+		assert.Empty(t, extractNarration(t, wm), fmt.Sprintf("move %d", i))
+
+		bm, ok := <-bEventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoundResult, bm.EventType)
+		assert.Equal(t, expNarrations[i], extractNarration(t, bm), fmt.Sprintf("move %d", i))
+	}
+}
+
+func TestRoomConnectHandler_ShouldReturnBadRequest_UnknownNarration(t *testing.T) {
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/room/connect?token=abc&narration=xx",
+		testSvr.Listener.Addr().String()))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func extractNarration(t *testing.T, event room.EventPartial) string {
+	t.Helper()
+	var p room.EventRoundPayload
+	require.NoError(t, json.Unmarshal(event.Payload, &p))
+	return p.Narration
+}
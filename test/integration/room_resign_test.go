@@ -0,0 +1,141 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/room"
+	"github.com/dyxj/chess/test/testx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requireGameOver each player receives an event of eventType and is then disconnected
+func requireGameOver(t *testing.T, eventChans map[engine.Color]chan room.EventPartial, eventType room.EventType) []room.EventPartial {
+	t.Helper()
+	var events []room.EventPartial
+	for color, eventChan := range eventChans {
+		e, ok := <-eventChan
+		require.True(t, ok, color.String())
+		require.Equal(t, eventType, e.EventType, color.String())
+		events = append(events, e)
+
+		_, ok = <-eventChan
+		require.False(t, ok, "%v should be disconnected", color)
+	}
+	return events
+}
+
+func TestRoomConnectHandler_ActionResign(t *testing.T) {
+	go testx.SetTimeout(t.Context(), 10*time.Second)
+
+	c := testx.GlobalEnv().RoomCoordinator()
+
+	code, wToken, bToken, err := createRoomAndTokens(c)
+	require.NoError(t, err)
+
+	eventChans, conns, _ := connectPlayers(t, wToken, bToken)
+
+	// 1. e4
+	require.NoError(t, writeActionMove(conns[engine.White], engine.Pawn, new(12), new(28)))
+	for _, eventChan := range eventChans {
+		e, ok := <-eventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoundResult, e.EventType)
+	}
+
+	require.NoError(t, writeAction(conns[engine.Black], room.NewActionResign()))
+	for _, e := range requireGameOver(t, eventChans, room.EventTypeResign) {
+		var p room.EventResignPayload
+		require.NoError(t, json.Unmarshal(e.Payload, &p))
+		assert.Equal(t, room.EventResignPayload{Resigner: engine.Black, Winner: engine.White}, p)
+	}
+
+	r, exist := room.NewMemCache(testx.GlobalEnv().MemCache()).Find(code)
+	require.True(t, exist)
+	assert.Equal(t, game.StateBlackResign, r.Game.State())
+	assert.Equal(t, engine.White, r.Game.Winner())
+}
+
+func TestRoomConnectHandler_ActionAbort(t *testing.T) {
+	go testx.SetTimeout(t.Context(), 10*time.Second)
+
+	c := testx.GlobalEnv().RoomCoordinator()
+
+	code, wToken, bToken, err := createRoomAndTokens(c)
+	require.NoError(t, err)
+
+	eventChans, conns, _ := connectPlayers(t, wToken, bToken)
+
+	// 1. e4, black may still abort
+	require.NoError(t, writeActionMove(conns[engine.White], engine.Pawn, new(12), new(28)))
+	for _, eventChan := range eventChans {
+		e, ok := <-eventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoundResult, e.EventType)
+	}
+
+	require.NoError(t, writeAction(conns[engine.Black], room.NewActionAbort()))
+	for _, e := range requireGameOver(t, eventChans, room.EventTypeAbort) {
+		var p room.EventAbortPayload
+		require.NoError(t, json.Unmarshal(e.Payload, &p))
+		assert.Equal(t, room.EventAbortPayload{Aborter: engine.Black}, p)
+	}
+
+	r, exist := room.NewMemCache(testx.GlobalEnv().MemCache()).Find(code)
+	require.True(t, exist)
+	assert.Equal(t, game.StateAborted, r.Game.State())
+	assert.Zero(t, r.Game.Winner())
+
+	status, _ := getAnalysis(t, testx.GlobalEnv().HTTTPTestServer().Listener.Addr().String(), code)
+	assert.Equal(t, http.StatusBadRequest, status, "aborted games are not analyzed")
+}
+
+func TestRoomConnectHandler_ActionAbortAfterBothMoved(t *testing.T) {
+	go testx.SetTimeout(t.Context(), 10*time.Second)
+
+	c := testx.GlobalEnv().RoomCoordinator()
+
+	_, wToken, bToken, err := createRoomAndTokens(c)
+	require.NoError(t, err)
+
+	eventChans, conns, _ := connectPlayers(t, wToken, bToken)
+
+	moves := []room.ActionMove{
+		room.NewActionMove(engine.Pawn, new(12), new(28)), // 1. e4   (e2->e4)
+		room.NewActionMove(engine.Pawn, new(52), new(36)), // 1... e5 (e7->e5)
+	}
+	for i, move := range moves {
+		conn := conns[engine.White]
+		if i%2 == 1 {
+			conn = conns[engine.Black]
+		}
+		require.NoError(t, writeActionMove(conn, move.Payload.Symbol, move.Payload.From, move.Payload.To))
+		for _, eventChan := range eventChans {
+			e, ok := <-eventChan
+			require.True(t, ok)
+			require.Equal(t, room.EventTypeRoundResult, e.EventType)
+		}
+	}
+
+	require.NoError(t, writeAction(conns[engine.White], room.NewActionAbort()))
+	e, ok := <-eventChans[engine.White]
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeError, e.EventType)
+	var p room.EventErrorPayload
+	require.NoError(t, json.Unmarshal(e.Payload, &p))
+	assert.Equal(t, game.ErrAbortNotAllowed.Error(), p.Error)
+	assert.Equal(t, 2, p.LastValidMoveCount)
+
+	// the game goes on, white resigns instead
+	require.NoError(t, writeAction(conns[engine.White], room.NewActionResign()))
+	for _, e := range requireGameOver(t, eventChans, room.EventTypeResign) {
+		var p room.EventResignPayload
+		require.NoError(t, json.Unmarshal(e.Payload, &p))
+		assert.Equal(t, room.EventResignPayload{Resigner: engine.White, Winner: engine.Black}, p)
+	}
+}
@@ -0,0 +1,272 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dyxj/chess/pkg/engine"
+	"github.com/dyxj/chess/pkg/game"
+	"github.com/dyxj/chess/pkg/room"
+	"github.com/dyxj/chess/test/testx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoomConnectHandler_CrazyhouseDrop(t *testing.T) {
+	go testx.SetTimeout(t.Context(), 10*time.Second)
+
+	logger := testx.GlobalEnv().Logger()
+
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	c := testx.GlobalEnv().RoomCoordinator()
+
+	code, wToken, bToken, err := createRoomAndTokens(c, room.Config{Variant: engine.VariantCrazyhouse})
+	require.NoError(t, err)
+	logger.Printf("code: %s, wToken: %s, bToken: %s\n", code, wToken, bToken)
+
+	bEventChan, bConn, err := websocketDialAndListen(
+		fmt.Sprintf(connectURLFormat, testSvr.Listener.Addr().String(), bToken),
+		logger,
+	)
+	require.NoError(t, err)
+	defer bConn.Close()
+
+	// Wait for black player to get "waiting" message
+	b1, ok := <-bEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeMessage, b1.EventType)
+
+	wEventChan, wConn, err := websocketDialAndListen(
+		fmt.Sprintf(connectURLFormat, testSvr.Listener.Addr().String(), wToken),
+		logger,
+	)
+	require.NoError(t, err)
+	defer wConn.Close()
+
+	w0, ok := <-wEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeRoomReady, w0.EventType)
+
+	var w0p room.EventRoomReadyPayload
+	err = json.Unmarshal(w0.Payload, &w0p)
+	require.NoError(t, err)
+	assert.Equal(t, engine.VariantCrazyhouse, w0p.Variant)
+
+	b0, ok := <-bEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeRoomReady, b0.EventType)
+
+	w1, ok := <-wEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeRoundResult, w1.EventType)
+
+	b2, ok := <-bEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeRoundResult, b2.EventType)
+
+	moves := []room.ActionMove{
+		room.NewActionMove(engine.Pawn, new(12), new(28)),   // 1. e4   (e2->e4)
+		room.NewActionMove(engine.Pawn, new(51), new(35)),   // 1... d5 (d7->d5)
+		room.NewActionMove(engine.Pawn, new(28), new(35)),   // 2. exd5 (e4->d5)
+		room.NewActionMove(engine.Knight, new(62), new(45)), // 2... Nf6 (g8->f6)
+	}
+	mConn := wConn
+	for i, move := range moves {
+		err := writeActionMove(mConn, move.Payload.Symbol, move.Payload.From, move.Payload.To)
+		require.NoError(t, err, fmt.Sprintf("move %d failed", i))
+		if mConn == wConn {
+			mConn = bConn
+		} else {
+			mConn = wConn
+		}
+		wm, okwm := <-wEventChan
+		require.True(t, okwm)
+		require.Equal(t, room.EventTypeRoundResult, wm.EventType, fmt.Sprintf("unexpected event type for move %d", i))
+		bm, okbm := <-bEventChan
+		require.True(t, okbm)
+		require.Equal(t, room.EventTypeRoundResult, bm.EventType, fmt.Sprintf("unexpected event type for move %d", i))
+	}
+
+	// drop on an occupied square is rejected
+	err = writeActionDrop(wConn, engine.Pawn, new(60))
+	require.NoError(t, err)
+
+	we, ok := <-wEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeError, we.EventType)
+
+	var wep room.EventErrorPayload
+	err = json.Unmarshal(we.Payload, &wep)
+	require.NoError(t, err)
+	assert.Equal(t, string(engine.IllegalDropOccupied), wep.Code)
+
+	// P@e6
+	err = writeActionDrop(wConn, engine.Pawn, new(44))
+	require.NoError(t, err)
+
+	for _, eventChan := range []chan room.EventPartial{wEventChan, bEventChan} {
+		e, ok := <-eventChan
+		require.True(t, ok)
+		rr, err := extractRoundResult(e)
+		require.NoError(t, err)
+
+		require.NotNil(t, rr.MoveResult)
+		assert.True(t, rr.MoveResult.IsDrop)
+		assert.Equal(t, 44, rr.MoveResult.To)
+		assert.Equal(t, 1, rr.Grid[44])
+		assert.Empty(t, rr.Pockets[engine.White])
+		assert.Empty(t, rr.Pockets[engine.Black])
+		assert.Equal(t, 5, rr.Count)
+	}
+}
+
+func TestRoomConnectHandler_DarkChessViews(t *testing.T) {
+	go testx.SetTimeout(t.Context(), 10*time.Second)
+
+	logger := testx.GlobalEnv().Logger()
+
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	c := testx.GlobalEnv().RoomCoordinator()
+
+	code, wToken, bToken, err := createRoomAndTokens(c, room.Config{Variant: engine.VariantDarkChess})
+	require.NoError(t, err)
+	logger.Printf("code: %s, wToken: %s, bToken: %s\n", code, wToken, bToken)
+
+	bEventChan, bConn, err := websocketDialAndListen(
+		fmt.Sprintf(connectURLFormat, testSvr.Listener.Addr().String(), bToken),
+		logger,
+	)
+	require.NoError(t, err)
+	defer bConn.Close()
+
+	b1, ok := <-bEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeMessage, b1.EventType)
+
+	wEventChan, wConn, err := websocketDialAndListen(
+		fmt.Sprintf(connectURLFormat, testSvr.Listener.Addr().String(), wToken),
+		logger,
+	)
+	require.NoError(t, err)
+	defer wConn.Close()
+
+	for _, eventChan := range []chan room.EventPartial{wEventChan, bEventChan} {
+		e, ok := <-eventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoomReady, e.EventType)
+	}
+
+	// initial round is masked per color
+	w1, ok := <-wEventChan
+	require.True(t, ok)
+	wr, err := extractRoundResult(w1)
+	require.NoError(t, err)
+	assert.Equal(t, game.HiddenCell, wr.Grid[60], "e8 hidden from white")
+	assert.Equal(t, 6, wr.Grid[4])
+
+	b2, ok := <-bEventChan
+	require.True(t, ok)
+	br, err := extractRoundResult(b2)
+	require.NoError(t, err)
+	assert.Equal(t, game.HiddenCell, br.Grid[4], "e1 hidden from black")
+	assert.Equal(t, -6, br.Grid[60])
+
+	moves := []room.ActionMove{
+		room.NewActionMove(engine.Pawn, new(12), new(28)),  // 1. e4   (e2->e4)
+		room.NewActionMove(engine.Pawn, new(53), new(45)),  // 1... f6 (f7->f6)
+		room.NewActionMove(engine.Queen, new(3), new(39)),  // 2. Qh5  (d1->h5)
+		room.NewActionMove(engine.Pawn, new(48), new(40)),  // 2... a6 (a7->a6)
+		room.NewActionMove(engine.Queen, new(39), new(60)), // 3. Qxe8 (h5->e8)
+	}
+	mConn := wConn
+	for i, move := range moves {
+		err := writeActionMove(mConn, move.Payload.Symbol, move.Payload.From, move.Payload.To)
+		require.NoError(t, err, fmt.Sprintf("move %d failed", i))
+		if mConn == wConn {
+			mConn = bConn
+		} else {
+			mConn = wConn
+		}
+
+		wm, okwm := <-wEventChan
+		require.True(t, okwm)
+		wr, err = extractRoundResult(wm)
+		require.NoError(t, err, fmt.Sprintf("unexpected event for move %d", i))
+
+		bm, okbm := <-bEventChan
+		require.True(t, okbm)
+		br, err = extractRoundResult(bm)
+		require.NoError(t, err, fmt.Sprintf("unexpected event for move %d", i))
+
+		if i == 2 {
+			// Qh5 is out of sight for black
+			assert.Nil(t, br.MoveResult)
+			assert.Equal(t, game.HiddenCell, br.Grid[39])
+			assert.Equal(t, 5, wr.Grid[39])
+		}
+	}
+
+	// king captured, full board revealed to both
+	for _, rr := range []game.RoundResult{wr, br} {
+		assert.Equal(t, game.StateKingCaptured, rr.State)
+		require.NotNil(t, rr.MoveResult)
+		assert.Equal(t, 39, rr.MoveResult.From)
+		assert.Equal(t, 60, rr.MoveResult.To)
+		assert.Equal(t, engine.King, rr.MoveResult.Captured)
+		assert.Equal(t, -1, rr.Grid[40])
+		assert.NotContains(t, rr.Grid, game.HiddenCell)
+	}
+}
+
+func TestRoomConnectHandler_OddsStartingPosition(t *testing.T) {
+	go testx.SetTimeout(t.Context(), 10*time.Second)
+
+	logger := testx.GlobalEnv().Logger()
+
+	testSvr := testx.GlobalEnv().HTTTPTestServer()
+
+	c := testx.GlobalEnv().RoomCoordinator()
+
+	code, wToken, bToken, err := createRoomAndTokens(c, room.Config{Odds: engine.OddsQueen})
+	require.NoError(t, err)
+	logger.Printf("code: %s, wToken: %s, bToken: %s\n", code, wToken, bToken)
+
+	bEventChan, bConn, err := websocketDialAndListen(
+		fmt.Sprintf(connectURLFormat, testSvr.Listener.Addr().String(), bToken),
+		logger,
+	)
+	require.NoError(t, err)
+	defer bConn.Close()
+
+	b1, ok := <-bEventChan
+	require.True(t, ok)
+	require.Equal(t, room.EventTypeMessage, b1.EventType)
+
+	wEventChan, wConn, err := websocketDialAndListen(
+		fmt.Sprintf(connectURLFormat, testSvr.Listener.Addr().String(), wToken),
+		logger,
+	)
+	require.NoError(t, err)
+	defer wConn.Close()
+
+	for _, eventChan := range []chan room.EventPartial{wEventChan, bEventChan} {
+		e, ok := <-eventChan
+		require.True(t, ok)
+		require.Equal(t, room.EventTypeRoomReady, e.EventType)
+
+		var p room.EventRoomReadyPayload
+		require.NoError(t, json.Unmarshal(e.Payload, &p))
+		assert.Equal(t, engine.OddsQueen.FEN(), p.FEN)
+
+		e, ok = <-eventChan
+		require.True(t, ok)
+		rr, err := extractRoundResult(e)
+		require.NoError(t, err)
+		assert.Equal(t, 0, rr.Grid[3], "white queen removed")
+		assert.Equal(t, -5, rr.Grid[59])
+	}
+}
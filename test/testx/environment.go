@@ -24,6 +24,7 @@ type Environment struct {
 	httptestServer  *httptest.Server
 	memCache        *store.MemCache
 	roomCoordinator *room.Coordinator
+	correspondence  *room.Correspondence
 
 	runOnce   sync.Once
 	closeOnce sync.Once
@@ -53,6 +54,10 @@ func (e *Environment) RoomCoordinator() *room.Coordinator {
 	return e.roomCoordinator
 }
 
+func (e *Environment) Correspondence() *room.Correspondence {
+	return e.correspondence
+}
+
 func (e *Environment) Run() (<-chan struct{}, <-chan error) {
 	e.runOnce.Do(func() {
 		e.logger.Printf("starting environment")
@@ -84,9 +89,15 @@ func (e *Environment) run(ready chan struct{}, errorChan chan error) {
 		logger,
 		30*time.Second,
 		room.NewMemCache(e.memCache),
+		room.NewAdjournMemStore(e.memCache),
+	)
+
+	e.correspondence = room.NewCorrespondence(
+		logger,
+		room.NewCorrespondenceMemStore(e.memCache),
 	)
 
-	httptestServer, err := e.buildHttpTestServer(e.roomCoordinator)
+	httptestServer, err := e.buildHttpTestServer(e.roomCoordinator, e.correspondence)
 	if err != nil {
 		errorChan <- err
 		return
@@ -96,7 +107,10 @@ func (e *Environment) run(ready chan struct{}, errorChan chan error) {
 	close(ready)
 }
 
-func (e *Environment) buildHttpTestServer(roomCoordinator *room.Coordinator) (*httptest.Server, error) {
+func (e *Environment) buildHttpTestServer(
+	roomCoordinator *room.Coordinator,
+	correspondence *room.Correspondence,
+) (*httptest.Server, error) {
 	logger, err := zap.NewDevelopment()
 	if err != nil {
 		return nil, err
@@ -105,6 +119,7 @@ func (e *Environment) buildHttpTestServer(roomCoordinator *room.Coordinator) (*h
 	router := server.BuildRouter(
 		logger,
 		roomCoordinator,
+		correspondence,
 		false,
 	)
 